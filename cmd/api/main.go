@@ -8,7 +8,10 @@ import (
 	"algoforces/internal/repository/postgres"
 	"algoforces/internal/services"
 	"algoforces/pkg/database"
+	"algoforces/pkg/judge0"
 	"algoforces/pkg/queue"
+	"algoforces/pkg/storage"
+	"context"
 	"fmt"
 	"log"
 
@@ -39,12 +42,33 @@ func main() {
 	}
 	defer db.Close()
 
-	// Run migrations
-	err = db.AutoMigrate(&domain.User{}, &domain.Contest{}, &domain.ContestRegistration{}, &domain.Problem{}, &domain.TestCase{}, &domain.Submission{})
+	// Run migrations, test cases sharing an order position are renumbered before it becomes unique
+	// and problems of unfinished contests stay hidden when visibility is added
+	err = postgres.MigrateTestCaseOrder(context.Background(), db.DB)
+	if err != nil {
+		log.Fatal("Failed to migrate test case order:", err)
+	}
+	err = postgres.MigrateProblemVisibility(context.Background(), db.DB)
+	if err != nil {
+		log.Fatal("Failed to migrate problem visibility:", err)
+	}
+	err = db.AutoMigrate(&domain.User{}, &domain.Contest{}, &domain.ContestRegistration{}, &domain.Problem{}, &domain.TestCase{}, &domain.Submission{}, &domain.ContestProblem{}, &domain.VirtualParticipation{}, &domain.Team{}, &domain.TeamMember{}, &domain.Clarification{}, &domain.ContestSeries{}, &domain.PlagiarismCase{}, &domain.ProblemRevision{}, &domain.ProblemRevisionTest{}, &domain.ProblemAttachment{}, &domain.ProblemTranslation{}, &domain.ReferenceSolution{}, &domain.ProblemGenerator{}, &domain.TestGeneration{})
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
+	blobStorage, err := storage.NewLocalStorage(conf.STORAGE_DIR)
+	if err != nil {
+		log.Fatal("Failed to initialize blob storage:", err)
+	}
+	testData := storage.NewContentStore(blobStorage, domain.TestDataPrefix)
+
+	// Test data used to be stored in Postgres
+	err = postgres.MigrateTestCaseData(context.Background(), db.DB, testData)
+	if err != nil {
+		log.Fatal("Failed to migrate test case data:", err)
+	}
+
 	// Initialiaze queue
 	submissionQueue, err := queue.NewSubmissionQueue(conf.REDIS_URL)
 	if err != nil {
@@ -53,22 +77,71 @@ func main() {
 
 	defer submissionQueue.Close()
 
+	plagiarismQueue, err := queue.NewPlagiarismQueue(conf.REDIS_URL)
+	if err != nil {
+		log.Fatal("Failed to initialize plagiarism queue:", err)
+	}
+
+	defer plagiarismQueue.Close()
+
+	referenceSolutionQueue, err := queue.NewReferenceSolutionQueue(conf.REDIS_URL)
+	if err != nil {
+		log.Fatal("Failed to initialize reference solution queue:", err)
+	}
+
+	defer referenceSolutionQueue.Close()
+
+	testGenerationQueue, err := queue.NewTestGenerationQueue(conf.REDIS_URL)
+	if err != nil {
+		log.Fatal("Failed to initialize test generation queue:", err)
+	}
+
+	defer testGenerationQueue.Close()
+
+	testSetNotifier, err := queue.NewTestSetNotifier(conf.REDIS_URL)
+	if err != nil {
+		log.Fatal("Failed to initialize test set notifier:", err)
+	}
+
+	defer testSetNotifier.Close()
+
 	// 2. Initialize dependencies
 	userRepo := postgres.NewUserRepository(db.DB)
 	adminRepo := postgres.NewAdminRepository(db.DB)
 	contestRepo := postgres.NewContestRepository(db.DB)
 	contestRegisterRepo := postgres.NewContestRegisterRepository(db.DB)
 	problemRepo := postgres.NewProblemRepository(db.DB)
-	testCaseRepo := postgres.NewTestCaseRepository(db.DB)
+	testCaseRepo := postgres.NewTestCaseRepository(db.DB, testData)
 	submissionRepo := postgres.NewSubmissionRepository(db.DB)
+	virtualParticipationRepo := postgres.NewVirtualParticipationRepository(db.DB)
+	teamRepo := postgres.NewTeamRepository(db.DB)
+	clarificationRepo := postgres.NewClarificationRepository(db.DB)
+	contestSeriesRepo := postgres.NewContestSeriesRepository(db.DB)
+	plagiarismRepo := postgres.NewPlagiarismRepository(db.DB)
+	problemRevisionRepo := postgres.NewProblemRevisionRepository(db.DB)
+	problemAttachmentRepo := postgres.NewProblemAttachmentRepository(db.DB)
+	problemTranslationRepo := postgres.NewProblemTranslationRepository(db.DB)
+	referenceSolutionRepo := postgres.NewReferenceSolutionRepository(db.DB)
+	testGeneratorRepo := postgres.NewTestGeneratorRepository(db.DB)
+	unitOfWork := postgres.NewUnitOfWork(db.DB, testData)
 
 	authService := services.NewAuthService(userRepo)
 	adminService := services.NewAdminService(adminRepo)
-	contestService := services.NewContestService(contestRepo, userRepo)
-	contestRegisterService := services.NewContestRegisterService(contestRegisterRepo, contestRepo, userRepo)
-	problemService := services.NewProblemService(problemRepo, userRepo)
-	testCaseService := services.NewTestCaseService(testCaseRepo)
-	submissionService := services.NewSubmissionService(submissionRepo, submissionQueue)
+	contestService := services.NewContestService(contestRepo, userRepo, problemRepo, contestSeriesRepo, contestRegisterRepo, unitOfWork)
+	contestRegisterService := services.NewContestRegisterService(contestRegisterRepo, contestRepo, userRepo, teamRepo, contestSeriesRepo, unitOfWork)
+	problemService := services.NewProblemService(problemRepo, userRepo, contestRepo, contestRegisterRepo, testCaseRepo, problemAttachmentRepo, problemTranslationRepo, unitOfWork, blobStorage)
+	testCaseService := services.NewTestCaseService(testCaseRepo, problemRepo, userRepo, contestRepo, contestRegisterRepo, problemRevisionRepo, unitOfWork, judge0.NewInputValidator(conf.JUDGE0_URL), testSetNotifier)
+	submissionService := services.NewSubmissionService(submissionRepo, contestRepo, contestRegisterRepo, virtualParticipationRepo, problemRepo, problemRevisionRepo, testCaseRepo, submissionQueue)
+	standingsService := services.NewStandingsService(contestRepo, contestRegisterRepo, problemRepo, submissionRepo, userRepo, virtualParticipationRepo, teamRepo)
+	virtualParticipationService := services.NewVirtualParticipationService(virtualParticipationRepo, contestRepo, submissionRepo, userRepo, contestRegisterRepo)
+	teamService := services.NewTeamService(teamRepo, userRepo)
+	clarificationService := services.NewClarificationService(clarificationRepo, contestRepo, contestRegisterRepo, userRepo)
+	contestSeriesService := services.NewContestSeriesService(contestSeriesRepo, contestRepo)
+	plagiarismService := services.NewPlagiarismService(plagiarismRepo, contestRepo, contestRegisterRepo, plagiarismQueue, unitOfWork)
+	problemRevisionService := services.NewProblemRevisionService(problemRepo, userRepo, contestRepo, contestRegisterRepo, problemRevisionRepo, testCaseRepo, unitOfWork)
+	problemPackageService := services.NewProblemPackageService(problemRepo, testCaseRepo, userRepo, contestRepo, contestRegisterRepo, problemRevisionRepo)
+	referenceSolutionService := services.NewReferenceSolutionService(referenceSolutionRepo, problemRepo, userRepo, contestRepo, contestRegisterRepo, problemRevisionRepo, testCaseRepo, referenceSolutionQueue, conf.TIME_LIMIT_FACTOR)
+	testGeneratorService := services.NewTestGeneratorService(testGeneratorRepo, problemRepo, userRepo, contestRepo, contestRegisterRepo, referenceSolutionRepo, testGenerationQueue)
 
 	authHandler := handlers.NewAuthHandler(authService)
 	userHandler := handlers.NewUserHandler(authService)
@@ -78,6 +151,16 @@ func main() {
 	problemHandler := handlers.NewProblemHandler(problemService)
 	testCaseHandler := handlers.NewTestCaseHandler(testCaseService)
 	submissionHandler := handlers.NewSubmissionHandler(submissionService)
+	standingsHandler := handlers.NewStandingsHandler(standingsService)
+	virtualParticipationHandler := handlers.NewVirtualParticipationHandler(virtualParticipationService)
+	teamHandler := handlers.NewTeamHandler(teamService)
+	clarificationHandler := handlers.NewClarificationHandler(clarificationService)
+	contestSeriesHandler := handlers.NewContestSeriesHandler(contestSeriesService)
+	plagiarismHandler := handlers.NewPlagiarismHandler(plagiarismService)
+	problemRevisionHandler := handlers.NewProblemRevisionHandler(problemRevisionService)
+	problemPackageHandler := handlers.NewProblemPackageHandler(problemPackageService)
+	referenceSolutionHandler := handlers.NewReferenceSolutionHandler(referenceSolutionService)
+	testGeneratorHandler := handlers.NewTestGeneratorHandler(testGeneratorService)
 	// 3. Setup router
 	r := gin.Default()
 
@@ -113,7 +196,13 @@ func main() {
 		admin.GET("/admins", adminHandler.GetAdmins)
 		admin.GET("/problem-setters", adminHandler.GetProblemSetters)
 		admin.GET("/contests", contestHandler.GetAllContests)
+		admin.GET("/contests/:id/export", standingsHandler.ExportContestParticipants)
+		admin.GET("/contests/:id/plagiarism", plagiarismHandler.GetContestCases)
+		admin.POST("/contests/:id/plagiarism/check", plagiarismHandler.RequestCheck)
+		admin.POST("/plagiarism/review", plagiarismHandler.ReviewCase)
 		admin.GET("/registrations", contestRegisterHandler.GetAllRegistrationsForAdmin)
+		admin.POST("/registrations/approve", contestRegisterHandler.ApproveRegistrations)
+		admin.POST("/registrations/reject", contestRegisterHandler.RejectRegistrations)
 	}
 
 	// Contest routes (protected + admin/problem-setter role required)
@@ -121,18 +210,57 @@ func main() {
 	contest.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("admin"))
 	{
 		contest.POST("/create", middleware.RoleMiddleware("admin", "problem-setter"), contestHandler.CreateContest)
-		contest.GET("/:id", contestHandler.GetContestDetails)
 		contest.PUT("/update", middleware.RoleMiddleware("admin", "problem-setter"), contestHandler.UpdateContest)
 		contest.DELETE("/:id", middleware.RoleMiddleware("admin"), contestHandler.DeleteContest)
+		contest.POST("/:id/problems", contestHandler.AddProblemToContest)
+		contest.DELETE("/:id/problems/:problemId", contestHandler.RemoveProblemFromContest)
+		contest.POST("/series/create", contestSeriesHandler.CreateSeries)
+		contest.PUT("/series/update", contestSeriesHandler.UpdateSeries)
+		contest.GET("/series/all", contestSeriesHandler.GetAllSeries)
+		contest.GET("/series/:seriesId", contestSeriesHandler.GetSeries)
 	}
 
 	// Contest registration routes (protected)
 	contestRegistration := r.Group("/api/contest")
-	contestRegistration.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("user", "admin"))
+	contestRegistration.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("user", "admin", "problem_setter"))
 	{
 		contestRegistration.POST("/register", contestRegisterHandler.RegisterContest)
 		contestRegistration.POST("/unregister", contestRegisterHandler.UnregisterContest)
 		contestRegistration.GET("/registrations", contestRegisterHandler.GetAllRegistrations)
+		contestRegistration.GET("/list", contestHandler.GetVisibleContests)
+		contestRegistration.GET("/:id", contestHandler.GetContestDetails)
+		contestRegistration.GET("/:id/problems", contestHandler.GetContestProblems)
+		contestRegistration.POST("/window/start", contestRegisterHandler.StartContestWindow)
+		contestRegistration.POST("/team/register", contestRegisterHandler.RegisterTeamContest)
+		contestRegistration.POST("/team/unregister", contestRegisterHandler.UnregisterTeamContest)
+		contestRegistration.POST("/virtual/start", virtualParticipationHandler.StartVirtualParticipation)
+		contestRegistration.GET("/:id/virtual", virtualParticipationHandler.GetVirtualParticipation)
+		contestRegistration.GET("/:id/standings", standingsHandler.GetContestStandings)
+		contestRegistration.GET("/:id/standings/virtual", standingsHandler.GetVirtualStandings)
+	}
+
+	// Contest clarification routes (protected, answering is checked against the contest problem setters)
+	clarification := r.Group("/api/contest/:id/clarifications")
+	clarification.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("user", "admin", "problem_setter"))
+	{
+		clarification.POST("", clarificationHandler.AskClarification)
+		clarification.GET("", clarificationHandler.GetClarifications)
+		clarification.GET("/stream", clarificationHandler.StreamClarifications)
+		clarification.POST("/announcements", clarificationHandler.CreateAnnouncement)
+		clarification.POST("/:clarificationId/answer", clarificationHandler.AnswerClarification)
+	}
+
+	// Team routes (protected)
+	team := r.Group("/api/team")
+	team.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("user", "admin", "problem_setter"))
+	{
+		team.POST("/create", teamHandler.CreateTeam)
+		team.POST("/join", teamHandler.JoinTeam)
+		team.GET("/my", teamHandler.GetMyTeams)
+		team.GET("/:id", teamHandler.GetTeam)
+		team.POST("/:id/leave", teamHandler.LeaveTeam)
+		team.DELETE("/:id/members/:userId", teamHandler.RemoveTeamMember)
+		team.POST("/:id/invite-code", teamHandler.RegenerateInviteCode)
 	}
 
 	// Problem routes (protected)
@@ -141,8 +269,38 @@ func main() {
 	{
 		problem.POST("/create", middleware.RoleMiddleware("admin", "problem_setter"), problemHandler.CreateProblem)
 		problem.POST("/bulk", middleware.RoleMiddleware("admin", "problem_setter"), problemHandler.CreateProblemsInBulk)
+		problem.POST("/import", middleware.RoleMiddleware("admin", "problem_setter"), problemPackageHandler.ImportPackage)
 		problem.GET("/all", problemHandler.GetAllProblems)
+		problem.GET("/tags", problemHandler.GetAllTags)
 		problem.GET("/:id", problemHandler.GetProblemByID)
+		problem.GET("/:id/statement", problemHandler.GetProblemStatement)
+		problem.POST("/:id/attachments", middleware.RoleMiddleware("admin", "problem_setter"), problemHandler.UploadAttachment)
+		problem.GET("/:id/attachments/:name", problemHandler.GetAttachment)
+		problem.DELETE("/:id/attachments/:name", middleware.RoleMiddleware("admin", "problem_setter"), problemHandler.DeleteAttachment)
+		problem.GET("/:id/translations", problemHandler.GetTranslations)
+		problem.PUT("/:id/translations/:locale", middleware.RoleMiddleware("admin", "problem_setter"), problemHandler.SaveTranslation)
+		problem.DELETE("/:id/translations/:locale", middleware.RoleMiddleware("admin", "problem_setter"), problemHandler.DeleteTranslation)
+		problem.GET("/:id/export", middleware.RoleMiddleware("admin", "problem_setter"), problemPackageHandler.ExportPackage)
+		problem.GET("/:id/revisions", middleware.RoleMiddleware("admin", "problem_setter"), problemRevisionHandler.GetRevisions)
+		problem.GET("/:id/revisions/diff", middleware.RoleMiddleware("admin", "problem_setter"), problemRevisionHandler.DiffRevisions)
+		problem.POST("/:id/revisions/rollback", middleware.RoleMiddleware("admin", "problem_setter"), problemRevisionHandler.RollbackProblem)
+		problem.GET("/:id/validator", middleware.RoleMiddleware("admin", "problem_setter"), testCaseHandler.GetValidator)
+		problem.PUT("/:id/validator", middleware.RoleMiddleware("admin", "problem_setter"), testCaseHandler.SetValidator)
+		problem.DELETE("/:id/validator", middleware.RoleMiddleware("admin", "problem_setter"), testCaseHandler.DeleteValidator)
+		problem.POST("/:id/validator/run", middleware.RoleMiddleware("admin", "problem_setter"), testCaseHandler.ValidateTestCases)
+		problem.POST("/:id/tests/archive", middleware.RoleMiddleware("admin", "problem_setter"), testCaseHandler.UploadTestArchive)
+		problem.GET("/:id/tests/archive", middleware.RoleMiddleware("admin", "problem_setter"), testCaseHandler.DownloadTestArchive)
+		problem.PUT("/:id/tests/order", middleware.RoleMiddleware("admin", "problem_setter"), testCaseHandler.ReorderTestCases)
+		problem.GET("/:id/generators", middleware.RoleMiddleware("admin", "problem_setter"), testGeneratorHandler.GetGenerators)
+		problem.POST("/:id/generators", middleware.RoleMiddleware("admin", "problem_setter"), testGeneratorHandler.SaveGenerator)
+		problem.DELETE("/:id/generators/:name", middleware.RoleMiddleware("admin", "problem_setter"), testGeneratorHandler.DeleteGenerator)
+		problem.POST("/:id/generate", middleware.RoleMiddleware("admin", "problem_setter"), testGeneratorHandler.GenerateTests)
+		problem.GET("/:id/generations/:generationId", middleware.RoleMiddleware("admin", "problem_setter"), testGeneratorHandler.GetGeneration)
+		problem.POST("/:id/solutions", middleware.RoleMiddleware("admin", "problem_setter"), referenceSolutionHandler.AddSolution)
+		problem.GET("/:id/solutions", middleware.RoleMiddleware("admin", "problem_setter"), referenceSolutionHandler.GetSolutions)
+		problem.POST("/:id/solutions/run", middleware.RoleMiddleware("admin", "problem_setter"), referenceSolutionHandler.RunSolutions)
+		problem.GET("/:id/solutions/report", middleware.RoleMiddleware("admin", "problem_setter"), referenceSolutionHandler.GetReport)
+		problem.DELETE("/:id/solutions/:solutionId", middleware.RoleMiddleware("admin", "problem_setter"), referenceSolutionHandler.DeleteSolution)
 		problem.PUT("/update", middleware.RoleMiddleware("admin", "problem_setter"), problemHandler.UpdateProblem)
 		problem.DELETE("/:id", middleware.RoleMiddleware("admin", "problem_setter"), problemHandler.DeleteProblem)
 	}
@@ -4,9 +4,13 @@ import (
 	"algoforces/internal/conf"
 	"algoforces/internal/domain"
 	"algoforces/internal/repository/postgres"
+	"algoforces/internal/services"
 	"algoforces/pkg/database"
+	"algoforces/pkg/judge0"
 	"algoforces/pkg/queue"
+	"algoforces/pkg/storage"
 	"algoforces/pkg/worker"
+	"context"
 	"log"
 
 	"github.com/hibiken/asynq"
@@ -20,17 +24,66 @@ func main() {
 	}
 	defer db.Close()
 
-	// Run migrations
-	err = db.AutoMigrate(&domain.User{}, &domain.Contest{}, &domain.ContestRegistration{}, &domain.Problem{}, &domain.TestCase{}, &domain.Submission{})
+	// Run migrations, test cases sharing an order position are renumbered before it becomes unique
+	// and problems of unfinished contests stay hidden when visibility is added
+	err = postgres.MigrateTestCaseOrder(context.Background(), db.DB)
+	if err != nil {
+		log.Fatal("Failed to migrate test case order:", err)
+	}
+	err = postgres.MigrateProblemVisibility(context.Background(), db.DB)
+	if err != nil {
+		log.Fatal("Failed to migrate problem visibility:", err)
+	}
+	err = db.AutoMigrate(&domain.User{}, &domain.Contest{}, &domain.ContestRegistration{}, &domain.Problem{}, &domain.TestCase{}, &domain.Submission{}, &domain.ContestProblem{}, &domain.VirtualParticipation{}, &domain.Team{}, &domain.TeamMember{}, &domain.Clarification{}, &domain.ContestSeries{}, &domain.PlagiarismCase{}, &domain.ProblemRevision{}, &domain.ProblemRevisionTest{}, &domain.ProblemAttachment{}, &domain.ProblemTranslation{}, &domain.ReferenceSolution{}, &domain.ProblemGenerator{}, &domain.TestGeneration{})
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
+	// Test data is read from the shared blob storage and cached on the local disk
+	blobStorage, err := storage.NewLocalStorage(conf.STORAGE_DIR)
+	if err != nil {
+		log.Fatal("Failed to initialize blob storage:", err)
+	}
+	testCacheStorage, err := storage.NewLocalStorage(conf.TEST_CACHE_DIR)
+	if err != nil {
+		log.Fatal("Failed to initialize test data cache:", err)
+	}
+	testData := storage.NewContentStore(blobStorage, domain.TestDataPrefix)
+	testCache := storage.NewContentStore(testCacheStorage, domain.TestDataPrefix)
+
+	testSetNotifier, err := queue.NewTestSetNotifier(conf.REDIS_URL)
+	if err != nil {
+		log.Fatal("Failed to initialize test set notifier:", err)
+	}
+	defer testSetNotifier.Close()
+
 	// Initialize repository
 	submissionRepo := postgres.NewSubmissionRepository(db.DB)
+	contestRepo := postgres.NewContestRepository(db.DB)
+	contestRegisterRepo := postgres.NewContestRegisterRepository(db.DB)
+	userRepo := postgres.NewUserRepository(db.DB)
+	plagiarismRepo := postgres.NewPlagiarismRepository(db.DB)
+	problemRepo := postgres.NewProblemRepository(db.DB)
+	problemRevisionRepo := postgres.NewProblemRevisionRepository(db.DB)
+	testCaseRepo := postgres.NewTestCaseRepository(db.DB, testData)
+	referenceSolutionRepo := postgres.NewReferenceSolutionRepository(db.DB)
+	testGeneratorRepo := postgres.NewTestGeneratorRepository(db.DB)
+	unitOfWork := postgres.NewUnitOfWork(db.DB, testData)
+
+	// Generated tests are stored through the test case service, which validates them and records a revision
+	testCaseService := services.NewTestCaseService(testCaseRepo, problemRepo, userRepo, contestRepo, contestRegisterRepo, problemRevisionRepo, unitOfWork, judge0.NewInputValidator(conf.JUDGE0_URL), testSetNotifier)
 
 	// Initialize Judge Worker
-	judgeWorker := worker.NewJudgeWorker(submissionRepo, conf.JUDGE0_URL)
+	judgeWorker := worker.NewJudgeWorker(submissionRepo, problemRevisionRepo, testData, testCache, int64(conf.TEST_SET_CACHE_MB)<<20, conf.JUDGE0_URL)
+	plagiarismWorker := worker.NewPlagiarismWorker(contestRepo, submissionRepo, plagiarismRepo)
+	problemPublishWorker := worker.NewProblemPublishWorker(problemRepo)
+	referenceSolutionWorker := worker.NewReferenceSolutionWorker(referenceSolutionRepo, problemRepo, problemRevisionRepo, testCaseRepo, conf.JUDGE0_URL)
+	testGenerationWorker := worker.NewTestGenerationWorker(testGeneratorRepo, problemRepo, referenceSolutionRepo, testCaseService, conf.JUDGE0_URL)
+
+	// Cached test sets are dropped when the test cases of their problem change
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go testSetNotifier.Subscribe(ctx, judgeWorker.InvalidateTestSet)
 
 	// Setup Asynq Server
 	redisOpt := asynq.RedisClientOpt{Addr: conf.REDIS_URL}
@@ -38,13 +91,40 @@ func main() {
 	srv := asynq.NewServer(redisOpt, asynq.Config{
 		Concurrency: 10,
 		Queues: map[string]int{
-			"submission": 10,
+			"submission":              10,
+			queue.PlagiarismQueueName: 1,
+			queue.ProblemQueueName:    1,
+			queue.ReferenceQueueName:  3,
+			queue.GenerationQueueName: 2,
 		},
 	})
 
 	// Register task handlers
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(queue.TypeSubmissionJudge, judgeWorker.JudgeSubmission)
+	mux.HandleFunc(queue.TypePlagiarismCheck, plagiarismWorker.CheckContest)
+	mux.HandleFunc(queue.TypePlagiarismSweep, plagiarismWorker.SweepEndedContests)
+	mux.HandleFunc(queue.TypeProblemPublish, problemPublishWorker.PublishEndedContestProblems)
+	mux.HandleFunc(queue.TypeReferenceSolutionJudge, referenceSolutionWorker.JudgeSolution)
+	mux.HandleFunc(queue.TypeTestGeneration, testGenerationWorker.GenerateTests)
+
+	// Ended contests are checked for plagiarism periodically
+	scheduler := asynq.NewScheduler(redisOpt, nil)
+	_, err = scheduler.Register("@every 15m", asynq.NewTask(queue.TypePlagiarismSweep, nil), asynq.Queue(queue.PlagiarismQueueName))
+	if err != nil {
+		log.Fatal("Failed to register plagiarism sweep:", err)
+	}
+
+	// Problems of ended contests are published to the practice archive
+	_, err = scheduler.Register("@every 1m", asynq.NewTask(queue.TypeProblemPublish, nil), asynq.Queue(queue.ProblemQueueName))
+	if err != nil {
+		log.Fatal("Failed to register problem publication:", err)
+	}
+
+	if err := scheduler.Start(); err != nil {
+		log.Fatal("Failed to start scheduler:", err)
+	}
+	defer scheduler.Shutdown()
 
 	// Start the server
 	log.Println("Starting Judge Worker...")
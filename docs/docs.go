@@ -131,26 +131,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/problem-setters": {
+        "/api/admin/contests/{id}/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of all problem setter users (admin only)",
+                "description": "Stream the final standings of a contest with the username, email, rank, score and per problem results of every participant, for prizes and certificates. Registered participants who did not submit are included with nothing solved. Team contests get one row per team member.",
                 "produces": [
+                    "text/csv",
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Standings"
+                ],
+                "summary": "Export contest participants (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get All Problem Setters",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.UserListResponse"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
                     "500": {
@@ -162,33 +195,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/registrations": {
+        "/api/admin/contests/{id}/plagiarism": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all contest registrations for all users (admin only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the suspicious submission pairs of a contest, most similar first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contest-registration"
+                    "Plagiarism"
+                ],
+                "summary": "Get plagiarism cases (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "suspected",
+                            "confirmed",
+                            "dismissed"
+                        ],
+                        "type": "string",
+                        "description": "Case status",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all contest registrations (Admin)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.AllRegisteredContestForUserResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.PlagiarismCase"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
@@ -196,14 +255,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/removerole": {
-            "put": {
+        "/api/admin/contests/{id}/plagiarism/check": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a role from a user",
+                "description": "Queue a similarity check of the accepted submissions of an ended contest. Ended contests are checked automatically, this runs the check again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plagiarism"
+                ],
+                "summary": "Run a plagiarism check (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/plagiarism/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm or dismiss a suspected case. Confirming disqualifies the users of the case (or only the given ones), removing them from the standings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -211,17 +310,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Plagiarism"
                 ],
-                "summary": "Remove Role from User",
+                "summary": "Review a plagiarism case (Admin)",
                 "parameters": [
                     {
-                        "description": "Remove Role Request",
-                        "name": "removeRoleRequest",
+                        "description": "Review decision",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.RemoveRoleRequest"
+                            "$ref": "#/definitions/domain.ReviewPlagiarismCaseRequest"
                         }
                     }
                 ],
@@ -229,7 +328,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.RemoveRoleResponse"
+                            "$ref": "#/definitions/domain.PlagiarismCase"
                         }
                     },
                     "400": {
@@ -237,31 +336,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/admin/users": {
+        "/api/admin/problem-setters": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of all users (admin only)",
+                "description": "Get list of all problem setter users (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Get All Users",
+                "summary": "Get All Problem Setters",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -278,9 +371,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/login": {
-            "post": {
-                "description": "Authenticate a user and return a token",
+        "/api/admin/registrations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all contest registrations for all users (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -288,31 +386,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
-                ],
-                "summary": "User Login",
-                "parameters": [
-                    {
-                        "description": "Login Request",
-                        "name": "loginRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.LoginRequest"
-                        }
-                    }
+                    "contest-registration"
                 ],
+                "summary": "Get all contest registrations (Admin)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.AuthResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
+                            "$ref": "#/definitions/domain.AllRegisteredContestForUserResponse"
                         }
                     },
                     "500": {
@@ -324,9 +405,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/signup": {
+        "/api/admin/registrations/approve": {
             "post": {
-                "description": "Register a new user",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve pending registrations of a contest, registrations over the contest capacity are waitlisted",
                 "consumes": [
                     "application/json"
                 ],
@@ -334,25 +420,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "contest-registration"
                 ],
-                "summary": "User Signup",
+                "summary": "Approve contest registrations (Admin)",
                 "parameters": [
                     {
-                        "description": "Signup Request",
-                        "name": "signupRequest",
+                        "description": "Registrations to approve",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.SignupRequest"
+                            "$ref": "#/definitions/domain.RegistrationDecisionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.AuthResponse"
+                            "$ref": "#/definitions/domain.RegistrationDecisionResponse"
                         }
                     },
                     "400": {
@@ -370,14 +456,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/contest/create": {
+        "/api/admin/registrations/reject": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new contest (admin or problem-setter only)",
+                "description": "Reject pending or waitlisted registrations of a contest",
                 "consumes": [
                     "application/json"
                 ],
@@ -385,25 +471,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Contest"
+                    "contest-registration"
                 ],
-                "summary": "Create a new Contest",
+                "summary": "Reject contest registrations (Admin)",
                 "parameters": [
                     {
-                        "description": "Create Contest Request",
-                        "name": "createContestRequest",
+                        "description": "Registrations to reject",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateContestRequest"
+                            "$ref": "#/definitions/domain.RegistrationDecisionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateContestResponse"
+                            "$ref": "#/definitions/domain.RegistrationDecisionResponse"
                         }
                     },
                     "400": {
@@ -421,14 +507,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/contest/register": {
-            "post": {
+        "/api/admin/removerole": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Register the current user for a contest",
+                "description": "Remove a role from a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -436,25 +522,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contest-registration"
+                    "Admin"
                 ],
-                "summary": "Register for a contest",
+                "summary": "Remove Role from User",
                 "parameters": [
                     {
-                        "description": "Contest registration request",
-                        "name": "request",
+                        "description": "Remove Role Request",
+                        "name": "removeRoleRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ContestRegisterRequest"
+                            "$ref": "#/definitions/domain.RemoveRoleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ContestRegisterResponse"
+                            "$ref": "#/definitions/domain.RemoveRoleResponse"
                         }
                     },
                     "400": {
@@ -472,29 +558,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/contest/registrations": {
+        "/api/admin/users": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all contests the current user is registered for",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get list of all users (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contest-registration"
+                    "Admin"
                 ],
-                "summary": "Get all contest registrations",
+                "summary": "Get All Users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.AllRegisteredContestForUserResponse"
+                            "$ref": "#/definitions/domain.UserListResponse"
                         }
                     },
                     "500": {
@@ -506,14 +589,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/contest/unregister": {
+        "/api/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Unregister the current user from a contest",
+                "description": "Authenticate a user and return a token",
                 "consumes": [
                     "application/json"
                 ],
@@ -521,17 +599,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contest-registration"
+                    "Auth"
                 ],
-                "summary": "Unregister from a contest",
+                "summary": "User Login",
                 "parameters": [
                     {
-                        "description": "Contest unregister request",
-                        "name": "request",
+                        "description": "Login Request",
+                        "name": "loginRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ContestUnregisterRequest"
+                            "$ref": "#/definitions/domain.LoginRequest"
                         }
                     }
                 ],
@@ -539,7 +617,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/utils.SuccessResponse"
+                            "$ref": "#/definitions/domain.AuthResponse"
                         }
                     },
                     "400": {
@@ -557,14 +635,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/contest/update": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update an existing contest (admin or problem-setter only)",
+        "/api/auth/signup": {
+            "post": {
+                "description": "Register a new user",
                 "consumes": [
                     "application/json"
                 ],
@@ -572,25 +645,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Contest"
+                    "Auth"
                 ],
-                "summary": "Update a Contest",
+                "summary": "User Signup",
                 "parameters": [
                     {
-                        "description": "Update Contest Request",
-                        "name": "updateContestRequest",
+                        "description": "Signup Request",
+                        "name": "signupRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateContestRequest"
+                            "$ref": "#/definitions/domain.SignupRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateContestResponse"
+                            "$ref": "#/definitions/domain.AuthResponse"
                         }
                     },
                     "400": {
@@ -608,33 +681,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/contest/{id}": {
-            "get": {
+        "/api/contest/create": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific contest by ID",
+                "description": "Create a new contest (admin or problem-setter only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Contest"
                 ],
-                "summary": "Get Contest Details",
+                "summary": "Create a new Contest",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Contest ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Create Contest Request",
+                        "name": "createContestRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateContestRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/domain.CreateContestResponse"
                         }
@@ -645,42 +723,83 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/contest/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a contest by ID (admin only)",
+                "description": "List the contests visible to the current user, unlisted contests only appear once registered",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Contest"
                 ],
-                "summary": "Delete a Contest",
+                "summary": "List Contests",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.CreateContestResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/contest/register": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register the current user for a contest",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contest-registration"
+                ],
+                "summary": "Register for a contest",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Contest ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Contest registration request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ContestRegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/utils.SuccessResponse"
+                            "$ref": "#/definitions/domain.ContestRegisterResponse"
                         }
                     },
                     "400": {
@@ -698,49 +817,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/health": {
+        "/api/contest/registrations": {
             "get": {
-                "description": "Get the health status of the API",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all contests the current user is registered for",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "contest-registration"
                 ],
-                "summary": "Health Check",
+                "summary": "Get all contest registrations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/domain.AllRegisteredContestForUserResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/problem/all": {
+        "/api/contest/series/all": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all problems (authenticated users only)",
+                "description": "Get all contest series",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Problem"
+                    "Contest"
                 ],
-                "summary": "Get All Problems",
+                "summary": "Get All Contest Series",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.ProblemCreationResponse"
+                                "$ref": "#/definitions/domain.ContestSeriesResponse"
                             }
                         }
                     },
@@ -753,14 +885,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/problem/bulk": {
+        "/api/contest/series/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create multiple problems at once (admin or problem-setter only)",
+                "description": "Create a series grouping parallel contests such as divisions (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -768,17 +900,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Problem"
+                    "Contest"
                 ],
-                "summary": "Create Multiple Problems in Bulk",
+                "summary": "Create a Contest Series",
                 "parameters": [
                     {
-                        "description": "Bulk Problem Creation Request",
-                        "name": "bulkRequest",
+                        "description": "Create Contest Series Request",
+                        "name": "createContestSeriesRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.BulkProblemCreationRequest"
+                            "$ref": "#/definitions/domain.CreateContestSeriesRequest"
                         }
                     }
                 ],
@@ -786,7 +918,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.BulkProblemCreationResponse"
+                            "$ref": "#/definitions/domain.ContestSeriesResponse"
                         }
                     },
                     "400": {
@@ -795,12 +927,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -810,14 +936,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/problem/create": {
-            "post": {
+        "/api/contest/series/update": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new problem (admin or problem-setter only)",
+                "description": "Update a contest series and its registration policy (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -825,25 +951,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Problem"
+                    "Contest"
                 ],
-                "summary": "Create a new Problem",
+                "summary": "Update a Contest Series",
                 "parameters": [
                     {
-                        "description": "Problem Creation Request",
-                        "name": "problemRequest",
+                        "description": "Update Contest Series Request",
+                        "name": "updateContestSeriesRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ProblemCreationRequest"
+                            "$ref": "#/definitions/domain.UpdateContestSeriesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ProblemCreationResponse"
+                            "$ref": "#/definitions/domain.ContestSeriesResponse"
                         }
                     },
                     "400": {
@@ -852,12 +978,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -867,40 +987,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/problem/update": {
-            "put": {
+        "/api/contest/series/{seriesId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing problem (admin or creator only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a contest series along with its contests",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Problem"
+                    "Contest"
                 ],
-                "summary": "Update a Problem",
+                "summary": "Get Contest Series",
                 "parameters": [
                     {
-                        "description": "Problem Update Request",
-                        "name": "problemRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ProblemUpdateRequest"
-                        }
+                        "type": "string",
+                        "description": "Contest Series ID",
+                        "name": "seriesId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ProblemUpdateResponse"
+                            "$ref": "#/definitions/domain.ContestSeriesResponse"
                         }
                     },
                     "400": {
@@ -909,60 +1024,53 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/problem/{id}": {
-            "get": {
+        "/api/contest/team/register": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a specific problem by its unique ID",
+                "description": "Register all members of a team for a team contest (captain only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Problem"
+                    "contest-registration"
                 ],
-                "summary": "Get Problem by ID",
+                "summary": "Register a team for a contest",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Problem ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Team contest registration request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TeamContestRegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.ProblemCreationResponse"
+                            "$ref": "#/definitions/domain.TeamContestRegisterResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
@@ -974,28 +1082,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/contest/team/unregister": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a problem by ID (admin or creator only)",
+                "description": "Unregister all members of a team from a team contest (captain only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Problem"
+                    "contest-registration"
                 ],
-                "summary": "Delete a Problem",
+                "summary": "Unregister a team from a contest",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Problem ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Team contest unregister request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TeamContestUnregisterRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1011,20 +1126,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
@@ -1032,14 +1135,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/submission/create": {
+        "/api/contest/unregister": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new code submission for evaluation",
+                "description": "Unregister the current user from a contest",
                 "consumes": [
                     "application/json"
                 ],
@@ -1047,37 +1150,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Submission"
+                    "contest-registration"
                 ],
-                "summary": "Create a new submission",
+                "summary": "Unregister from a contest",
                 "parameters": [
                     {
-                        "description": "Create Submission Request",
-                        "name": "createSubmissionRequest",
+                        "description": "Contest unregister request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateSubmissionRequest"
+                            "$ref": "#/definitions/domain.ContestUnregisterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.CreateSubmissionResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.SuccessResponse"
                         }
                     },
                     "400": {
@@ -1095,47 +1186,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/submission/{id}": {
-            "get": {
+        "/api/contest/update": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific submission by its ID",
+                "description": "Update an existing contest (admin or problem-setter only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Submission"
+                    "Contest"
                 ],
-                "summary": "Get submission details",
+                "summary": "Update a Contest",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Submission ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Update Contest Request",
+                        "name": "updateContestRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateContestRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.UpdateContestResponse"
                         }
                     },
                     "400": {
@@ -1144,8 +1228,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
@@ -1153,14 +1237,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/submission/{id}/update": {
-            "put": {
+        "/api/contest/virtual/start": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the status of a specific submission",
+                "description": "Replay an ended contest starting now, with the original contest duration",
                 "consumes": [
                     "application/json"
                 ],
@@ -1168,35 +1252,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Submission"
+                    "contest-registration"
                 ],
-                "summary": "Update submission status",
+                "summary": "Start a virtual participation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Submission ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Status Update Request",
+                        "description": "Start virtual participation request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.StartVirtualParticipationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/utils.SuccessResponse"
+                            "$ref": "#/definitions/domain.VirtualParticipationResponse"
                         }
                     },
                     "400": {
@@ -1214,14 +1288,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/testcase/bulk": {
+        "/api/contest/window/start": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates multiple test cases at once for a problem",
+                "description": "Start the personal window of a windowed contest, the user then has the contest duration to solve the problems",
                 "consumes": [
                     "application/json"
                 ],
@@ -1229,17 +1303,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TestCase"
+                    "contest-registration"
                 ],
-                "summary": "Upload test cases in bulk",
+                "summary": "Start contest window",
                 "parameters": [
                     {
-                        "description": "Bulk test case upload request",
+                        "description": "Start contest window request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.BulkTestCaseUploadRequest"
+                            "$ref": "#/definitions/domain.StartContestWindowRequest"
                         }
                     }
                 ],
@@ -1247,19 +1321,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.BulkTestCaseUploadResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.ContestRegisterResponse"
                         }
                     },
                     "400": {
@@ -1277,52 +1339,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/testcase/create": {
-            "post": {
+        "/api/contest/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new test case for a problem",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get details of a specific contest by ID, hidden and private contests are only visible to the users allowed in them",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TestCase"
+                    "Contest"
                 ],
-                "summary": "Create a new test case",
+                "summary": "Get Contest Details",
                 "parameters": [
                     {
-                        "description": "Test case creation request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CreateTestCaseRequest"
-                        }
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.CreateTestCaseResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.CreateContestResponse"
                         }
                     },
                     "400": {
@@ -1331,38 +1376,33 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/testcase/problem/{problemId}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all test cases associated with a specific problem",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete a contest by ID (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TestCase"
+                    "Contest"
                 ],
-                "summary": "Get all test cases for a problem",
+                "summary": "Delete a Contest",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Problem ID",
-                        "name": "problemId",
+                        "description": "Contest ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1371,22 +1411,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.TestCase"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.SuccessResponse"
                         }
                     },
                     "400": {
@@ -1404,52 +1429,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/testcase/update": {
-            "put": {
+        "/api/contest/{id}/clarifications": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing test case",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Problem setters see every clarification, participants see public ones and their own questions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TestCase"
+                    "Clarification"
                 ],
-                "summary": "Update a test case",
+                "summary": "Get contest clarifications",
                 "parameters": [
                     {
-                        "description": "Test case update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.UpdateTestCaseRequest"
-                        }
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.UpdateTestCaseResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ClarificationResponse"
+                            }
                         }
                     },
                     "400": {
@@ -1458,23 +1469,21 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/testcase/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves details of a specific test case by its unique ID",
+                "description": "Ask a question during a running contest, optionally about one of its problems",
                 "consumes": [
                     "application/json"
                 ],
@@ -1482,35 +1491,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TestCase"
+                    "Clarification"
                 ],
-                "summary": "Get test case details",
+                "summary": "Ask a clarification",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Test Case Unique ID",
+                        "description": "Contest ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Clarification question",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AskClarificationRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.TestCase"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.ClarificationResponse"
                         }
                     },
                     "400": {
@@ -1526,14 +1532,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/contest/{id}/clarifications/announcements": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a test case by its unique ID",
+                "description": "Broadcast an announcement to all participants of the contest (contest problem setters only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1541,23 +1549,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TestCase"
+                    "Clarification"
                 ],
-                "summary": "Delete a test case",
+                "summary": "Make an announcement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Test Case Unique ID",
+                        "description": "Contest ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Announcement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateAnnouncementRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/utils.SuccessResponse"
+                            "$ref": "#/definitions/domain.ClarificationResponse"
                         }
                     },
                     "400": {
@@ -1575,43 +1592,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/profile": {
+        "/api/contest/{id}/clarifications/stream": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the profile of the authenticated user",
+                "description": "Server-sent events stream of announcements, public answers and answers to the user's own questions. Problem setters also receive new questions.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "User"
+                    "Clarification"
+                ],
+                "summary": "Stream clarification events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get User Profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.UserProfileResponse"
+                            "$ref": "#/definitions/domain.ClarificationEvent"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/contest/{id}/clarifications/{clarificationId}/answer": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the profile of the authenticated user",
+                "description": "Answer a question privately or broadcast the answer to all participants (contest problem setters only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1619,17 +1653,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Clarification"
                 ],
-                "summary": "Update User Profile",
+                "summary": "Answer a clarification",
                 "parameters": [
                     {
-                        "description": "Update User Profile Request",
-                        "name": "updateUserProfileRequest",
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clarification ID",
+                        "name": "clarificationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clarification answer",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateUserProfileRequest"
+                            "$ref": "#/definitions/domain.AnswerClarificationRequest"
                         }
                     }
                 ],
@@ -1637,7 +1685,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.UserProfileResponse"
+                            "$ref": "#/definitions/domain.ClarificationResponse"
                         }
                     },
                     "400": {
@@ -1654,87 +1702,5336 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.AddRoleRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "role"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "admin",
-                        "user",
-                        "problem_setter"
-                    ]
-                }
-            }
-        },
-        "domain.AddRoleResponse": {
-            "type": "object",
-            "properties": {
-                "current_role": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "role": {
-                    "type": "string"
-                }
-            }
         },
-        "domain.AllRegisteredContestForUserResponse": {
-            "type": "object",
-            "properties": {
-                "registrations": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ContestRegisterResponse"
+        "/api/contest/{id}/problems": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                }
-            }
-        },
-        "domain.AuthResponse": {
-            "type": "object",
-            "properties": {
-                "access_token": {
-                    "type": "string"
-                },
-                "user": {
-                    "description": "Returns user info (without password)",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.User"
+                ],
+                "description": "Get the problems of a contest ordered by index, participants see them once the contest has started",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contest"
+                ],
+                "summary": "Get Contest Problems",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ContestProblemResponse"
+                            }
                         }
-                    ]
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
                 }
-            }
-        },
-        "domain.BulkProblemCreationRequest": {
-            "type": "object",
-            "required": [
-                "problems"
-            ],
-            "properties": {
-                "problems": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/domain.ProblemCreationRequest"
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach an existing problem to a contest under the given index (admin only). Drafts become contest-only, public problems can only be added to ended contests.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contest"
+                ],
+                "summary": "Add a Problem to a Contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add Contest Problem Request",
+                        "name": "addContestProblemRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AddContestProblemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ContestProblemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "domain.BulkProblemCreationResponse": {
-            "type": "object",
+        "/api/contest/{id}/problems/{problemId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Detach a problem from a contest (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contest"
+                ],
+                "summary": "Remove a Problem from a Contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "problemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/contest/{id}/standings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the ICPC style standings of a contest (virtual participants excluded)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Standings"
+                ],
+                "summary": "Get Contest Standings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.StandingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/contest/{id}/standings/virtual": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the ghost standings of the current user's virtual participation, merged with the original participants at the same elapsed time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Standings"
+                ],
+                "summary": "Get Virtual Contest Standings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.StandingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/contest/{id}/virtual": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current user's virtual participation in a contest",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contest-registration"
+                ],
+                "summary": "Get virtual participation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.VirtualParticipationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/health": {
+            "get": {
+                "description": "Get the health status of the API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Health Check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search, filter, sort and paginate the problems visible to the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Get All Problems",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full-text search over title and statement",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Problems having all these tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "easy",
+                            "medium",
+                            "hard"
+                        ],
+                        "type": "string",
+                        "description": "Difficulty",
+                        "name": "difficulty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum rating",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum rating",
+                        "name": "max_rating",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "rating",
+                            "title",
+                            "relevance"
+                        ],
+                        "type": "string",
+                        "description": "Sort key, relevance by default when searching",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru",
+                            "hi"
+                        ],
+                        "type": "string",
+                        "description": "Language to read the problems in, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, each problem's own language when none is available",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create multiple problems at once (admin or problem-setter only). In atomic mode, the default, nothing is created when any problem fails and the failures are returned with 422. In best_effort mode every valid problem is created and the failures are reported per item.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Create Multiple Problems in Bulk",
+                "parameters": [
+                    {
+                        "description": "Bulk Problem Creation Request",
+                        "name": "bulkRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.BulkProblemCreationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BulkProblemCreationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BulkProblemCreationResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new problem (admin or problem-setter only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Create a new Problem",
+                "parameters": [
+                    {
+                        "description": "Problem Creation Request",
+                        "name": "problemRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemCreationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemCreationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a draft problem from a zipped Kattis (problem.yaml, data/sample, data/secret) or Polygon (problem.xml, tests/) package, with its statement, limits, sample and hidden tests, checker and validator. Polygon packages must include their generated tests.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Import a problem package",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Zipped problem package",
+                        "name": "package",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "kattis",
+                            "polygon"
+                        ],
+                        "type": "string",
+                        "description": "Package format, detected when empty",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "easy",
+                            "medium",
+                            "hard"
+                        ],
+                        "type": "string",
+                        "description": "Problem difficulty, medium when empty",
+                        "name": "difficulty",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Problem rating",
+                        "name": "rating",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Problem tags",
+                        "name": "tags",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemPackageImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every tag of the problems visible to the user with the number of those problems using it, most used first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Get Problem Tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.TagCount"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing problem (admin or problem author only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Update a Problem",
+                "parameters": [
+                    {
+                        "description": "Problem Update Request",
+                        "name": "problemRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a specific problem by its unique ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Get Problem by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru",
+                            "hi"
+                        ],
+                        "type": "string",
+                        "description": "Language to read the problem in, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, the problem's own language when none is available",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemCreationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a problem by ID (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Delete a Problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/attachments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach an image or file to a problem statement (admin or problem author only). Statements reference it as attachment:\u003cfile name\u003e, a file uploaded under an existing name replaces it.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Upload a problem attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Attachment, at most 10 MB",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name to store the file under, the uploaded file name when empty",
+                        "name": "file_name",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemAttachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/attachments/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download a file attached to a problem statement, for users who can view the problem. Images are shown inline, other files are downloaded.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Download a problem attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment file name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a file attached to a problem statement (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Delete a problem attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment file name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download a problem with all its tests, checker and validator as a zipped Kattis or Polygon package (admin or problem author only)",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Export a problem package",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "kattis",
+                            "polygon"
+                        ],
+                        "type": "string",
+                        "default": "kattis",
+                        "description": "Package format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a generator script (admin or problem author only). Every line invokes a generator with its arguments, e.g. \"gen_random 100000 42\", and gives a hidden test whose expected output is produced by the main reference solution. Inputs are checked by the problem's validator, nothing is stored unless every line succeeds.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Generate test cases",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Generator script",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.GenerateTestsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TestGeneration"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/generations/{generationId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the status of a generator script run, with the error when it failed (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Get a test generation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Test generation ID",
+                        "name": "generationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TestGeneration"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/generators": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the generator programs of a problem (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Get the test generators of a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ProblemGenerator"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a generator program, replacing the generator of the same name (admin or problem author only). A generator prints a test input to stdout from its command line arguments and must be deterministic, pass the seed as an argument.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Save a test generator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Generator",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SaveGeneratorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemGenerator"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/generators/{name}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a generator program of a problem, the tests it generated are kept (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Delete a test generator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Generator name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the revision history of a problem, newest first (admin or problem author only). A revision is recorded whenever the statement, the limits or the test set change.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Get problem revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ProblemRevision"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare two revisions of a problem: the changed fields, a line diff of the statement and whether the test set changed (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Diff two problem revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number to compare to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemRevisionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/revisions/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore the statement and limits of an earlier revision, recorded as a new revision (admin or problem author only). Test cases are not restored, compare test_set_hash to see whether they differ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Roll back a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Revision to restore",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RollbackProblemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemRevision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/solutions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the reference solutions of a problem with the result of their last run (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Get the reference solutions of a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ReferenceSolution"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach a reference solution to a problem and queue its run against every test case (admin or problem author only). Main and correct solutions must be accepted, wrong solutions must fail and time_limit solutions must exceed the time limit. A problem has at most one main solution.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Add a reference solution",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reference solution",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateReferenceSolutionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReferenceSolution"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/solutions/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tell whether every reference solution got its expected verdict on the latest revision of the problem and suggest a time limit: the max runtime of the main solution times a configured factor, rounded up to whole seconds (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Get the reference solution report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReferenceSolutionReport"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/solutions/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a new run of every reference solution of a problem, e.g. after its tests or limits changed (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Run the reference solutions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ReferenceSolution"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/solutions/{solutionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a reference solution of a problem (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Delete a reference solution",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reference solution ID",
+                        "name": "solutionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/statement": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the statement of a problem ready to display: its Markdown sections with LaTeX math, the samples taken from the visible test cases and the attachments. Attachment references (attachment:\u003cfile name\u003e) are replaced by download URLs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Get a problem statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru",
+                            "hi"
+                        ],
+                        "type": "string",
+                        "description": "Language to read the statement in, takes precedence over Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, the problem's own language when none is available",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemStatement"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/tests/archive": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the test cases of a problem as a zip in the layout the upload reads: samples in sample/, hidden tests at the root, numbered in judging order (admin or problem author only)",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Download a test archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add the tests of a zip of NN.in files with their answers in NN.out or NN.ans (admin or problem author only). Tests in a sample/ directory or named sample* are samples, the others are hidden. Samples come first, then tests in natural order of their names. Every input is checked by the problem's validator and nothing is stored unless every test is accepted.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Upload a test archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Zipped tests",
+                        "name": "archive",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Replace the existing test cases instead of appending after them",
+                        "name": "replace",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TestArchiveUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/tests/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the judging order of the test cases of a problem (admin or problem author only). Every test case must be listed exactly once, they are numbered 1, 2, 3 ... in the given order and a new revision is recorded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Reorder test cases",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Test case IDs in the new order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReorderTestCasesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TestCaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every translation of a problem's title and statement",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Get the translations of a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ProblemTranslation"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/translations/{locale}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or replace the translation of a problem's title and statement into a locale other than the one it is written in (admin or problem author only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Translate a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru",
+                            "hi"
+                        ],
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProblemTranslation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the translation of a problem into a locale (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Problem"
+                ],
+                "summary": "Delete a problem translation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru",
+                            "hi"
+                        ],
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/validator": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the input validator of a problem (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Get the validator of a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ProblemValidator"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attaches an input validator to a problem (admin or problem author only). The validator reads a test input on stdin and exits with 0 when it is valid, otherwise with another code and the reason on stderr. New and updated test cases are rejected when invalid, existing ones become unchecked until validated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Set the validator of a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Validator",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SetValidatorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ProblemValidator"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the input validator of a problem, its test cases become unchecked (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Delete the validator of a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/problem/{id}/validator/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Runs the validator on every test case of a problem and stores their validation status (admin or problem author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Validate the test cases of a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.TestValidationReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/submission/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new code submission for evaluation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Submission"
+                ],
+                "summary": "Create a new submission",
+                "parameters": [
+                    {
+                        "description": "Create Submission Request",
+                        "name": "createSubmissionRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateSubmissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CreateSubmissionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/submission/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get details of a specific submission by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Submission"
+                ],
+                "summary": "Get submission details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/submission/{id}/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the status of a specific submission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Submission"
+                ],
+                "summary": "Update submission status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/team/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new team with the current user as captain",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Create a team",
+                "parameters": [
+                    {
+                        "description": "Create team request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateTeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/team/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Join a team using its invite code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Join a team",
+                "parameters": [
+                    {
+                        "description": "Join team request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.JoinTeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/team/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all teams the current user is a member of",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Get my teams",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.TeamResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/team/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a team and its members",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Get team details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/team/{id}/invite-code": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the team invite code, the previous one stops working (captain only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Regenerate team invite code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/team/{id}/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Leave a team, captaincy passes to the longest standing member",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Leave a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/team/{id}/members/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a member from the team (captain only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Remove a team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/testcase/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates multiple test cases at once for a problem. In atomic mode, the default, nothing is created when any test case fails and the failures are returned with 422. In best_effort mode every valid test case is created and the failures are reported per item.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Upload test cases in bulk",
+                "parameters": [
+                    {
+                        "description": "Bulk test case upload request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.BulkTestCaseUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.BulkTestCaseUploadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.BulkTestCaseUploadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/testcase/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new test case for a problem (admin or problem author only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Create a new test case",
+                "parameters": [
+                    {
+                        "description": "Test case creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateTestCaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CreateTestCaseResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/testcase/problem/{problemId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the test cases of a problem. Admins and the problem's authors, its creator and the staff of its contests, get every test case, other users who can see the problem only the samples.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Get all test cases for a problem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Problem ID",
+                        "name": "problemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.TestCase"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/testcase/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an existing test case (admin or problem author only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Update a test case",
+                "parameters": [
+                    {
+                        "description": "Test case update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateTestCaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.UpdateTestCaseResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/testcase/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves details of a specific test case by its unique ID. Hidden test cases are only found for admins and the problem's authors.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Get test case details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Test Case Unique ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.TestCase"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a test case by its unique ID (admin or problem author only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TestCase"
+                ],
+                "summary": "Delete a test case",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Test Case Unique ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the profile of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User Profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.UserProfileResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the profile of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User Profile",
+                "parameters": [
+                    {
+                        "description": "Update User Profile Request",
+                        "name": "updateUserProfileRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateUserProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AddContestProblemRequest": {
+            "type": "object",
+            "required": [
+                "index",
+                "problem_id"
+            ],
+            "properties": {
+                "index": {
+                    "type": "string",
+                    "maxLength": 5
+                },
+                "problem_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AddRoleRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "user",
+                        "problem_setter"
+                    ]
+                }
+            }
+        },
+        "domain.AddRoleResponse": {
+            "type": "object",
+            "properties": {
+                "current_role": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AllRegisteredContestForUserResponse": {
+            "type": "object",
+            "properties": {
+                "registrations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ContestRegisterResponse"
+                    }
+                }
+            }
+        },
+        "domain.AnswerClarificationRequest": {
+            "type": "object",
+            "required": [
+                "answer"
+            ],
+            "properties": {
+                "answer": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "is_public": {
+                    "description": "broadcast the answer to all participants",
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.AskClarificationRequest": {
+            "type": "object",
+            "required": [
+                "question"
+            ],
+            "properties": {
+                "problem_id": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "domain.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "Returns user info (without password)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.BulkItemError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "description": "position of the item in the request, from 0",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BulkProblemCreationRequest": {
+            "type": "object",
+            "required": [
+                "problems"
+            ],
+            "properties": {
+                "mode": {
+                    "description": "Mode is atomic when empty, see BulkMode*",
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                },
+                "problems": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.ProblemCreationRequest"
+                    }
+                }
+            }
+        },
+        "domain.BulkProblemCreationResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BulkItemError"
+                    }
+                },
+                "failed_count": {
+                    "type": "integer"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ProblemCreationResponse"
+                    }
+                },
+                "success_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BulkTestCaseUploadRequest": {
+            "type": "object",
+            "required": [
+                "test_cases"
+            ],
+            "properties": {
+                "mode": {
+                    "description": "Mode is atomic when empty, see BulkMode*",
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                },
+                "test_cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CreateTestCaseRequest"
+                    }
+                }
+            }
+        },
+        "domain.BulkTestCaseUploadResponse": {
+            "type": "object",
+            "properties": {
+                "created_test_cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CreateTestCaseResponse"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BulkItemError"
+                    }
+                },
+                "failed_count": {
+                    "type": "integer"
+                },
+                "success_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ClarificationEvent": {
+            "type": "object",
+            "properties": {
+                "clarification": {
+                    "$ref": "#/definitions/domain.ClarificationResponse"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ClarificationResponse": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "answered_at": {
+                    "type": "string"
+                },
+                "answered_by": {
+                    "type": "string"
+                },
+                "asked_by": {
+                    "type": "string"
+                },
+                "contest_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_announcement": {
+                    "type": "boolean"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ContestProblemResponse": {
+            "type": "object",
+            "properties": {
+                "contest_id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ContestRegisterRequest": {
+            "type": "object",
+            "required": [
+                "contest_id"
+            ],
+            "properties": {
+                "contest_id": {
+                    "type": "string"
+                },
+                "invite_code": {
+                    "description": "required for private contests unless the user is allowlisted",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ContestRegisterResponse": {
+            "type": "object",
+            "properties": {
+                "contest_id": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "registered_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ContestSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "allow_multiple_registrations": {
+                    "type": "boolean"
+                },
+                "contests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CreateContestResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ContestUnregisterRequest": {
+            "type": "object",
+            "required": [
+                "contest_id"
+            ],
+            "properties": {
+                "contest_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateAnnouncementRequest": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "problem_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateContestRequest": {
+            "type": "object",
+            "required": [
+                "duration",
+                "end_time",
+                "name",
+                "start_time"
+            ],
+            "properties": {
+                "allow_late_registration": {
+                    "type": "boolean"
+                },
+                "allowed_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowed_emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "division": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "in minutes",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "invite_code": {
+                    "description": "generated for private contests when empty",
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_team_contest": {
+                    "type": "boolean"
+                },
+                "is_windowed": {
+                    "type": "boolean"
+                },
+                "max_participants": {
+                    "description": "0 means unlimited",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "problem_setters": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "registration_closes_at": {
+                    "type": "string"
+                },
+                "registration_opens_at": {
+                    "type": "string"
+                },
+                "requires_approval": {
+                    "type": "boolean"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "unregistration_closes_at": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "default: public",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ]
+                },
+                "visible": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.CreateContestResponse": {
+            "type": "object",
+            "properties": {
+                "allow_late_registration": {
+                    "type": "boolean"
+                },
+                "allowed_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowed_emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "division": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "in minutes",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invite_code": {
+                    "description": "only shown to the contest staff",
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_team_contest": {
+                    "type": "boolean"
+                },
+                "is_windowed": {
+                    "type": "boolean"
+                },
+                "max_participants": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "problem_setters": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "registration_closes_at": {
+                    "type": "string"
+                },
+                "registration_opens_at": {
+                    "type": "string"
+                },
+                "requires_approval": {
+                    "type": "boolean"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "unregistration_closes_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                },
+                "visible": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.CreateContestSeriesRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "allow_multiple_registrations": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateReferenceSolutionRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "kind",
+                "language",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "main",
+                        "correct",
+                        "wrong",
+                        "time_limit"
+                    ]
+                },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "python",
+                        "cpp",
+                        "java"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "domain.CreateSubmissionRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "contest_id",
+                "language",
+                "problem_id",
+                "user_id"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "contest_id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "python",
+                        "cpp",
+                        "java"
+                    ]
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateSubmissionResponse": {
+            "type": "object",
+            "properties": {
+                "contest_id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "unique_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "verdict": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateTeamRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                }
+            }
+        },
+        "domain.CreateTestCaseRequest": {
+            "type": "object",
+            "required": [
+                "input",
+                "order_position",
+                "output",
+                "problem_id"
+            ],
+            "properties": {
+                "input": {
+                    "type": "string"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "order_position": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "output": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateTestCaseResponse": {
+            "type": "object",
+            "properties": {
+                "input": {
+                    "type": "string"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "order_position": {
+                    "type": "integer"
+                },
+                "output": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "unique_id": {
+                    "type": "string"
+                },
+                "validation_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DiffLine": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GenerateTestsRequest": {
+            "type": "object",
+            "required": [
+                "script"
+            ],
+            "properties": {
+                "replace": {
+                    "description": "delete the tests of earlier generations",
+                    "type": "boolean"
+                },
+                "script": {
+                    "description": "one generator invocation per line, blank lines and lines starting with # are skipped",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.JoinTeamRequest": {
+            "type": "object",
+            "required": [
+                "invite_code"
+            ],
+            "properties": {
+                "invite_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PlagiarismCase": {
+            "type": "object",
+            "properties": {
+                "contest_id": {
+                    "description": "references Contest(Id)",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "description": "references Problem(UniqueID)",
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "similarity": {
+                    "description": "between 0 and 1",
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submission_a_id": {
+                    "description": "references Submission(UniqueID)",
+                    "type": "string"
+                },
+                "submission_b_id": {
+                    "description": "references Submission(UniqueID)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_a_id": {
+                    "type": "string"
+                },
+                "user_b_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProblemAttachment": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "references User(Id)",
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "description": "references Problem(UniqueID)",
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProblemCreationRequest": {
+            "type": "object",
+            "required": [
+                "difficulty",
+                "statement",
+                "title"
+            ],
+            "properties": {
+                "co_authors": {
+                    "description": "problem setters managing the problem with its creator",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "constraints": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string",
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard"
+                    ]
+                },
+                "input_format": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "language of the title and statement, default: en",
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "ru",
+                        "hi"
+                    ]
+                },
+                "memory_limit_in_mb": {
+                    "description": "default: 256 MB",
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "output_format": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5000,
+                    "minimum": 0
+                },
+                "statement": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time_limit_in_seconds": {
+                    "description": "default: 1 second",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "default: draft",
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "contest",
+                        "public"
+                    ]
+                }
+            }
+        },
+        "domain.ProblemCreationResponse": {
+            "type": "object",
+            "properties": {
+                "available_locales": {
+                    "description": "languages the statement can be read in, for single problems",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "co_authors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "constraints": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "input_format": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "language of the returned title and statement",
+                    "type": "string"
+                },
+                "memory_limit_in_mb": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "output_format": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "statement": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time_limit_in_seconds": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "unique_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProblemGenerator": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "references User(Id)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "invoked by this name in generator scripts",
+                    "type": "string"
+                },
+                "problem_id": {
+                    "description": "references Problem(UniqueID)",
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProblemListResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ProblemCreationResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProblemPackageImportResponse": {
+            "type": "object",
+            "properties": {
+                "has_checker": {
+                    "type": "boolean"
+                },
+                "has_validator": {
+                    "type": "boolean"
+                },
+                "problem": {
+                    "$ref": "#/definitions/domain.ProblemCreationResponse"
+                },
+                "sample_count": {
+                    "type": "integer"
+                },
+                "test_count": {
+                    "description": "samples included",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProblemRevision": {
+            "type": "object",
+            "properties": {
+                "constraints": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "references User(Id), author of the change",
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "input_format": {
+                    "type": "string"
+                },
+                "memory_limit_in_mb": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "description": "1, 2, 3 ... per problem",
+                    "type": "integer"
+                },
+                "output_format": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "description": "references Problem(UniqueID)",
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "statement": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "test_count": {
+                    "type": "integer"
+                },
+                "test_set_hash": {
+                    "description": "sha256 of the ordered test cases",
+                    "type": "string"
+                },
+                "time_limit_in_seconds": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProblemRevisionDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "every changed field except the statement and its sections",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "section_diffs": {
+                    "description": "line diff of every changed statement section, by section name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/domain.DiffLine"
+                        }
+                    }
+                },
+                "statement_diff": {
+                    "description": "empty when the statement did not change",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DiffLine"
+                    }
+                },
+                "test_set_changed": {
+                    "type": "boolean"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProblemStanding": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "rejected attempts before the first accepted one",
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "solved": {
+                    "type": "boolean"
+                },
+                "solved_at_minute": {
+                    "description": "minutes since the participant started",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProblemStatement": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ProblemAttachment"
+                    }
+                },
+                "available_locales": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locale": {
+                    "description": "language of the title and sections",
+                    "type": "string"
+                },
+                "memory_limit_in_mb": {
+                    "type": "integer"
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "samples": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.StatementSample"
+                    }
+                },
+                "sections": {
+                    "description": "empty sections are left out",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.StatementSection"
+                    }
+                },
+                "time_limit_in_seconds": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProblemTranslation": {
+            "type": "object",
+            "properties": {
+                "constraints": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "input_format": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "output_format": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "description": "references Problem(UniqueID)",
+                    "type": "string"
+                },
+                "statement": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "description": "references User(Id)",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProblemTranslationRequest": {
+            "type": "object",
+            "required": [
+                "statement",
+                "title"
+            ],
+            "properties": {
+                "constraints": {
+                    "type": "string"
+                },
+                "input_format": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "output_format": {
+                    "type": "string"
+                },
+                "statement": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProblemUpdateRequest": {
+            "type": "object",
+            "required": [
+                "difficulty",
+                "statement",
+                "title",
+                "unique_id"
+            ],
+            "properties": {
+                "co_authors": {
+                    "description": "default: unchanged, only the creator and admins can change them",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "constraints": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string",
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard"
+                    ]
+                },
+                "input_format": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "default: unchanged",
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "ru",
+                        "hi"
+                    ]
+                },
+                "memory_limit_in_mb": {
+                    "description": "default: 256 MB",
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "output_format": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5000,
+                    "minimum": 0
+                },
+                "statement": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time_limit_in_seconds": {
+                    "description": "default: 1 second",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "unique_id": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "default: unchanged",
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "contest",
+                        "public"
+                    ]
+                }
+            }
+        },
+        "domain.ProblemUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "co_authors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "constraints": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "input_format": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "language of the returned title and statement",
+                    "type": "string"
+                },
+                "memory_limit_in_mb": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "output_format": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "statement": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time_limit_in_seconds": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "unique_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProblemValidator": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ReferenceSolution": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "references User(Id)",
+                    "type": "string"
+                },
+                "expectation_met": {
+                    "description": "whether the verdict is the one expected from the kind, null until judged",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "judged_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "see ReferenceSolution* kinds",
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "max_memory_in_kb": {
+                    "type": "integer"
+                },
+                "max_time_in_ms": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "problem_id": {
+                    "description": "references Problem(UniqueID)",
+                    "type": "string"
+                },
+                "problem_revision_id": {
+                    "description": "problem revision the solution was judged against",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "see ReferenceRun* states",
+                    "type": "string"
+                },
+                "test_case_results": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "test_cases_passed": {
+                    "type": "integer"
+                },
+                "total_test_cases": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "verdict": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ReferenceSolutionReport": {
+            "type": "object",
+            "properties": {
+                "main_max_time_in_ms": {
+                    "type": "number"
+                },
+                "pending": {
+                    "description": "solutions queued or running",
+                    "type": "integer"
+                },
+                "problem_id": {
+                    "type": "string"
+                },
+                "solutions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ReferenceSolution"
+                    }
+                },
+                "suggested_time_limit_in_seconds": {
+                    "description": "0 until the main solution is accepted",
+                    "type": "integer"
+                },
+                "time_limit_factor": {
+                    "type": "number"
+                },
+                "time_limit_in_seconds": {
+                    "type": "integer"
+                },
+                "verified": {
+                    "description": "a main solution exists and every solution got its expected verdict on the latest revision",
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.RegistrationDecisionRequest": {
+            "type": "object",
+            "required": [
+                "contest_id",
+                "registration_ids"
+            ],
+            "properties": {
+                "contest_id": {
+                    "type": "string"
+                },
+                "registration_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.RegistrationDecisionResponse": {
+            "type": "object",
             "properties": {
                 "errors": {
                     "type": "array",
@@ -1742,46 +7039,244 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
-                "failed_count": {
+                "registrations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ContestRegisterResponse"
+                    }
+                }
+            }
+        },
+        "domain.RemoveRoleRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "user",
+                        "problem_setter"
+                    ]
+                }
+            }
+        },
+        "domain.RemoveRoleResponse": {
+            "type": "object",
+            "properties": {
+                "current_role": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ReorderTestCasesRequest": {
+            "type": "object",
+            "required": [
+                "test_case_ids"
+            ],
+            "properties": {
+                "test_case_ids": {
+                    "description": "Every test case of the problem in the new judging order",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.ReviewPlagiarismCaseRequest": {
+            "type": "object",
+            "required": [
+                "case_id",
+                "status"
+            ],
+            "properties": {
+                "case_id": {
+                    "type": "string"
+                },
+                "disqualify_user_ids": {
+                    "description": "DisqualifyUserIDs limits a confirmation to some users of the case, both are disqualified when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "confirmed",
+                        "dismissed"
+                    ]
+                }
+            }
+        },
+        "domain.RollbackProblemRequest": {
+            "type": "object",
+            "required": [
+                "revision"
+            ],
+            "properties": {
+                "revision": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "domain.SaveGeneratorRequest": {
+            "type": "object",
+            "required": [
+                "language",
+                "name",
+                "source"
+            ],
+            "properties": {
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "python",
+                        "cpp",
+                        "java"
+                    ]
+                },
+                "name": {
+                    "description": "no spaces nor #",
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SetValidatorRequest": {
+            "type": "object",
+            "required": [
+                "language",
+                "source"
+            ],
+            "properties": {
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "python",
+                        "cpp",
+                        "java"
+                    ]
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SignupRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "username": {
+                    "type": "string",
+                    "minLength": 3
+                }
+            }
+        },
+        "domain.StandingsResponse": {
+            "type": "object",
+            "properties": {
+                "contest_id": {
+                    "type": "string"
+                },
+                "elapsed_minutes": {
                     "type": "integer"
                 },
                 "problems": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.ProblemCreationResponse"
+                        "$ref": "#/definitions/domain.ContestProblemResponse"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.StandingsRow"
                     }
-                },
-                "success_count": {
-                    "type": "integer"
                 }
             }
         },
-        "domain.BulkTestCaseUploadRequest": {
+        "domain.StandingsRow": {
             "type": "object",
-            "required": [
-                "test_cases"
-            ],
             "properties": {
-                "test_cases": {
+                "is_virtual": {
+                    "type": "boolean"
+                },
+                "penalty": {
+                    "description": "in minutes",
+                    "type": "integer"
+                },
+                "problems": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.CreateTestCaseRequest"
+                        "$ref": "#/definitions/domain.ProblemStanding"
                     }
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "solved": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "description": "set instead of the user in team contests",
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "domain.BulkTestCaseUploadResponse": {
+        "domain.StartContestWindowRequest": {
             "type": "object",
+            "required": [
+                "contest_id"
+            ],
             "properties": {
-                "created_test_cases": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.CreateTestCaseResponse"
-                    }
+                "contest_id": {
+                    "type": "string"
                 }
             }
         },
-        "domain.ContestRegisterRequest": {
+        "domain.StartVirtualParticipationRequest": {
             "type": "object",
             "required": [
                 "contest_id"
@@ -1792,462 +7287,327 @@ const docTemplate = `{
                 }
             }
         },
-        "domain.ContestRegisterResponse": {
+        "domain.StatementSample": {
             "type": "object",
             "properties": {
-                "contest_id": {
+                "input": {
                     "type": "string"
                 },
-                "id": {
+                "output": {
                     "type": "string"
-                },
-                "registered_at": {
+                }
+            }
+        },
+        "domain.StatementSection": {
+            "type": "object",
+            "properties": {
+                "markdown": {
                     "type": "string"
                 },
-                "status": {
+                "name": {
+                    "description": "legend, input_format, output_format, constraints or notes",
                     "type": "string"
                 },
-                "user_id": {
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "domain.ContestUnregisterRequest": {
+        "domain.TagCount": {
             "type": "object",
-            "required": [
-                "contest_id"
-            ],
             "properties": {
-                "contest_id": {
+                "count": {
+                    "type": "integer"
+                },
+                "tag": {
                     "type": "string"
                 }
             }
         },
-        "domain.CreateContestRequest": {
+        "domain.TeamContestRegisterRequest": {
             "type": "object",
             "required": [
-                "duration",
-                "end_time",
-                "name",
-                "start_time"
+                "contest_id",
+                "team_id"
             ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "duration": {
-                    "description": "in minutes",
-                    "type": "integer"
-                },
-                "end_time": {
+                "contest_id": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "name": {
+                "invite_code": {
+                    "description": "required for private contests unless every member is allowlisted",
                     "type": "string"
                 },
-                "problem_setters": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "start_time": {
+                "team_id": {
                     "type": "string"
-                },
-                "visible": {
-                    "type": "boolean"
                 }
             }
         },
-        "domain.CreateContestResponse": {
+        "domain.TeamContestRegisterResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "duration": {
-                    "description": "in minutes",
-                    "type": "integer"
-                },
-                "end_time": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "name": {
+                "contest_id": {
                     "type": "string"
                 },
-                "problem_setters": {
+                "registrations": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.ContestRegisterResponse"
                     }
                 },
-                "start_time": {
-                    "type": "string"
-                },
-                "updated_at": {
+                "team_id": {
                     "type": "string"
-                },
-                "visible": {
-                    "type": "boolean"
                 }
             }
         },
-        "domain.CreateSubmissionRequest": {
+        "domain.TeamContestUnregisterRequest": {
             "type": "object",
             "required": [
-                "code",
                 "contest_id",
-                "language",
-                "memory_limit",
-                "problem_id",
-                "time_limit",
-                "user_id"
+                "team_id"
             ],
             "properties": {
-                "code": {
-                    "type": "string"
-                },
                 "contest_id": {
                     "type": "string"
                 },
-                "language": {
-                    "type": "string",
-                    "enum": [
-                        "python",
-                        "cpp",
-                        "java"
-                    ]
-                },
-                "memory_limit": {
-                    "type": "integer"
-                },
-                "problem_id": {
-                    "type": "string"
-                },
-                "time_limit": {
-                    "type": "integer"
-                },
-                "user_id": {
+                "team_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.CreateSubmissionResponse": {
+        "domain.TeamMemberResponse": {
             "type": "object",
             "properties": {
-                "contest_id": {
-                    "type": "string"
-                },
-                "language": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "problem_id": {
-                    "type": "string"
-                },
-                "submitted_at": {
-                    "type": "string"
-                },
-                "unique_id": {
+                "joined_at": {
                     "type": "string"
                 },
                 "user_id": {
                     "type": "string"
                 },
-                "verdict": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "domain.CreateTestCaseRequest": {
+        "domain.TeamResponse": {
             "type": "object",
-            "required": [
-                "input",
-                "order_position",
-                "output",
-                "problem_id"
-            ],
             "properties": {
-                "input": {
+                "captain_id": {
                     "type": "string"
                 },
-                "is_hidden": {
-                    "type": "boolean"
+                "created_at": {
+                    "type": "string"
                 },
-                "order_position": {
-                    "type": "integer",
-                    "minimum": 1
+                "id": {
+                    "type": "string"
                 },
-                "output": {
+                "invite_code": {
+                    "description": "only shown to team members",
                     "type": "string"
                 },
-                "problem_id": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TeamMemberResponse"
+                    }
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "domain.CreateTestCaseResponse": {
+        "domain.TestArchiveEntry": {
             "type": "object",
             "properties": {
-                "input": {
-                    "type": "string"
-                },
                 "is_hidden": {
                     "type": "boolean"
                 },
-                "order_position": {
-                    "type": "integer"
-                },
-                "output": {
+                "name": {
+                    "description": "file name without extension",
                     "type": "string"
                 },
-                "problem_id": {
-                    "type": "string"
+                "order_position": {
+                    "type": "integer"
                 },
                 "unique_id": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.LoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
                 },
-                "password": {
+                "validation_status": {
                     "type": "string"
                 }
             }
         },
-        "domain.ProblemCreationRequest": {
+        "domain.TestArchiveUploadResponse": {
             "type": "object",
-            "required": [
-                "difficulty",
-                "statement",
-                "title"
-            ],
             "properties": {
-                "difficulty": {
-                    "type": "string",
-                    "enum": [
-                        "easy",
-                        "medium",
-                        "hard"
-                    ]
-                },
-                "memory_limit_in_mb": {
-                    "description": "default: 256 MB",
-                    "type": "integer"
-                },
-                "statement": {
+                "problem_id": {
                     "type": "string"
                 },
-                "time_limit_in_seconds": {
-                    "description": "default: 1 second",
+                "replaced": {
+                    "type": "boolean"
+                },
+                "sample_count": {
                     "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "test_cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TestArchiveEntry"
+                    }
                 }
             }
         },
-        "domain.ProblemCreationResponse": {
+        "domain.TestCase": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "generator": {
+                    "description": "generator invocation the test was generated by",
                     "type": "string"
                 },
-                "created_by": {
+                "input": {
+                    "description": "Test data is kept in the blob storage under the sha256 of its content,\nthe repository loads it into Input and ExpectedOutput",
                     "type": "string"
                 },
-                "difficulty": {
+                "input_hash": {
                     "type": "string"
                 },
-                "memory_limit_in_mb": {
+                "input_size": {
                     "type": "integer"
                 },
-                "statement": {
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "order_position": {
+                    "description": "unique per problem, tests are judged by increasing position",
+                    "type": "integer"
+                },
+                "output": {
                     "type": "string"
                 },
-                "time_limit_in_seconds": {
+                "output_hash": {
+                    "type": "string"
+                },
+                "output_size": {
                     "type": "integer"
                 },
-                "title": {
+                "problem_id": {
+                    "description": "references Problem(UniqueID)",
                     "type": "string"
                 },
                 "unique_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "validation_message": {
+                    "type": "string"
+                },
+                "validation_status": {
+                    "description": "Result of the problem's validator on the input, see TestValidation*",
                     "type": "string"
                 }
             }
         },
-        "domain.ProblemUpdateRequest": {
+        "domain.TestCaseOrder": {
             "type": "object",
-            "required": [
-                "difficulty",
-                "statement",
-                "title",
-                "unique_id"
-            ],
             "properties": {
-                "difficulty": {
-                    "type": "string",
-                    "enum": [
-                        "easy",
-                        "medium",
-                        "hard"
-                    ]
-                },
-                "memory_limit_in_mb": {
-                    "description": "default: 256 MB",
-                    "type": "integer"
-                },
-                "statement": {
+                "problem_id": {
                     "type": "string"
                 },
-                "time_limit_in_seconds": {
-                    "description": "default: 1 second",
-                    "type": "integer"
+                "test_cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TestCaseOrderEntry"
+                    }
+                }
+            }
+        },
+        "domain.TestCaseOrderEntry": {
+            "type": "object",
+            "properties": {
+                "is_hidden": {
+                    "type": "boolean"
                 },
-                "title": {
-                    "type": "string"
+                "order_position": {
+                    "type": "integer"
                 },
                 "unique_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.ProblemUpdateResponse": {
+        "domain.TestGeneration": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
                 "created_by": {
+                    "description": "references User(Id)",
                     "type": "string"
                 },
-                "difficulty": {
+                "error": {
+                    "description": "why the generation failed",
                     "type": "string"
                 },
-                "memory_limit_in_mb": {
-                    "type": "integer"
-                },
-                "statement": {
+                "id": {
                     "type": "string"
                 },
-                "time_limit_in_seconds": {
-                    "type": "integer"
-                },
-                "title": {
+                "problem_id": {
+                    "description": "references Problem(UniqueID)",
                     "type": "string"
                 },
-                "unique_id": {
-                    "type": "string"
+                "replace": {
+                    "description": "whether the tests of earlier generations are deleted",
+                    "type": "boolean"
                 },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.RemoveRoleRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "role"
-            ],
-            "properties": {
-                "email": {
+                "script": {
                     "type": "string"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "admin",
-                        "user",
-                        "problem_setter"
-                    ]
-                }
-            }
-        },
-        "domain.RemoveRoleResponse": {
-            "type": "object",
-            "properties": {
-                "current_role": {
+                "status": {
+                    "description": "see TestGeneration* states",
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "test_count": {
+                    "description": "tests stored once done",
+                    "type": "integer"
                 },
-                "role": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.SignupRequest": {
+        "domain.TestValidationReport": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
-            ],
             "properties": {
-                "email": {
+                "invalid": {
+                    "type": "integer"
+                },
+                "problem_id": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TestValidationResult"
+                    }
                 },
-                "username": {
-                    "type": "string",
-                    "minLength": 3
+                "valid": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.TestCase": {
+        "domain.TestValidationResult": {
             "type": "object",
             "properties": {
-                "input": {
+                "message": {
                     "type": "string"
                 },
-                "is_hidden": {
-                    "type": "boolean"
-                },
                 "order_position": {
                     "type": "integer"
                 },
-                "output": {
-                    "type": "string"
-                },
-                "problem_id": {
-                    "description": "references Problem(UniqueID)",
+                "status": {
                     "type": "string"
                 },
-                "unique_id": {
+                "test_case_id": {
                     "type": "string"
                 }
             }
@@ -2262,9 +7622,27 @@ const docTemplate = `{
                 "start_time"
             ],
             "properties": {
+                "allow_late_registration": {
+                    "type": "boolean"
+                },
+                "allowed_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowed_emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "description": {
                     "type": "string"
                 },
+                "division": {
+                    "type": "string"
+                },
                 "duration": {
                     "description": "in minutes",
                     "type": "integer"
@@ -2275,9 +7653,24 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "invite_code": {
+                    "description": "kept when empty, generated for private contests without one",
+                    "type": "string"
+                },
                 "is_active": {
                     "type": "boolean"
                 },
+                "is_team_contest": {
+                    "type": "boolean"
+                },
+                "is_windowed": {
+                    "type": "boolean"
+                },
+                "max_participants": {
+                    "description": "0 means unlimited",
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "name": {
                     "type": "string"
                 },
@@ -2287,9 +7680,33 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "registration_closes_at": {
+                    "type": "string"
+                },
+                "registration_opens_at": {
+                    "type": "string"
+                },
+                "requires_approval": {
+                    "type": "boolean"
+                },
+                "series_id": {
+                    "type": "string"
+                },
                 "start_time": {
                     "type": "string"
                 },
+                "unregistration_closes_at": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "default: public",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ]
+                },
                 "visible": {
                     "type": "boolean"
                 }
@@ -2298,6 +7715,21 @@ const docTemplate = `{
         "domain.UpdateContestResponse": {
             "type": "object",
             "properties": {
+                "allow_late_registration": {
+                    "type": "boolean"
+                },
+                "allowed_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowed_emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -2307,6 +7739,9 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "division": {
+                    "type": "string"
+                },
                 "duration": {
                     "description": "in minutes",
                     "type": "integer"
@@ -2317,9 +7752,22 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "invite_code": {
+                    "description": "only shown to the contest staff",
+                    "type": "string"
+                },
                 "is_active": {
                     "type": "boolean"
                 },
+                "is_team_contest": {
+                    "type": "boolean"
+                },
+                "is_windowed": {
+                    "type": "boolean"
+                },
+                "max_participants": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -2329,17 +7777,56 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "registration_closes_at": {
+                    "type": "string"
+                },
+                "registration_opens_at": {
+                    "type": "string"
+                },
+                "requires_approval": {
+                    "type": "boolean"
+                },
+                "series_id": {
+                    "type": "string"
+                },
                 "start_time": {
                     "type": "string"
                 },
+                "unregistration_closes_at": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
+                "visibility": {
+                    "type": "string"
+                },
                 "visible": {
                     "type": "boolean"
                 }
             }
         },
+        "domain.UpdateContestSeriesRequest": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "allow_multiple_registrations": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.UpdateTestCaseRequest": {
             "type": "object",
             "required": [
@@ -2391,6 +7878,9 @@ const docTemplate = `{
                 },
                 "unique_id": {
                     "type": "string"
+                },
+                "validation_status": {
+                    "type": "string"
                 }
             }
         },
@@ -2488,6 +7978,32 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.VirtualParticipationResponse": {
+            "type": "object",
+            "properties": {
+                "contest_id": {
+                    "type": "string"
+                },
+                "elapsed_minutes": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_running": {
+                    "type": "boolean"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "utils.ErrorResponse": {
             "type": "object",
             "properties": {
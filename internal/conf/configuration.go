@@ -5,6 +5,7 @@ package conf
 import (
 	"fmt"
 	"os"
+	"strconv"
 )
 
 // Default configuration values
@@ -18,6 +19,12 @@ const (
 	defaultDB_SSLMODE  = "disable"
 	defaultREDIS_ADDR  = "localhost:6379"
 	defaultJUDGE0_URL  = "http://localhost:2358"
+	defaultSTORAGE_DIR = "./data/storage"
+
+	defaultTEST_CACHE_DIR    = "./data/test-cache"
+	defaultTEST_SET_CACHE_MB = 256
+
+	defaultTIME_LIMIT_FACTOR = 2.0
 )
 
 // Configuration variables with defaults and environment overrides
@@ -31,6 +38,12 @@ var (
 	DB_SSLMODE  string
 	REDIS_URL   string
 	JUDGE0_URL  string
+	STORAGE_DIR string // root directory of the local blob storage
+
+	TEST_CACHE_DIR    string // directory workers cache test data fetched from the blob storage in
+	TEST_SET_CACHE_MB int    // memory workers keep recently judged test sets in
+
+	TIME_LIMIT_FACTOR float64 // suggested time limit over the max runtime of the main reference solution
 )
 
 // init function runs when the package is imported
@@ -45,6 +58,10 @@ func init() {
 	DB_SSLMODE = defaultDB_SSLMODE
 	REDIS_URL = defaultREDIS_ADDR
 	JUDGE0_URL = defaultJUDGE0_URL
+	STORAGE_DIR = defaultSTORAGE_DIR
+	TEST_CACHE_DIR = defaultTEST_CACHE_DIR
+	TEST_SET_CACHE_MB = defaultTEST_SET_CACHE_MB
+	TIME_LIMIT_FACTOR = defaultTIME_LIMIT_FACTOR
 	fmt.Println("db host", DB_HOST)
 
 	// Override with environment variables if they exist
@@ -76,4 +93,24 @@ func init() {
 	if envValue := os.Getenv("JUDGE0_URL"); envValue != "" {
 		JUDGE0_URL = envValue
 	}
+	if envValue := os.Getenv("STORAGE_DIR"); envValue != "" {
+		STORAGE_DIR = envValue
+	}
+	if envValue := os.Getenv("TEST_CACHE_DIR"); envValue != "" {
+		TEST_CACHE_DIR = envValue
+	}
+	if envValue := os.Getenv("TEST_SET_CACHE_MB"); envValue != "" {
+		if size, err := strconv.Atoi(envValue); err == nil && size >= 0 {
+			TEST_SET_CACHE_MB = size
+		} else {
+			fmt.Println("ignoring invalid TEST_SET_CACHE_MB", envValue)
+		}
+	}
+	if envValue := os.Getenv("TIME_LIMIT_FACTOR"); envValue != "" {
+		if factor, err := strconv.ParseFloat(envValue, 64); err == nil && factor >= 1 {
+			TIME_LIMIT_FACTOR = factor
+		} else {
+			fmt.Println("ignoring invalid TIME_LIMIT_FACTOR", envValue)
+		}
+	}
 }
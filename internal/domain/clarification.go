@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Types of events pushed on the clarification stream
+const (
+	ClarificationEventQuestion     = "question"
+	ClarificationEventAnswer       = "answer"
+	ClarificationEventAnnouncement = "announcement"
+)
+
+// Clarification is a question asked by a contestant during a contest, or an
+// announcement made by the contest staff
+type Clarification struct {
+	ID             string     `json:"id" gorm:"primaryKey;type:uuid"`
+	ContestID      string     `json:"contest_id" gorm:"type:uuid;not null;index"` // references Contest(Id)
+	ProblemID      *string    `json:"problem_id,omitempty" gorm:"type:uuid"`      // optional, references Problem(ID)
+	AskedBy        string     `json:"asked_by" gorm:"type:uuid;not null"`         // references User(Id)
+	Question       string     `json:"question" gorm:"type:text"`
+	Answer         string     `json:"answer" gorm:"type:text"`
+	AnsweredBy     *string    `json:"answered_by,omitempty" gorm:"type:uuid"`
+	AnsweredAt     *time.Time `json:"answered_at,omitempty"`
+	IsPublic       bool       `json:"is_public" gorm:"default:false"`       // visible to every participant
+	IsAnnouncement bool       `json:"is_announcement" gorm:"default:false"` // posted by the staff, no question
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type AskClarificationRequest struct {
+	ProblemID *string `json:"problem_id" binding:"omitempty,uuid"`
+	Question  string  `json:"question" binding:"required,max=2000"`
+}
+
+type AnswerClarificationRequest struct {
+	Answer   string `json:"answer" binding:"required,max=2000"`
+	IsPublic bool   `json:"is_public"` // broadcast the answer to all participants
+}
+
+type CreateAnnouncementRequest struct {
+	ProblemID *string `json:"problem_id" binding:"omitempty,uuid"`
+	Message   string  `json:"message" binding:"required,max=2000"`
+}
+
+type ClarificationResponse struct {
+	ID             string     `json:"id"`
+	ContestID      string     `json:"contest_id"`
+	ProblemID      *string    `json:"problem_id,omitempty"`
+	AskedBy        string     `json:"asked_by"`
+	Question       string     `json:"question,omitempty"`
+	Answer         string     `json:"answer,omitempty"`
+	AnsweredBy     *string    `json:"answered_by,omitempty"`
+	AnsweredAt     *time.Time `json:"answered_at,omitempty"`
+	IsPublic       bool       `json:"is_public"`
+	IsAnnouncement bool       `json:"is_announcement"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ClarificationEvent is pushed to the subscribers of a contest stream
+type ClarificationEvent struct {
+	Type          string                `json:"type"`
+	Clarification ClarificationResponse `json:"clarification"`
+}
+
+type ClarificationRepository interface {
+	CreateClarification(ctx context.Context, clarification *Clarification) error
+	GetClarificationByID(ctx context.Context, id string) (*Clarification, error)
+	GetContestClarifications(ctx context.Context, contestID string) ([]Clarification, error)
+	GetUserClarifications(ctx context.Context, contestID string, userID string) ([]Clarification, error)
+	UpdateClarification(ctx context.Context, clarification *Clarification) error
+}
+
+type ClarificationUseCase interface {
+	AskClarification(ctx context.Context, userID string, contestID string, req *AskClarificationRequest) (*ClarificationResponse, error)
+	AnswerClarification(ctx context.Context, userID string, role string, contestID string, clarificationID string, req *AnswerClarificationRequest) (*ClarificationResponse, error)
+	CreateAnnouncement(ctx context.Context, userID string, role string, contestID string, req *CreateAnnouncementRequest) (*ClarificationResponse, error)
+	GetClarifications(ctx context.Context, userID string, role string, contestID string) ([]ClarificationResponse, error)
+	// Subscribe streams the events visible to the user until unsubscribe is called
+	Subscribe(ctx context.Context, userID string, role string, contestID string) (events <-chan ClarificationEvent, unsubscribe func(), err error)
+}
@@ -8,91 +8,234 @@ import (
 )
 
 type Contest struct {
-	Id             string         `json:"id" gorm:"primaryKey";type:uuid"`
-	Name           string         `json:"name" gorm:"not null"`
-	Description    string         `json:"description" gorm:""`
-	StartTime      time.Time      `json:"start_time" gorm:"not null"`
-	EndTime        time.Time      `json:"end_time" gorm:"not null"`
-	Duration       int            `json:"duration" gorm:"not null"` // in minutes
-	Visible        bool           `json:"visible" gorm:"default:false"`
-	CreatedBy      string         `json:"created_by" gorm:"type:uuid;not null"` //refrences User(Id)
-	IsActive       bool           `json:"is_active" gorm:"default:false"`
-	ProblemSetters pq.StringArray `json:"problem_setters" gorm:"type:text[]"`
-	CreatedAt      time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt      time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	Id                     string         `json:"id" gorm:"primaryKey;type:uuid"`
+	Name                   string         `json:"name" gorm:"not null"`
+	Description            string         `json:"description" gorm:""`
+	StartTime              time.Time      `json:"start_time" gorm:"not null"`
+	EndTime                time.Time      `json:"end_time" gorm:"not null"`
+	Duration               int            `json:"duration" gorm:"not null"` // in minutes
+	Visible                bool           `json:"visible" gorm:"default:false"`
+	CreatedBy              string         `json:"created_by" gorm:"type:uuid;not null"` //refrences User(Id)
+	IsActive               bool           `json:"is_active" gorm:"default:false"`
+	ProblemSetters         pq.StringArray `json:"problem_setters" gorm:"type:text[]"`
+	IsTeamContest          bool           `json:"is_team_contest" gorm:"default:false"`         // participants register as teams
+	IsWindowed             bool           `json:"is_windowed" gorm:"default:false"`             // participants start any time in the window and get Duration minutes
+	SeriesID               *string        `json:"series_id,omitempty" gorm:"type:uuid;index"`   // references ContestSeries(ID)
+	Division               string         `json:"division"`                                     // e.g. Div. 1, only meaningful inside a series
+	Visibility             string         `json:"visibility" gorm:"default:public"`             // public, unlisted or private
+	InviteCode             string         `json:"invite_code"`                                  // grants registration to a private contest
+	AllowedEmails          pq.StringArray `json:"allowed_emails" gorm:"type:text[]"`            // users allowed in a private contest
+	AllowedDomains         pq.StringArray `json:"allowed_domains" gorm:"type:text[]"`           // email domains allowed in a private contest
+	MaxParticipants        int            `json:"max_participants" gorm:"default:0"`            // 0 means unlimited, teams count as one participant
+	RequiresApproval       bool           `json:"requires_approval" gorm:"default:false"`       // registrations wait for an admin approval
+	RegistrationOpensAt    *time.Time     `json:"registration_opens_at,omitempty"`              // nil means registration is open right away
+	RegistrationClosesAt   *time.Time     `json:"registration_closes_at,omitempty"`             // nil means DefaultRegistrationCutoff before start
+	UnregistrationClosesAt *time.Time     `json:"unregistration_closes_at,omitempty"`           // nil means DefaultUnregistrationCutoff before start
+	AllowLateRegistration  bool           `json:"allow_late_registration" gorm:"default:false"` // users may join a running contest with the time left
+	PlagiarismCheckedAt    *time.Time     `json:"plagiarism_checked_at,omitempty"`              // last plagiarism check of the contest, nil until checked
+	CreatedAt              time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt              time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 type CreateContestRequest struct {
-	Name           string    `json:"name" binding:"required"`
-	Description    string    `json:"description"`
-	StartTime      time.Time `json:"start_time" binding:"required"`
-	EndTime        time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
-	Duration       int       `json:"duration" binding:"required,gt=0"` // in minutes
-	Visible        bool      `json:"visible"`
-	IsActive       bool      `json:"is_active"`
-	ProblemSetters []string  `json:"problem_setters"`
+	Name                   string     `json:"name" binding:"required"`
+	Description            string     `json:"description"`
+	StartTime              time.Time  `json:"start_time" binding:"required"`
+	EndTime                time.Time  `json:"end_time" binding:"required,gtfield=StartTime"`
+	Duration               int        `json:"duration" binding:"required,gt=0"` // in minutes
+	Visible                bool       `json:"visible"`
+	IsActive               bool       `json:"is_active"`
+	IsTeamContest          bool       `json:"is_team_contest"`
+	IsWindowed             bool       `json:"is_windowed"`
+	SeriesID               *string    `json:"series_id" binding:"omitempty,uuid"`
+	Division               string     `json:"division"`
+	Visibility             string     `json:"visibility" binding:"omitempty,oneof=public unlisted private"` // default: public
+	InviteCode             string     `json:"invite_code"`                                                  // generated for private contests when empty
+	AllowedEmails          []string   `json:"allowed_emails" binding:"omitempty,dive,email"`
+	AllowedDomains         []string   `json:"allowed_domains"`
+	MaxParticipants        int        `json:"max_participants" binding:"omitempty,gte=0"` // 0 means unlimited
+	RequiresApproval       bool       `json:"requires_approval"`
+	RegistrationOpensAt    *time.Time `json:"registration_opens_at"`
+	RegistrationClosesAt   *time.Time `json:"registration_closes_at"`
+	UnregistrationClosesAt *time.Time `json:"unregistration_closes_at"`
+	AllowLateRegistration  bool       `json:"allow_late_registration"`
+	ProblemSetters         []string   `json:"problem_setters"`
 }
 
 type CreateContestResponse struct {
-	Id             string    `json:"id"`
-	Name           string    `json:"name"`
-	Description    string    `json:"description"`
-	StartTime      time.Time `json:"start_time"`
-	EndTime        time.Time `json:"end_time"`
-	Duration       int       `json:"duration"` // in minutes
-	Visible        bool      `json:"visible"`
-	IsActive       bool      `json:"is_active"`
-	ProblemSetters []string  `json:"problem_setters"`
-	CreatedBy      string    `json:"created_by"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	Id                     string     `json:"id"`
+	Name                   string     `json:"name"`
+	Description            string     `json:"description"`
+	StartTime              time.Time  `json:"start_time"`
+	EndTime                time.Time  `json:"end_time"`
+	Duration               int        `json:"duration"` // in minutes
+	Visible                bool       `json:"visible"`
+	IsActive               bool       `json:"is_active"`
+	IsTeamContest          bool       `json:"is_team_contest"`
+	IsWindowed             bool       `json:"is_windowed"`
+	SeriesID               *string    `json:"series_id,omitempty"`
+	Division               string     `json:"division"`
+	Visibility             string     `json:"visibility"`
+	InviteCode             string     `json:"invite_code,omitempty"` // only shown to the contest staff
+	AllowedEmails          []string   `json:"allowed_emails,omitempty"`
+	AllowedDomains         []string   `json:"allowed_domains,omitempty"`
+	MaxParticipants        int        `json:"max_participants"`
+	RequiresApproval       bool       `json:"requires_approval"`
+	RegistrationOpensAt    *time.Time `json:"registration_opens_at,omitempty"`
+	RegistrationClosesAt   time.Time  `json:"registration_closes_at"`
+	UnregistrationClosesAt time.Time  `json:"unregistration_closes_at"`
+	AllowLateRegistration  bool       `json:"allow_late_registration"`
+	ProblemSetters         []string   `json:"problem_setters"`
+	CreatedBy              string     `json:"created_by"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
 }
 
 type UpdateContestRequest struct {
-	Id             string    `json:"id" binding:"required,uuid"`
-	Name           string    `json:"name" binding:"required"`
-	Description    string    `json:"description"`
-	StartTime      time.Time `json:"start_time" binding:"required"`
-	EndTime        time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
-	Duration       int       `json:"duration" binding:"required,gt=0"` // in minutes
-	Visible        bool      `json:"visible"`
-	ProblemSetters []string  `json:"problem_setters"`
-	IsActive       bool      `json:"is_active"`
+	Id                     string     `json:"id" binding:"required,uuid"`
+	Name                   string     `json:"name" binding:"required"`
+	Description            string     `json:"description"`
+	StartTime              time.Time  `json:"start_time" binding:"required"`
+	EndTime                time.Time  `json:"end_time" binding:"required,gtfield=StartTime"`
+	Duration               int        `json:"duration" binding:"required,gt=0"` // in minutes
+	Visible                bool       `json:"visible"`
+	ProblemSetters         []string   `json:"problem_setters"`
+	IsActive               bool       `json:"is_active"`
+	IsTeamContest          bool       `json:"is_team_contest"`
+	IsWindowed             bool       `json:"is_windowed"`
+	SeriesID               *string    `json:"series_id" binding:"omitempty,uuid"`
+	Division               string     `json:"division"`
+	Visibility             string     `json:"visibility" binding:"omitempty,oneof=public unlisted private"` // default: public
+	InviteCode             string     `json:"invite_code"`                                                  // kept when empty, generated for private contests without one
+	AllowedEmails          []string   `json:"allowed_emails" binding:"omitempty,dive,email"`
+	AllowedDomains         []string   `json:"allowed_domains"`
+	MaxParticipants        int        `json:"max_participants" binding:"omitempty,gte=0"` // 0 means unlimited
+	RequiresApproval       bool       `json:"requires_approval"`
+	RegistrationOpensAt    *time.Time `json:"registration_opens_at"`
+	RegistrationClosesAt   *time.Time `json:"registration_closes_at"`
+	UnregistrationClosesAt *time.Time `json:"unregistration_closes_at"`
+	AllowLateRegistration  bool       `json:"allow_late_registration"`
 }
 
 type UpdateContestResponse struct {
-	Id             string    `json:"id"`
-	Name           string    `json:"name"`
-	Description    string    `json:"description"`
-	StartTime      time.Time `json:"start_time"`
-	EndTime        time.Time `json:"end_time"`
-	Duration       int       `json:"duration"` // in minutes
-	Visible        bool      `json:"visible"`
-	ProblemSetters []string  `json:"problem_setters"`
-	IsActive       bool      `json:"is_active"`
-	CreatedBy      string    `json:"created_by"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	Id                     string     `json:"id"`
+	Name                   string     `json:"name"`
+	Description            string     `json:"description"`
+	StartTime              time.Time  `json:"start_time"`
+	EndTime                time.Time  `json:"end_time"`
+	Duration               int        `json:"duration"` // in minutes
+	Visible                bool       `json:"visible"`
+	ProblemSetters         []string   `json:"problem_setters"`
+	IsActive               bool       `json:"is_active"`
+	IsTeamContest          bool       `json:"is_team_contest"`
+	IsWindowed             bool       `json:"is_windowed"`
+	SeriesID               *string    `json:"series_id,omitempty"`
+	Division               string     `json:"division"`
+	Visibility             string     `json:"visibility"`
+	InviteCode             string     `json:"invite_code,omitempty"` // only shown to the contest staff
+	AllowedEmails          []string   `json:"allowed_emails,omitempty"`
+	AllowedDomains         []string   `json:"allowed_domains,omitempty"`
+	MaxParticipants        int        `json:"max_participants"`
+	RequiresApproval       bool       `json:"requires_approval"`
+	RegistrationOpensAt    *time.Time `json:"registration_opens_at,omitempty"`
+	RegistrationClosesAt   time.Time  `json:"registration_closes_at"`
+	UnregistrationClosesAt time.Time  `json:"unregistration_closes_at"`
+	AllowLateRegistration  bool       `json:"allow_late_registration"`
+	CreatedBy              string     `json:"created_by"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
 }
 
 type DeleteContestRequest struct {
 	Id string `json:"id" binding:"required,uuid"`
 }
 
+// Registration cutoffs used when a contest does not set its own
+const (
+	DefaultRegistrationCutoff   = 5 * time.Minute
+	DefaultUnregistrationCutoff = 2 * time.Minute
+)
+
+// RegistrationCloseTime returns when registration closes. Windowed contests
+// and contests allowing late registration accept registrations until the end.
+func (c *Contest) RegistrationCloseTime() time.Time {
+	if c.RegistrationClosesAt != nil {
+		return *c.RegistrationClosesAt
+	}
+	if c.IsWindowed || c.AllowLateRegistration {
+		return c.EndTime
+	}
+	return c.StartTime.Add(-DefaultRegistrationCutoff)
+}
+
+// UnregistrationCloseTime returns the last moment a participant can unregister
+func (c *Contest) UnregistrationCloseTime() time.Time {
+	if c.UnregistrationClosesAt != nil {
+		return *c.UnregistrationClosesAt
+	}
+	if c.IsWindowed {
+		return c.EndTime
+	}
+	return c.StartTime.Add(-DefaultUnregistrationCutoff)
+}
+
+// Contest visibility modes
+const (
+	ContestVisibilityPublic   = "public"   // listed and open to everyone
+	ContestVisibilityUnlisted = "unlisted" // open to everyone with the contest ID, not listed
+	ContestVisibilityPrivate  = "private"  // invite code or allowlist only
+)
+
+// ContestProblem links a problem to the contest it is used in
+type ContestProblem struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	ContestID string    `json:"contest_id" gorm:"type:uuid;not null;uniqueIndex:idx_contest_problem"` // references Contest(Id)
+	ProblemID string    `json:"problem_id" gorm:"type:uuid;not null;uniqueIndex:idx_contest_problem"` // references Problem(UniqueID)
+	Index     string    `json:"index" gorm:"column:problem_index;type:varchar(5);not null"`           // A, B, C ...
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type AddContestProblemRequest struct {
+	ProblemID string `json:"problem_id" binding:"required,uuid"`
+	Index     string `json:"index" binding:"required,max=5"`
+}
+
+type ContestProblemResponse struct {
+	ContestID string `json:"contest_id"`
+	ProblemID string `json:"problem_id"`
+	Index     string `json:"index"`
+	Title     string `json:"title"`
+}
+
 type ContestRepository interface {
 	CreateContest(ctx context.Context, contest *Contest) error
 	GetByID(ctx context.Context, id string) (*Contest, error)
+	// LockContest reads a contest and locks its row until the transaction
+	// ends, changes to the seats of the contest are serialized by it
+	LockContest(ctx context.Context, id string) (*Contest, error)
 	UpdateContest(ctx context.Context, contest *Contest) error
 	DeleteContest(ctx context.Context, id string) error
 	CheckContestInTimeWindow(ctx context.Context, startTime, endTime time.Time) ([]Contest, error)
 	GetAllContests(ctx context.Context) ([]Contest, error)
+	AddContestProblem(ctx context.Context, contestProblem *ContestProblem) error
+	RemoveContestProblem(ctx context.Context, contestID string, problemID string) error
+	GetContestProblems(ctx context.Context, contestID string) ([]ContestProblem, error)
+	GetContestProblemsByProblemID(ctx context.Context, problemID string) ([]ContestProblem, error)
+	GetContestsBySeriesID(ctx context.Context, seriesID string) ([]Contest, error)
+	// GetContestsPendingPlagiarismCheck returns the contests ended before the given time and never checked for plagiarism
+	GetContestsPendingPlagiarismCheck(ctx context.Context, endedBefore time.Time) ([]Contest, error)
+	SetPlagiarismCheckedAt(ctx context.Context, contestID string, checkedAt time.Time) error
 }
 
 type ContestUseCase interface {
 	CreateContest(ctx context.Context, req *CreateContestRequest, userId string) (*CreateContestResponse, error)
 	UpdateContest(ctx context.Context, req *UpdateContestRequest) (*UpdateContestResponse, error)
-	GetContestDetails(ctx context.Context, id string) (*CreateContestResponse, error)
+	GetContestDetails(ctx context.Context, id string, userID string, role string) (*CreateContestResponse, error)
+	GetVisibleContests(ctx context.Context, userID string, role string) ([]CreateContestResponse, error)
 	DeleteContest(ctx context.Context, req *DeleteContestRequest) error
 	StartContest(ctx context.Context, id string) error
 	GetAllContests(ctx context.Context) ([]CreateContestResponse, error)
+	AddProblemToContest(ctx context.Context, contestID string, req *AddContestProblemRequest) (*ContestProblemResponse, error)
+	RemoveProblemFromContest(ctx context.Context, contestID string, problemID string) error
+	GetContestProblems(ctx context.Context, contestID string, userID string, role string) ([]ContestProblemResponse, error)
 }
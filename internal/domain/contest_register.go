@@ -5,27 +5,76 @@ import (
 	"time"
 )
 
+// Registration statuses. Registered and approved registrations take part in
+// the contest, pending ones wait for an admin decision and waitlisted ones for
+// a free seat. Disqualified participants are excluded from the standings.
+const (
+	RegistrationStatusRegistered   = "registered"
+	RegistrationStatusUnregistered = "unregistered"
+	RegistrationStatusPending      = "pending"
+	RegistrationStatusApproved     = "approved"
+	RegistrationStatusRejected     = "rejected"
+	RegistrationStatusWaitlisted   = "waitlisted"
+	RegistrationStatusDisqualified = "disqualified"
+)
+
+// ActiveRegistrationStatuses are the statuses of users taking part in a contest
+var ActiveRegistrationStatuses = []string{RegistrationStatusRegistered, RegistrationStatusApproved}
+
 // ContestRegistration is the database model
 type ContestRegistration struct {
-	ID           string    `json:"id" gorm:"primaryKey;type:uuid"`
-	UserID       string    `json:"user_id" gorm:"type:uuid;not null"`
-	ContestID    string    `json:"contest_id" gorm:"type:uuid;not null"`
-	RegisteredAt time.Time `json:"registered_at" gorm:"autoCreateTime"`
-	Status       string    `json:"status" gorm:"default:registered"`
-	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID           string     `json:"id" gorm:"primaryKey;type:uuid"`
+	UserID       string     `json:"user_id" gorm:"type:uuid;not null"`
+	ContestID    string     `json:"contest_id" gorm:"type:uuid;not null"`
+	TeamID       *string    `json:"team_id,omitempty" gorm:"type:uuid;index"` // set when registered as part of a team
+	RegisteredAt time.Time  `json:"registered_at" gorm:"autoCreateTime"`
+	Status       string     `json:"status" gorm:"default:registered"`
+	StartedAt    *time.Time `json:"started_at,omitempty"` // personal start in windowed contests
+	EndsAt       *time.Time `json:"ends_at,omitempty"`    // personal end in windowed contests
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// IsActive reports whether the registration takes part in the contest
+func (r *ContestRegistration) IsActive() bool {
+	return r.Status == RegistrationStatusRegistered || r.Status == RegistrationStatusApproved
 }
 
 type ContestRegisterRequest struct {
-	ContestID string `json:"contest_id" binding:"required,uuid4"`
+	ContestID  string `json:"contest_id" binding:"required,uuid4"`
+	InviteCode string `json:"invite_code"` // required for private contests unless the user is allowlisted
 }
 
 type ContestRegisterResponse struct {
-	ID           string    `json:"id"`
-	UserID       string    `json:"user_id"`
-	ContestID    string    `json:"contest_id"`
-	RegisteredAt time.Time `json:"registered_at"`
-	Status       string    `json:"status"`
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	ContestID    string     `json:"contest_id"`
+	TeamID       *string    `json:"team_id,omitempty"`
+	RegisteredAt time.Time  `json:"registered_at"`
+	Status       string     `json:"status"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	EndsAt       *time.Time `json:"ends_at,omitempty"`
+}
+
+type StartContestWindowRequest struct {
+	ContestID string `json:"contest_id" binding:"required,uuid4"`
+}
+
+type TeamContestRegisterRequest struct {
+	ContestID  string `json:"contest_id" binding:"required,uuid4"`
+	TeamID     string `json:"team_id" binding:"required,uuid4"`
+	InviteCode string `json:"invite_code"` // required for private contests unless every member is allowlisted
+}
+
+type TeamContestRegisterResponse struct {
+	TeamID        string                    `json:"team_id"`
+	ContestID     string                    `json:"contest_id"`
+	Registrations []ContestRegisterResponse `json:"registrations"`
+}
+
+type TeamContestUnregisterRequest struct {
+	ContestID string `json:"contest_id" binding:"required,uuid4"`
+	TeamID    string `json:"team_id" binding:"required,uuid4"`
 }
 
 type ContestUnregisterRequest struct {
@@ -40,16 +89,38 @@ type ContestUnregisterResponse struct {
 	Status       string    `json:"status"`
 }
 
+type RegistrationDecisionRequest struct {
+	ContestID       string   `json:"contest_id" binding:"required,uuid4"`
+	RegistrationIDs []string `json:"registration_ids" binding:"required,min=1,dive,uuid"`
+}
+
+type RegistrationDecisionResponse struct {
+	Registrations []ContestRegisterResponse `json:"registrations"`
+	Errors        []string                  `json:"errors,omitempty"`
+}
+
 type AllRegisteredContestForUserResponse struct {
 	Registrations []ContestRegisterResponse `json:"registrations"`
 }
 
 type ContestRegisterRepository interface {
-	CreateContestRegistration(ctx context.Context, userID string, contestID string) (*ContestRegistration, error)
+	CreateContestRegistration(ctx context.Context, userID string, contestID string, status string) (*ContestRegistration, error)
+	GetRegistrationByID(ctx context.Context, id string) (*ContestRegistration, error)
 	GetRegistrationByUserAndContest(ctx context.Context, userID string, contestID string) (*ContestRegistration, error)
 	UpdateRegistrationStatus(ctx context.Context, userID string, contestID string, status string) error
 	GetAllRegistrationsByUserID(ctx context.Context, userID string) ([]ContestRegistration, error)
 	GetAllRegistrationsForAdmin(ctx context.Context) ([]ContestRegistration, error)
+	RegisterTeamMembers(ctx context.Context, contestID string, teamID string, userIDs []string, status string) ([]ContestRegistration, error)
+	UpdateTeamRegistrationStatus(ctx context.Context, teamID string, contestID string, status string) error
+	GetRegistrationsByContestID(ctx context.Context, contestID string) ([]ContestRegistration, error)
+	// CountActiveParticipants counts the active individual registrations and teams of a contest
+	CountActiveParticipants(ctx context.Context, contestID string) (int64, error)
+	// GetWaitlistedRegistrations returns the waitlisted registrations, longest waiting first
+	GetWaitlistedRegistrations(ctx context.Context, contestID string) ([]ContestRegistration, error)
+	GetDisqualifiedUserIDs(ctx context.Context, contestID string) ([]string, error)
+	// StartContestWindow sets the personal window of a registration, it fails
+	// when the window was already started
+	StartContestWindow(ctx context.Context, userID string, contestID string, startedAt time.Time, endsAt time.Time) error
 }
 
 type ContestRegisterUseCase interface {
@@ -57,4 +128,9 @@ type ContestRegisterUseCase interface {
 	UnregisterContest(ctx context.Context, userID string, req *ContestUnregisterRequest) error
 	GetAllRegistrations(ctx context.Context, userID string) (*AllRegisteredContestForUserResponse, error)
 	GetAllRegistrationsForAdmin(ctx context.Context) (*AllRegisteredContestForUserResponse, error)
+	RegisterTeamContest(ctx context.Context, userID string, req *TeamContestRegisterRequest) (*TeamContestRegisterResponse, error)
+	UnregisterTeamContest(ctx context.Context, userID string, req *TeamContestUnregisterRequest) error
+	StartContestWindow(ctx context.Context, userID string, req *StartContestWindowRequest) (*ContestRegisterResponse, error)
+	ApproveRegistrations(ctx context.Context, req *RegistrationDecisionRequest) (*RegistrationDecisionResponse, error)
+	RejectRegistrations(ctx context.Context, req *RegistrationDecisionRequest) (*RegistrationDecisionResponse, error)
 }
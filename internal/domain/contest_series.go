@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ContestSeries groups contests that run in parallel, such as the divisions
+// of a round. Contests of a series may overlap in time and share problems.
+type ContestSeries struct {
+	ID                         string    `json:"id" gorm:"primaryKey;type:uuid"`
+	Name                       string    `json:"name" gorm:"uniqueIndex;not null"`
+	Description                string    `json:"description"`
+	AllowMultipleRegistrations bool      `json:"allow_multiple_registrations" gorm:"default:false"` // may a user register in several overlapping contests of the series
+	CreatedBy                  string    `json:"created_by" gorm:"type:uuid;not null"`              // references User(Id)
+	CreatedAt                  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt                  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type CreateContestSeriesRequest struct {
+	Name                       string `json:"name" binding:"required"`
+	Description                string `json:"description"`
+	AllowMultipleRegistrations bool   `json:"allow_multiple_registrations"`
+}
+
+type UpdateContestSeriesRequest struct {
+	ID                         string `json:"id" binding:"required,uuid"`
+	Name                       string `json:"name" binding:"required"`
+	Description                string `json:"description"`
+	AllowMultipleRegistrations bool   `json:"allow_multiple_registrations"`
+}
+
+type ContestSeriesResponse struct {
+	ID                         string                  `json:"id"`
+	Name                       string                  `json:"name"`
+	Description                string                  `json:"description"`
+	AllowMultipleRegistrations bool                    `json:"allow_multiple_registrations"`
+	Contests                   []CreateContestResponse `json:"contests,omitempty"`
+	CreatedBy                  string                  `json:"created_by"`
+	CreatedAt                  time.Time               `json:"created_at"`
+	UpdatedAt                  time.Time               `json:"updated_at"`
+}
+
+type ContestSeriesRepository interface {
+	CreateSeries(ctx context.Context, series *ContestSeries) error
+	GetSeriesByID(ctx context.Context, id string) (*ContestSeries, error)
+	GetAllSeries(ctx context.Context) ([]ContestSeries, error)
+	UpdateSeries(ctx context.Context, series *ContestSeries) error
+}
+
+type ContestSeriesUseCase interface {
+	CreateSeries(ctx context.Context, req *CreateContestSeriesRequest, userID string) (*ContestSeriesResponse, error)
+	UpdateSeries(ctx context.Context, req *UpdateContestSeriesRequest) (*ContestSeriesResponse, error)
+	GetSeries(ctx context.Context, id string) (*ContestSeriesResponse, error)
+	GetAllSeries(ctx context.Context) ([]ContestSeriesResponse, error)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Plagiarism case statuses
+const (
+	PlagiarismStatusSuspected = "suspected" // found by the similarity check, not reviewed yet
+	PlagiarismStatusConfirmed = "confirmed" // confirmed by an admin, the users involved are disqualified
+	PlagiarismStatusDismissed = "dismissed"
+)
+
+// PlagiarismCase is a pair of accepted submissions to the same contest problem
+// whose code is suspiciously similar
+type PlagiarismCase struct {
+	ID            string     `json:"id" gorm:"primaryKey;type:uuid"`
+	ContestID     string     `json:"contest_id" gorm:"type:uuid;not null;index"`                                // references Contest(Id)
+	ProblemID     string     `json:"problem_id" gorm:"type:uuid;not null"`                                      // references Problem(UniqueID)
+	SubmissionAID string     `json:"submission_a_id" gorm:"type:uuid;not null;uniqueIndex:idx_plagiarism_pair"` // references Submission(UniqueID)
+	SubmissionBID string     `json:"submission_b_id" gorm:"type:uuid;not null;uniqueIndex:idx_plagiarism_pair"` // references Submission(UniqueID)
+	UserAID       string     `json:"user_a_id" gorm:"type:uuid;not null"`
+	UserBID       string     `json:"user_b_id" gorm:"type:uuid;not null"`
+	Similarity    float64    `json:"similarity"` // between 0 and 1
+	Status        string     `json:"status" gorm:"default:suspected;index"`
+	ReviewedBy    *string    `json:"reviewed_by,omitempty" gorm:"type:uuid"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	Note          string     `json:"note"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type ReviewPlagiarismCaseRequest struct {
+	CaseID string `json:"case_id" binding:"required,uuid"`
+	Status string `json:"status" binding:"required,oneof=confirmed dismissed"`
+	// DisqualifyUserIDs limits a confirmation to some users of the case, both are disqualified when empty
+	DisqualifyUserIDs []string `json:"disqualify_user_ids" binding:"omitempty,dive,uuid"`
+	Note              string   `json:"note"`
+}
+
+type PlagiarismRepository interface {
+	// CreateCase stores a case, a pair of submissions already stored is left untouched
+	CreateCase(ctx context.Context, plagiarismCase *PlagiarismCase) error
+	GetCaseByID(ctx context.Context, id string) (*PlagiarismCase, error)
+	// GetContestCases returns the cases of a contest, most similar first. An empty status returns every case.
+	GetContestCases(ctx context.Context, contestID string, status string) ([]PlagiarismCase, error)
+	UpdateCase(ctx context.Context, plagiarismCase *PlagiarismCase) error
+}
+
+type PlagiarismUseCase interface {
+	RequestCheck(ctx context.Context, contestID string) error
+	GetContestCases(ctx context.Context, contestID string, status string) ([]PlagiarismCase, error)
+	ReviewCase(ctx context.Context, req *ReviewPlagiarismCaseRequest, reviewerID string) (*PlagiarismCase, error)
+}
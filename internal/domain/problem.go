@@ -2,27 +2,73 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type Problem struct {
-	UniqueID           string    `json:"unique_id" gorm:"primaryKey;type:uuid"`
-	Title              string    `json:"title" gorm:"not null"`
-	Statement          string    `json:"statement" gorm:"type:text;not null"`
-	Difficulty         string    `json:"difficulty" gorm:"not null"`
-	TimeLimitInSeconds int       `json:"time_limit_in_seconds" gorm:"not null"`
-	MemoryLimitInMB    int       `json:"memory_limit_in_mb" gorm:"not null"`
-	CreatedBy          string    `json:"created_by" gorm:"type:uuid;not null"` // references User(Id)
-	CreatedAt          time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt          time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	UniqueID           string         `json:"unique_id" gorm:"primaryKey;type:uuid"`
+	Title              string         `json:"title" gorm:"not null"`
+	Statement          string         `json:"statement" gorm:"type:text;not null"` // legend, the story and task of the problem
+	Difficulty         string         `json:"difficulty" gorm:"not null"`
+	Rating             int            `json:"rating" gorm:"default:0;index"`            // numeric difficulty, e.g. 800 to 3500, 0 when unrated
+	Tags               pq.StringArray `json:"tags" gorm:"type:text[];index:,type:gin"`  // dp, graphs, greedy ...
+	Visibility         string         `json:"visibility" gorm:"default:public;index"`   // draft, contest or public, see ProblemVisibility*
+	Locale             string         `json:"locale" gorm:"type:varchar(8);default:en"` // language of the title and statement, see ProblemTranslation for the others
+	TimeLimitInSeconds int            `json:"time_limit_in_seconds" gorm:"not null"`
+	MemoryLimitInMB    int            `json:"memory_limit_in_mb" gorm:"not null"`
+	CreatedBy          string         `json:"created_by" gorm:"type:uuid;not null"` // references User(Id)
+	CoAuthors          pq.StringArray `json:"co_authors" gorm:"type:text[]"`        // users managing the problem with its creator, references User(Id)
+	CreatedAt          time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+
+	StatementSections `gorm:"embedded"`
+
+	// Checker and Validator are the sources of a custom output checker and of
+	// an input validator, they come with imported problem packages
+	Checker           string `json:"-" gorm:"type:text"`
+	CheckerLanguage   string `json:"-"`
+	Validator         string `json:"-" gorm:"type:text"`
+	ValidatorLanguage string `json:"-"`
+
+	// SearchVector indexes the title and statement for full-text search, it is computed by Postgres
+	SearchVector string `json:"-" gorm:"->;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('english', coalesce(title, '')), 'A') || setweight(to_tsvector('english', coalesce(statement, '')), 'B')) STORED;index:,type:gin"`
+}
+
+// StatementSections are the parts of a problem statement following the
+// legend. Statements are Markdown with LaTeX math between $ (inline) or $$
+// (display) delimiters, rendered by the client. Attachments are referenced
+// as attachment:<file name>, e.g. ![graph](attachment:graph.png).
+type StatementSections struct {
+	InputFormat  string `json:"input_format" gorm:"type:text"`
+	OutputFormat string `json:"output_format" gorm:"type:text"`
+	Constraints  string `json:"constraints" gorm:"type:text"`
+	Notes        string `json:"notes" gorm:"type:text"`
 }
 
+// Problem visibility states. Problems stored before visibility existed are
+// public, new problems start as drafts.
+const (
+	ProblemVisibilityDraft   = "draft"   // only the author and admins
+	ProblemVisibilityContest = "contest" // contestants once one of its contests started, published when they all end
+	ProblemVisibilityPublic  = "public"  // practice archive, everyone
+)
+
 type ProblemCreationRequest struct {
-	Title              string `json:"title" binding:"required"`
-	Statement          string `json:"statement" binding:"required"`
-	Difficulty         string `json:"difficulty" binding:"required,oneof=easy medium hard"`
-	TimeLimitInSeconds int    `json:"time_limit_in_seconds,omitempty" binding:"omitempty,gt=0"` // default: 1 second
-	MemoryLimitInMB    int    `json:"memory_limit_in_mb,omitempty" binding:"omitempty,gt=0"`    // default: 256 MB
+	Title              string   `json:"title" binding:"required"`
+	Statement          string   `json:"statement" binding:"required"`
+	Difficulty         string   `json:"difficulty" binding:"required,oneof=easy medium hard"`
+	Rating             int      `json:"rating" binding:"omitempty,min=0,max=5000"`
+	Tags               []string `json:"tags" binding:"omitempty,max=10,dive,min=1,max=32"`
+	Visibility         string   `json:"visibility" binding:"omitempty,oneof=draft contest public"` // default: draft
+	Locale             string   `json:"locale" binding:"omitempty,oneof=en ru hi"`                 // language of the title and statement, default: en
+	CoAuthors          []string `json:"co_authors" binding:"omitempty,max=10,dive,uuid"`           // problem setters managing the problem with its creator
+	TimeLimitInSeconds int      `json:"time_limit_in_seconds,omitempty" binding:"omitempty,gt=0"`  // default: 1 second
+	MemoryLimitInMB    int      `json:"memory_limit_in_mb,omitempty" binding:"omitempty,gt=0"`     // default: 256 MB
+
+	StatementSections
 }
 
 type ProblemCreationResponse struct {
@@ -30,20 +76,35 @@ type ProblemCreationResponse struct {
 	Title              string    `json:"title"`
 	Statement          string    `json:"statement"`
 	Difficulty         string    `json:"difficulty"`
+	Rating             int       `json:"rating"`
+	Tags               []string  `json:"tags"`
+	Visibility         string    `json:"visibility"`
+	Locale             string    `json:"locale"` // language of the returned title and statement
 	TimeLimitInSeconds int       `json:"time_limit_in_seconds"`
 	MemoryLimitInMB    int       `json:"memory_limit_in_mb"`
 	CreatedBy          string    `json:"created_by"`
+	CoAuthors          []string  `json:"co_authors"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+	AvailableLocales   []string  `json:"available_locales,omitempty"` // languages the statement can be read in, for single problems
+
+	StatementSections
 }
 
 type ProblemUpdateRequest struct {
-	UniqueID           string `json:"unique_id" binding:"required,uuid"`
-	Title              string `json:"title" binding:"required"`
-	Statement          string `json:"statement" binding:"required"`
-	Difficulty         string `json:"difficulty" binding:"required,oneof=easy medium hard"`
-	TimeLimitInSeconds int    `json:"time_limit_in_seconds,omitempty" binding:"omitempty,gt=0"` // default: 1 second
-	MemoryLimitInMB    int    `json:"memory_limit_in_mb,omitempty" binding:"omitempty,gt=0"`    // default: 256 MB
+	UniqueID           string   `json:"unique_id" binding:"required,uuid"`
+	Title              string   `json:"title" binding:"required"`
+	Statement          string   `json:"statement" binding:"required"`
+	Difficulty         string   `json:"difficulty" binding:"required,oneof=easy medium hard"`
+	Rating             int      `json:"rating" binding:"omitempty,min=0,max=5000"`
+	Tags               []string `json:"tags" binding:"omitempty,max=10,dive,min=1,max=32"`
+	Visibility         string   `json:"visibility" binding:"omitempty,oneof=draft contest public"` // default: unchanged
+	Locale             string   `json:"locale" binding:"omitempty,oneof=en ru hi"`                 // default: unchanged
+	CoAuthors          []string `json:"co_authors" binding:"omitempty,max=10,dive,uuid"`           // default: unchanged, only the creator and admins can change them
+	TimeLimitInSeconds int      `json:"time_limit_in_seconds,omitempty" binding:"omitempty,gt=0"`  // default: 1 second
+	MemoryLimitInMB    int      `json:"memory_limit_in_mb,omitempty" binding:"omitempty,gt=0"`     // default: 256 MB
+
+	StatementSections
 }
 
 type ProblemUpdateResponse struct {
@@ -51,37 +112,111 @@ type ProblemUpdateResponse struct {
 	Title              string    `json:"title"`
 	Statement          string    `json:"statement"`
 	Difficulty         string    `json:"difficulty"`
+	Rating             int       `json:"rating"`
+	Tags               []string  `json:"tags"`
+	Visibility         string    `json:"visibility"`
+	Locale             string    `json:"locale"` // language of the returned title and statement
 	TimeLimitInSeconds int       `json:"time_limit_in_seconds"`
 	MemoryLimitInMB    int       `json:"memory_limit_in_mb"`
 	CreatedBy          string    `json:"created_by"`
+	CoAuthors          []string  `json:"co_authors"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+
+	StatementSections
 }
 
 type BulkProblemCreationRequest struct {
 	Problems []ProblemCreationRequest `json:"problems" binding:"required,min=1,dive"`
+	// Mode is atomic when empty, see BulkMode*
+	Mode string `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
 }
 
+// BulkProblemCreationResponse lists the created problems. In atomic mode
+// nothing is created when an item fails.
 type BulkProblemCreationResponse struct {
 	SuccessCount int                       `json:"success_count"`
 	FailedCount  int                       `json:"failed_count"`
 	Problems     []ProblemCreationResponse `json:"problems"`
-	Errors       []string                  `json:"errors,omitempty"`
+	Errors       []BulkItemError           `json:"errors,omitempty"`
+}
+
+// Problem list sort keys
+const (
+	ProblemSortCreatedAt = "created_at"
+	ProblemSortRating    = "rating"
+	ProblemSortTitle     = "title"
+	ProblemSortRelevance = "relevance" // search rank, requires a search query
+)
+
+// DefaultProblemPageSize is the problem list page size when none is given
+const DefaultProblemPageSize = 20
+
+// ProblemFilter holds the filter, sort and pagination parameters of the problem list
+type ProblemFilter struct {
+	Search     string   `form:"search"` // full-text search over title and statement
+	Tags       []string `form:"tags"`   // problems having all these tags
+	Difficulty string   `form:"difficulty" binding:"omitempty,oneof=easy medium hard"`
+	MinRating  int      `form:"min_rating" binding:"omitempty,min=0"`
+	MaxRating  int      `form:"max_rating" binding:"omitempty,min=0"`
+	SortBy     string   `form:"sort_by" binding:"omitempty,oneof=created_at rating title relevance"` // default: relevance when searching, created_at otherwise
+	Order      string   `form:"order" binding:"omitempty,oneof=asc desc"`                            // default: desc
+	Page       int      `form:"page" binding:"omitempty,min=1"`                                      // default: 1
+	PageSize   int      `form:"page_size" binding:"omitempty,min=1,max=100"`                         // default: 20
+
+	// Set by the service for users who are not admins: only the problems
+	// ViewerID can view are listed
+	RestrictVisibility bool   `form:"-"`
+	ViewerID           string `form:"-"`
+
+	// Locales are the languages the viewer prefers, most preferred first
+	Locales []string `form:"-"`
+}
+
+type ProblemListResponse struct {
+	Problems []ProblemCreationResponse `json:"problems"`
+	Total    int64                     `json:"total"`
+	Page     int                       `json:"page"`
+	PageSize int                       `json:"page_size"`
+}
+
+// TagCount is a problem tag with the number of problems using it
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
 }
 
 type ProblemRepository interface {
 	CreateProblem(ctx context.Context, problem *Problem) error
 	GetProblemByID(ctx context.Context, id string) (*Problem, error)
+	// LockProblem reads a problem and locks its row until the transaction
+	// ends, the revisions of the problem are numbered under it
+	LockProblem(ctx context.Context, id string) (*Problem, error)
 	UpdateProblem(ctx context.Context, problem *Problem) error
 	DeleteProblem(ctx context.Context, id string) error
-	GetAllProblems(ctx context.Context) ([]Problem, error)
+	SearchProblems(ctx context.Context, filter *ProblemFilter) ([]Problem, int64, error)
+	// GetAllTags counts the tags of the problems viewerID can view, of every problem when viewerID is empty
+	GetAllTags(ctx context.Context, viewerID string) ([]TagCount, error)
+	// PublishEndedContestProblems makes public the contest-only problems whose contests all ended before the given time
+	PublishEndedContestProblems(ctx context.Context, endedBefore time.Time) (int64, error)
 }
 
 type ProblemUseCase interface {
 	CreateProblem(ctx context.Context, req *ProblemCreationRequest, createdBy string) (*ProblemCreationResponse, error)
 	CreateProblemsInBulk(ctx context.Context, req *BulkProblemCreationRequest, createdBy string) (*BulkProblemCreationResponse, error)
-	GetProblemByID(ctx context.Context, id string) (*ProblemCreationResponse, error)
+	// GetProblemByID returns the problem in the first of the preferred locales it is translated to, in its own language otherwise
+	GetProblemByID(ctx context.Context, id string, userID string, role string, locales []string) (*ProblemCreationResponse, error)
 	UpdateProblem(ctx context.Context, req *ProblemUpdateRequest, userID string) (*ProblemUpdateResponse, error)
 	DeleteProblem(ctx context.Context, id string, userID string) error
-	GetAllProblems(ctx context.Context) ([]ProblemCreationResponse, error)
+	GetAllProblems(ctx context.Context, filter *ProblemFilter, userID string, role string) (*ProblemListResponse, error)
+	GetAllTags(ctx context.Context, userID string, role string) ([]TagCount, error)
+	GetProblemStatement(ctx context.Context, id string, userID string, role string, locales []string) (*ProblemStatement, error)
+	SaveTranslation(ctx context.Context, problemID string, locale string, req *ProblemTranslationRequest, userID string, role string) (*ProblemTranslation, error)
+	GetTranslations(ctx context.Context, problemID string, userID string, role string) ([]ProblemTranslation, error)
+	DeleteTranslation(ctx context.Context, problemID string, locale string, userID string, role string) error
+	// UploadAttachment stores a file under a name unique to the problem, replacing the file of the same name
+	UploadAttachment(ctx context.Context, problemID string, fileName string, contentType string, r io.Reader, userID string, role string) (*ProblemAttachment, error)
+	// OpenAttachment returns an attachment and its content, the caller closes it
+	OpenAttachment(ctx context.Context, problemID string, fileName string, userID string, role string) (*ProblemAttachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, problemID string, fileName string, userID string, role string) error
 }
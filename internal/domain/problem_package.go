@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// MaxProblemPackageSize is the largest problem package zip accepted for import
+const MaxProblemPackageSize = 64 << 20
+
+// Problem package formats
+const (
+	ProblemPackageKattis  = "kattis"  // ICPC problem package format: problem.yaml, data/sample, data/secret
+	ProblemPackagePolygon = "polygon" // Polygon full package: problem.xml, tests/
+)
+
+type ProblemPackageImportRequest struct {
+	// Format is detected from the package files when empty
+	Format     string   `form:"format" binding:"omitempty,oneof=kattis polygon"`
+	Difficulty string   `form:"difficulty" binding:"omitempty,oneof=easy medium hard"` // packages carry no difficulty, medium when empty
+	Rating     int      `form:"rating" binding:"omitempty,min=0,max=5000"`
+	Tags       []string `form:"tags" binding:"omitempty,max=10,dive,min=1,max=32"`
+}
+
+type ProblemPackageImportResponse struct {
+	Problem      ProblemCreationResponse `json:"problem"`
+	SampleCount  int                     `json:"sample_count"`
+	TestCount    int                     `json:"test_count"` // samples included
+	HasChecker   bool                    `json:"has_checker"`
+	HasValidator bool                    `json:"has_validator"`
+}
+
+type ProblemPackageUseCase interface {
+	// ImportPackage creates a draft problem with the tests of a zipped package
+	ImportPackage(ctx context.Context, r io.ReaderAt, size int64, req *ProblemPackageImportRequest, userID string) (*ProblemPackageImportResponse, error)
+	// ExportPackage zips a problem with all its tests, for its creator or admins
+	ExportPackage(ctx context.Context, problemID string, format string, userID string, role string) ([]byte, error)
+}
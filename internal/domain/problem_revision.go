@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ProblemRevision is an immutable snapshot of a problem. A revision is
+// recorded whenever the statement, the limits or the test set change, and
+// submissions are pinned to the revision they were judged against.
+type ProblemRevision struct {
+	ID                 string         `json:"id" gorm:"primaryKey;type:uuid"`
+	ProblemID          string         `json:"problem_id" gorm:"type:uuid;not null;uniqueIndex:idx_problem_revision"` // references Problem(UniqueID)
+	Number             int            `json:"number" gorm:"not null;uniqueIndex:idx_problem_revision"`               // 1, 2, 3 ... per problem
+	Title              string         `json:"title" gorm:"not null"`
+	Statement          string         `json:"statement" gorm:"type:text;not null"`
+	Difficulty         string         `json:"difficulty" gorm:"not null"`
+	Rating             int            `json:"rating"`
+	Tags               pq.StringArray `json:"tags" gorm:"type:text[]" swaggertype:"array,string"`
+	TimeLimitInSeconds int            `json:"time_limit_in_seconds" gorm:"not null"`
+	MemoryLimitInMB    int            `json:"memory_limit_in_mb" gorm:"not null"`
+	TestSetHash        string         `json:"test_set_hash" gorm:"not null"` // sha256 of the ordered test cases
+	TestCount          int            `json:"test_count"`
+	Note               string         `json:"note"`
+	CreatedBy          string         `json:"created_by" gorm:"type:uuid;not null"` // references User(Id), author of the change
+	CreatedAt          time.Time      `json:"created_at" gorm:"autoCreateTime"`
+
+	StatementSections `gorm:"embedded"`
+
+	Tests []ProblemRevisionTest `json:"-" gorm:"foreignKey:RevisionID"` // stored with the revision, loaded by GetRevisionTests
+}
+
+// ProblemRevisionTest is a test case as it was in a revision. Test data is
+// never deleted from the blob storage, so the tests a submission was pinned to
+// can be judged after the test cases changed.
+type ProblemRevisionTest struct {
+	RevisionID    string `json:"revision_id" gorm:"primaryKey;type:uuid"`  // references ProblemRevision(ID)
+	TestCaseID    string `json:"test_case_id" gorm:"primaryKey;type:uuid"` // references TestCase(UniqueID), which may be gone since
+	OrderPosition int    `json:"order_position" gorm:"not null"`
+	IsHidden      bool   `json:"is_hidden" gorm:"not null"`
+	InputHash     string `json:"input_hash" gorm:"type:char(64);not null"`
+	OutputHash    string `json:"output_hash" gorm:"type:char(64);not null"`
+	InputSize     int64  `json:"input_size"`
+	OutputSize    int64  `json:"output_size"`
+}
+
+// NewProblemRevisionTests returns the tests of a revision from the test
+// cases of its problem
+func NewProblemRevisionTests(revisionID string, tests []TestCase) []ProblemRevisionTest {
+	revisionTests := make([]ProblemRevisionTest, 0, len(tests))
+	for _, test := range tests {
+		revisionTests = append(revisionTests, ProblemRevisionTest{
+			RevisionID:    revisionID,
+			TestCaseID:    test.UniqueID,
+			OrderPosition: test.OrderPosition,
+			IsHidden:      test.IsHidden,
+			InputHash:     test.InputHash,
+			OutputHash:    test.OutputHash,
+			InputSize:     test.InputSize,
+			OutputSize:    test.OutputSize,
+		})
+	}
+	return revisionTests
+}
+
+// TestCase returns the test case of a revision test, without its data
+func (t ProblemRevisionTest) TestCase(problemID string) TestCase {
+	return TestCase{
+		UniqueID:      t.TestCaseID,
+		ProblemID:     problemID,
+		IsHidden:      t.IsHidden,
+		OrderPosition: t.OrderPosition,
+		InputHash:     t.InputHash,
+		OutputHash:    t.OutputHash,
+		InputSize:     t.InputSize,
+		OutputSize:    t.OutputSize,
+	}
+}
+
+// OrderTestCases returns the test cases in judging order
+func OrderTestCases(tests []TestCase) []TestCase {
+	sorted := slices.Clone(tests)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].OrderPosition != sorted[j].OrderPosition {
+			return sorted[i].OrderPosition < sorted[j].OrderPosition
+		}
+		return sorted[i].UniqueID < sorted[j].UniqueID
+	})
+	return sorted
+}
+
+// HashTestSet returns the sha256 of the test cases in judging order, the
+// TestSetHash of a revision. Test data is hashed through the content hashes
+// it is stored under, so test sets can be compared without loading it.
+// Every field is length prefixed.
+func HashTestSet(tests []TestCase) string {
+	h := sha256.New()
+	writeField := func(value string) {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(value)))
+		h.Write(length[:])
+		h.Write([]byte(value))
+	}
+	for _, test := range OrderTestCases(tests) {
+		writeField(strconv.Itoa(test.OrderPosition))
+		writeField(strconv.FormatBool(test.IsHidden))
+		writeField(test.InputHash)
+		writeField(test.OutputHash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type RollbackProblemRequest struct {
+	Revision int `json:"revision" binding:"required,min=1"`
+}
+
+// FieldChange is a problem field that differs between two revisions
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// DiffLine is a line of a line-based diff, Op is "=" for unchanged lines,
+// "-" for removed lines and "+" for added lines
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type ProblemRevisionDiff struct {
+	ProblemID      string                `json:"problem_id"`
+	From           int                   `json:"from"`
+	To             int                   `json:"to"`
+	Changes        []FieldChange         `json:"changes"`        // every changed field except the statement and its sections
+	StatementDiff  []DiffLine            `json:"statement_diff"` // empty when the statement did not change
+	SectionDiffs   map[string][]DiffLine `json:"section_diffs"`  // line diff of every changed statement section, by section name
+	TestSetChanged bool                  `json:"test_set_changed"`
+}
+
+type ProblemRevisionRepository interface {
+	// CreateNextRevision numbers a revision after the latest one of its problem and stores it, with the
+	// problem locked so concurrent calls get consecutive numbers. When unchanged reports the latest revision
+	// has the same content, nothing is stored and the latest revision is returned.
+	CreateNextRevision(ctx context.Context, revision *ProblemRevision, unchanged func(latest *ProblemRevision) bool) (*ProblemRevision, error)
+	GetRevision(ctx context.Context, problemID string, number int) (*ProblemRevision, error)
+	GetRevisionByID(ctx context.Context, id string) (*ProblemRevision, error)
+	GetLatestRevision(ctx context.Context, problemID string) (*ProblemRevision, error)
+	GetRevisions(ctx context.Context, problemID string) ([]ProblemRevision, error)
+	// GetRevisionTests returns the tests stored with a revision, none for revisions recorded before tests were
+	GetRevisionTests(ctx context.Context, revisionID string) ([]ProblemRevisionTest, error)
+}
+
+type ProblemRevisionUseCase interface {
+	GetRevisions(ctx context.Context, problemID string, userID string, role string) ([]ProblemRevision, error)
+	DiffRevisions(ctx context.Context, problemID string, from int, to int, userID string, role string) (*ProblemRevisionDiff, error)
+	// RollbackProblem restores the statement and limits of a revision as a new revision. Test cases are not restored.
+	RollbackProblem(ctx context.Context, problemID string, req *RollbackProblemRequest, userID string, role string) (*ProblemRevision, error)
+}
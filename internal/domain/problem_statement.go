@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxAttachmentSize is the largest file that can be attached to a problem
+const MaxAttachmentSize = 10 << 20
+
+// ProblemAttachment is an image or file referenced from a problem statement,
+// its content lives in the blob storage under StorageKey
+type ProblemAttachment struct {
+	ID          string    `json:"id" gorm:"primaryKey;type:uuid"`
+	ProblemID   string    `json:"problem_id" gorm:"type:uuid;not null;uniqueIndex:idx_problem_attachment"` // references Problem(UniqueID)
+	FileName    string    `json:"file_name" gorm:"not null;uniqueIndex:idx_problem_attachment"`
+	ContentType string    `json:"content_type" gorm:"not null"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-" gorm:"not null"`
+	CreatedBy   string    `json:"created_by" gorm:"type:uuid;not null"` // references User(Id)
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// StatementSection is a titled part of a rendered statement
+type StatementSection struct {
+	Name     string `json:"name"` // legend, input_format, output_format, constraints or notes
+	Title    string `json:"title"`
+	Markdown string `json:"markdown"`
+}
+
+// StatementSample is a visible test case shown with the statement
+type StatementSample struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
+// ProblemStatement is a problem statement ready to display. Attachment
+// references in the Markdown are replaced by their download URLs.
+type ProblemStatement struct {
+	ProblemID          string              `json:"problem_id"`
+	Title              string              `json:"title"`
+	Locale             string              `json:"locale"` // language of the title and sections
+	AvailableLocales   []string            `json:"available_locales"`
+	TimeLimitInSeconds int                 `json:"time_limit_in_seconds"`
+	MemoryLimitInMB    int                 `json:"memory_limit_in_mb"`
+	Sections           []StatementSection  `json:"sections"` // empty sections are left out
+	Samples            []StatementSample   `json:"samples"`
+	Attachments        []ProblemAttachment `json:"attachments"`
+}
+
+type ProblemAttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *ProblemAttachment) error
+	GetAttachment(ctx context.Context, problemID string, fileName string) (*ProblemAttachment, error)
+	GetAttachments(ctx context.Context, problemID string) ([]ProblemAttachment, error)
+	DeleteAttachment(ctx context.Context, id string) error
+}
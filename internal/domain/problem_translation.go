@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Statement languages. A problem is written in one of them, see
+// Problem.Locale, and can be translated to the others.
+const (
+	LocaleEnglish = "en"
+	LocaleRussian = "ru"
+	LocaleHindi   = "hi"
+
+	DefaultLocale = LocaleEnglish
+)
+
+// SupportedLocales lists the statement languages, in the order they are offered
+var SupportedLocales = []string{LocaleEnglish, LocaleRussian, LocaleHindi}
+
+// ProblemTranslation is the title and statement of a problem in another language
+type ProblemTranslation struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	ProblemID string    `json:"problem_id" gorm:"type:uuid;not null;uniqueIndex:idx_problem_translation"` // references Problem(UniqueID)
+	Locale    string    `json:"locale" gorm:"type:varchar(8);not null;uniqueIndex:idx_problem_translation"`
+	Title     string    `json:"title" gorm:"not null"`
+	Statement string    `json:"statement" gorm:"type:text;not null"`
+	UpdatedBy string    `json:"updated_by" gorm:"type:uuid;not null"` // references User(Id)
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	StatementSections `gorm:"embedded"`
+}
+
+type ProblemTranslationRequest struct {
+	Title     string `json:"title" binding:"required"`
+	Statement string `json:"statement" binding:"required"`
+
+	StatementSections
+}
+
+type ProblemTranslationRepository interface {
+	// SaveTranslation creates the translation or replaces the one of the same problem and locale
+	SaveTranslation(ctx context.Context, translation *ProblemTranslation) error
+	GetTranslations(ctx context.Context, problemID string) ([]ProblemTranslation, error)
+	// GetTranslationsForProblems returns the translations of several problems to the given locales
+	GetTranslationsForProblems(ctx context.Context, problemIDs []string, locales []string) ([]ProblemTranslation, error)
+	DeleteTranslation(ctx context.Context, problemID string, locale string) (int64, error)
+}
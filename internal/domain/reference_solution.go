@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Reference solution kinds, they tell the verdict a solution is expected to get
+const (
+	ReferenceSolutionMain      = "main"       // the author's solution, must be accepted, the time limit is suggested from it
+	ReferenceSolutionCorrect   = "correct"    // alternative solution, must be accepted
+	ReferenceSolutionWrong     = "wrong"      // intentionally wrong solution, must fail
+	ReferenceSolutionTimeLimit = "time_limit" // too slow solution, must exceed the time limit
+)
+
+// Reference solution run states
+const (
+	ReferenceRunNotRun  = "not_run" // never run, the problem had no test cases
+	ReferenceRunPending = "pending" // queued
+	ReferenceRunRunning = "running"
+	ReferenceRunDone    = "done"
+)
+
+// ReferenceSolution is a solution written by the problem setters. Reference
+// solutions are judged against every test case to verify the tests and the
+// limits of the problem.
+type ReferenceSolution struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	ProblemID string    `json:"problem_id" gorm:"type:uuid;not null;index"` // references Problem(UniqueID)
+	Name      string    `json:"name" gorm:"not null"`
+	Kind      string    `json:"kind" gorm:"type:varchar(20);not null"` // see ReferenceSolution* kinds
+	Language  string    `json:"language" gorm:"type:varchar(20);not null"`
+	Code      string    `json:"code" gorm:"type:text;not null"`
+	CreatedBy string    `json:"created_by" gorm:"type:uuid;not null"` // references User(Id)
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Result of the last run. RunID identifies the run queued last, results
+	// of older runs still in the queue are dropped.
+	RunID             string         `json:"-" gorm:"type:uuid"`
+	Status            string         `json:"status" gorm:"type:varchar(20);default:not_run"` // see ReferenceRun* states
+	ProblemRevisionID *string        `json:"problem_revision_id,omitempty" gorm:"type:uuid"` // problem revision the solution was judged against
+	Verdict           string         `json:"verdict" gorm:"type:varchar(50)"`
+	TestCasesPassed   int            `json:"test_cases_passed"`
+	TotalTestCases    int            `json:"total_test_cases"`
+	MaxTimeInMS       float64        `json:"max_time_in_ms"`
+	MaxMemoryInKB     int            `json:"max_memory_in_kb"`
+	TestCaseResults   pq.StringArray `json:"test_case_results" gorm:"type:text[]" swaggertype:"array,string"`
+	ExpectationMet    *bool          `json:"expectation_met"` // whether the verdict is the one expected from the kind, null until judged
+	JudgedAt          *time.Time     `json:"judged_at"`
+}
+
+// ExpectsVerdict reports whether the verdict is the one expected from the
+// kind of the solution. Wrong solutions may fail in any way but compiling.
+func (s *ReferenceSolution) ExpectsVerdict(verdict VerdictStatus) bool {
+	switch s.Kind {
+	case ReferenceSolutionMain, ReferenceSolutionCorrect:
+		return verdict == VerdictAccepted
+	case ReferenceSolutionWrong:
+		return verdict != VerdictAccepted && verdict != VerdictCompilationError && verdict != VerdictSystemError
+	case ReferenceSolutionTimeLimit:
+		return verdict == VerdictTimeLimitExceeded
+	default:
+		return false
+	}
+}
+
+type CreateReferenceSolutionRequest struct {
+	Name     string `json:"name" binding:"required,max=64"`
+	Kind     string `json:"kind" binding:"required,oneof=main correct wrong time_limit"`
+	Language string `json:"language" binding:"required,oneof=python cpp java"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// ReferenceSolutionReport tells whether the reference solutions of a problem
+// got their expected verdicts and suggests a time limit from the main solution
+type ReferenceSolutionReport struct {
+	ProblemID                   string              `json:"problem_id"`
+	Solutions                   []ReferenceSolution `json:"solutions"`
+	Verified                    bool                `json:"verified"` // a main solution exists and every solution got its expected verdict on the latest revision
+	Pending                     int                 `json:"pending"`  // solutions queued or running
+	TimeLimitInSeconds          int                 `json:"time_limit_in_seconds"`
+	MainMaxTimeInMS             float64             `json:"main_max_time_in_ms"`
+	TimeLimitFactor             float64             `json:"time_limit_factor"`
+	SuggestedTimeLimitInSeconds int                 `json:"suggested_time_limit_in_seconds"` // 0 until the main solution is accepted
+	Warnings                    []string            `json:"warnings"`
+}
+
+type ReferenceSolutionRepository interface {
+	CreateSolution(ctx context.Context, solution *ReferenceSolution) error
+	GetSolution(ctx context.Context, id string) (*ReferenceSolution, error)
+	GetSolutions(ctx context.Context, problemID string) ([]ReferenceSolution, error)
+	DeleteSolution(ctx context.Context, id string) error
+	// StartRun clears the result of a solution and marks it pending under a new run ID
+	StartRun(ctx context.Context, id string, runID string) error
+	// UpdateRunStatus changes the status of a run, it returns false when the run is no longer the latest
+	UpdateRunStatus(ctx context.Context, id string, runID string, status string) (bool, error)
+	// SaveRunResult stores the result of a run, it returns false when the run is no longer the latest
+	SaveRunResult(ctx context.Context, solution *ReferenceSolution) (bool, error)
+}
+
+type ReferenceSolutionUseCase interface {
+	// AddSolution stores a reference solution and queues its run
+	AddSolution(ctx context.Context, problemID string, req *CreateReferenceSolutionRequest, userID string, role string) (*ReferenceSolution, error)
+	GetSolutions(ctx context.Context, problemID string, userID string, role string) ([]ReferenceSolution, error)
+	DeleteSolution(ctx context.Context, problemID string, solutionID string, userID string, role string) error
+	// RunSolutions queues a new run of every reference solution of the problem
+	RunSolutions(ctx context.Context, problemID string, userID string, role string) ([]ReferenceSolution, error)
+	GetReport(ctx context.Context, problemID string, userID string, role string) (*ReferenceSolutionReport, error)
+}
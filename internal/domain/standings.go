@@ -0,0 +1,72 @@
+package domain
+
+import "context"
+
+// ProblemStanding is the result of one participant on one contest problem
+type ProblemStanding struct {
+	ProblemID      string `json:"problem_id"`
+	Index          string `json:"index"`
+	Solved         bool   `json:"solved"`
+	Attempts       int    `json:"attempts"`                   // rejected attempts before the first accepted one
+	SolvedAtMinute int    `json:"solved_at_minute,omitempty"` // minutes since the participant started
+}
+
+// StandingsRow is one ranked participant in the contest standings
+type StandingsRow struct {
+	Rank      int               `json:"rank"`
+	UserID    string            `json:"user_id,omitempty"`
+	Username  string            `json:"username,omitempty"`
+	TeamID    string            `json:"team_id,omitempty"` // set instead of the user in team contests
+	TeamName  string            `json:"team_name,omitempty"`
+	IsVirtual bool              `json:"is_virtual"`
+	Solved    int               `json:"solved"`
+	Penalty   int               `json:"penalty"` // in minutes
+	Problems  []ProblemStanding `json:"problems"`
+}
+
+type StandingsResponse struct {
+	ContestID      string                   `json:"contest_id"`
+	ElapsedMinutes int                      `json:"elapsed_minutes"`
+	Problems       []ContestProblemResponse `json:"problems"`
+	Rows           []StandingsRow           `json:"rows"`
+}
+
+// Participant export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+// ContestExportHeader describes the contest at the top of an export
+type ContestExportHeader struct {
+	ContestID   string                   `json:"contest_id"`
+	ContestName string                   `json:"contest_name"`
+	Problems    []ContestProblemResponse `json:"problems"`
+}
+
+// ParticipantExportRow is one participant in a contest export. Team contests
+// get one row per team member so every member can receive a certificate.
+type ParticipantExportRow struct {
+	Rank     int               `json:"rank"`
+	UserID   string            `json:"user_id"`
+	Username string            `json:"username"`
+	Email    string            `json:"email"`
+	TeamID   string            `json:"team_id,omitempty"`
+	TeamName string            `json:"team_name,omitempty"`
+	Solved   int               `json:"solved"`
+	Penalty  int               `json:"penalty"` // in minutes
+	Problems []ProblemStanding `json:"problems"`
+}
+
+// ParticipantExportWriter receives an export as it is produced, the header
+// first and then the rows in rank order
+type ParticipantExportWriter interface {
+	WriteHeader(header *ContestExportHeader) error
+	WriteRow(row *ParticipantExportRow) error
+}
+
+type StandingsUseCase interface {
+	GetContestStandings(ctx context.Context, contestID string, userID string, role string) (*StandingsResponse, error)
+	GetVirtualStandings(ctx context.Context, contestID string, userID string, role string) (*StandingsResponse, error)
+	ExportContestParticipants(ctx context.Context, contestID string, writer ParticipantExportWriter) error
+}
@@ -25,16 +25,19 @@ const (
 
 type Submission struct {
 	// Problem related stuff
-	UniqueID    string     `json:"unique_id" gorm:"primaryKey;type:uuid"`
-	UserId      string     `json:"user_id" gorm:"type:uuid;not null"`    // references User(Id)
-	ContestID   string     `json:"contest_id" gorm:"type:uuid;not null"` // references Contest(Id)
-	ProblemID   string     `json:"problem_id" gorm:"type:uuid;not null"` // references Problem(Id)
-	Code        string     `json:"code" gorm:"type:text;not null"`
-	Language    string     `json:"language" gorm:"type:varchar(20);not null"`
-	SubmittedAt time.Time  `json:"submitted_at"`
-	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
-	QueuedAt    *time.Time `json:"queued_at"`
+	UniqueID          string     `json:"unique_id" gorm:"primaryKey;type:uuid"`
+	UserId            string     `json:"user_id" gorm:"type:uuid;not null"`    // references User(Id)
+	ContestID         string     `json:"contest_id" gorm:"type:uuid;not null"` // references Contest(Id)
+	ProblemID         string     `json:"problem_id" gorm:"type:uuid;not null"` // references Problem(Id)
+	Code              string     `json:"code" gorm:"type:text;not null"`
+	Language          string     `json:"language" gorm:"type:varchar(20);not null"`
+	SubmittedAt       time.Time  `json:"submitted_at"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	QueuedAt          *time.Time `json:"queued_at"`
+	IsVirtual         bool       `json:"is_virtual" gorm:"default:false"`                      // made during a virtual participation
+	TeamID            *string    `json:"team_id,omitempty" gorm:"type:uuid;index"`             // team the submission counts for in team contests
+	ProblemRevisionID *string    `json:"problem_revision_id,omitempty" gorm:"type:uuid;index"` // problem revision the submission is judged against
 
 	//Verdict status update
 	Verdict           string         `json:"verdict" gorm:"type:varchar(50);not null;default:'Pending';index"`
@@ -51,13 +54,11 @@ type Submission struct {
 }
 
 type CreateSubmissionRequest struct {
-	UserID            string `json:"user_id" binding:"required,uuid"`
-	ContestID         string `json:"contest_id" binding:"required,uuid"`
-	ProblemID         string `json:"problem_id" binding:"required,uuid"`
-	Code              string `json:"code" binding:"required"`
-	Language          string `json:"language" binding:"required,oneof=python cpp java"`
-	TimeLimitInSecond int    `json:"time_limit" binding:"required,gt=0"`
-	MemoryLimitInMB   int    `json:"memory_limit" binding:"required,gt=0"`
+	UserID    string `json:"user_id" binding:"required,uuid"`
+	ContestID string `json:"contest_id" binding:"required,uuid"`
+	ProblemID string `json:"problem_id" binding:"required,uuid"`
+	Code      string `json:"code" binding:"required"`
+	Language  string `json:"language" binding:"required,oneof=python cpp java"`
 }
 
 type CreateSubmissionResponse struct {
@@ -111,6 +112,12 @@ type SubmissionRepository interface {
 	GetSubmissionDetails(ctx context.Context, uniqueID string) (*Submission, error)
 	UpdateSubmissionStatus(ctx context.Context, submissionID string, status string) error
 	UpdateSubmissionResult(ctx context.Context, submissionID string, result *Submission) error
+	GetContestSubmissions(ctx context.Context, contestID string) ([]Submission, error)
+	StreamContestSubmissions(ctx context.Context, contestID string, fn func(submission *Submission) error) error
+	// GetAcceptedProblemSubmissions returns the accepted official submissions made during the contest, oldest first
+	GetAcceptedProblemSubmissions(ctx context.Context, contestID string, problemID string) ([]Submission, error)
+	// CountOfficialSubmissions counts the submissions the user made during the contest, practice afterwards is not counted
+	CountOfficialSubmissions(ctx context.Context, userID string, contestID string) (int64, error)
 }
 
 type SubmissionUseCase interface {
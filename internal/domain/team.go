@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxTeamMembers is the maximum number of members a team can have, captain included
+const MaxTeamMembers = 3
+
+type Team struct {
+	ID         string    `json:"id" gorm:"primaryKey;type:uuid"`
+	Name       string    `json:"name" gorm:"uniqueIndex;not null"`
+	CaptainID  string    `json:"captain_id" gorm:"type:uuid;not null"` // references User(Id)
+	InviteCode string    `json:"invite_code" gorm:"uniqueIndex;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type TeamMember struct {
+	ID       string    `json:"id" gorm:"primaryKey;type:uuid"`
+	TeamID   string    `json:"team_id" gorm:"type:uuid;not null;uniqueIndex:idx_team_member"` // references Team(ID)
+	UserID   string    `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_team_member"` // references User(Id)
+	JoinedAt time.Time `json:"joined_at" gorm:"autoCreateTime"`
+}
+
+type CreateTeamRequest struct {
+	Name string `json:"name" binding:"required,min=3,max=50"`
+}
+
+type JoinTeamRequest struct {
+	InviteCode string `json:"invite_code" binding:"required"`
+}
+
+type TeamMemberResponse struct {
+	UserID   string    `json:"user_id"`
+	Username string    `json:"username"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type TeamResponse struct {
+	ID         string               `json:"id"`
+	Name       string               `json:"name"`
+	CaptainID  string               `json:"captain_id"`
+	InviteCode string               `json:"invite_code,omitempty"` // only shown to team members
+	Members    []TeamMemberResponse `json:"members"`
+	CreatedAt  time.Time            `json:"created_at"`
+}
+
+type TeamRepository interface {
+	CreateTeam(ctx context.Context, team *Team, captain *TeamMember) error
+	GetTeamByID(ctx context.Context, id string) (*Team, error)
+	GetTeamsByIDs(ctx context.Context, ids []string) ([]Team, error)
+	GetTeamByInviteCode(ctx context.Context, inviteCode string) (*Team, error)
+	GetTeamsByUserID(ctx context.Context, userID string) ([]Team, error)
+	UpdateTeam(ctx context.Context, team *Team) error
+	DeleteTeam(ctx context.Context, id string) error
+	AddTeamMember(ctx context.Context, member *TeamMember) error
+	RemoveTeamMember(ctx context.Context, teamID string, userID string) error
+	GetTeamMembers(ctx context.Context, teamID string) ([]TeamMember, error)
+}
+
+type TeamUseCase interface {
+	CreateTeam(ctx context.Context, userID string, req *CreateTeamRequest) (*TeamResponse, error)
+	JoinTeam(ctx context.Context, userID string, req *JoinTeamRequest) (*TeamResponse, error)
+	LeaveTeam(ctx context.Context, userID string, teamID string) error
+	RemoveTeamMember(ctx context.Context, userID string, teamID string, memberID string) error
+	RegenerateInviteCode(ctx context.Context, userID string, teamID string) (*TeamResponse, error)
+	GetTeam(ctx context.Context, userID string, teamID string) (*TeamResponse, error)
+	GetMyTeams(ctx context.Context, userID string) ([]TeamResponse, error)
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxGeneratorScriptLines is the number of tests a generator script may produce
+const MaxGeneratorScriptLines = 200
+
+// ProblemGenerator is a program printing a test input to stdout from its
+// command line arguments. It must be deterministic: the same arguments, a
+// seed among them, always give the same test.
+type ProblemGenerator struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	ProblemID string    `json:"problem_id" gorm:"type:uuid;not null;uniqueIndex:idx_problem_generator"` // references Problem(UniqueID)
+	Name      string    `json:"name" gorm:"not null;uniqueIndex:idx_problem_generator"`                 // invoked by this name in generator scripts
+	Language  string    `json:"language" gorm:"type:varchar(20);not null"`
+	Source    string    `json:"source" gorm:"type:text;not null"`
+	CreatedBy string    `json:"created_by" gorm:"type:uuid;not null"` // references User(Id)
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Test generation states
+const (
+	TestGenerationPending = "pending"
+	TestGenerationRunning = "running"
+	TestGenerationDone    = "done"
+	TestGenerationFailed  = "failed"
+)
+
+// TestGeneration is a run of a generator script. Every line of the script
+// invokes a generator, e.g. "gen_random 100000 1000000000 42", whose output
+// is a test input. The main reference solution produces the expected outputs.
+type TestGeneration struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	ProblemID string    `json:"problem_id" gorm:"type:uuid;not null;index"` // references Problem(UniqueID)
+	Script    string    `json:"script" gorm:"type:text;not null"`
+	Replace   bool      `json:"replace"`                                 // whether the tests of earlier generations are deleted
+	Status    string    `json:"status" gorm:"type:varchar(20);not null"` // see TestGeneration* states
+	Error     string    `json:"error,omitempty" gorm:"type:text"`        // why the generation failed
+	TestCount int       `json:"test_count"`                              // tests stored once done
+	CreatedBy string    `json:"created_by" gorm:"type:uuid;not null"`    // references User(Id)
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type SaveGeneratorRequest struct {
+	Name     string `json:"name" binding:"required,max=64,printascii"` // no spaces nor #
+	Language string `json:"language" binding:"required,oneof=python cpp java"`
+	Source   string `json:"source" binding:"required"`
+}
+
+type GenerateTestsRequest struct {
+	Script  string `json:"script" binding:"required"` // one generator invocation per line, blank lines and lines starting with # are skipped
+	Replace bool   `json:"replace"`                   // delete the tests of earlier generations
+}
+
+// GeneratorInvocation is a line of a generator script
+type GeneratorInvocation struct {
+	Generator string
+	Arguments string
+}
+
+// String returns the invocation as written in scripts
+func (i GeneratorInvocation) String() string {
+	if i.Arguments == "" {
+		return i.Generator
+	}
+	return i.Generator + " " + i.Arguments
+}
+
+// ParseGeneratorScript returns the generator invocations of a script, one per
+// line. Blank lines and lines starting with # are skipped.
+func ParseGeneratorScript(script string) ([]GeneratorInvocation, error) {
+	var invocations []GeneratorInvocation
+	for _, line := range strings.Split(script, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		invocations = append(invocations, GeneratorInvocation{
+			Generator: fields[0],
+			Arguments: strings.Join(fields[1:], " "),
+		})
+	}
+
+	if len(invocations) == 0 {
+		return nil, errors.New("the generator script has no invocations")
+	}
+	if len(invocations) > MaxGeneratorScriptLines {
+		return nil, fmt.Errorf("the generator script has more than %d invocations", MaxGeneratorScriptLines)
+	}
+	return invocations, nil
+}
+
+// GeneratedTest is a test produced by a generator invocation
+type GeneratedTest struct {
+	Invocation     string
+	Input          string
+	ExpectedOutput string
+}
+
+type TestGeneratorRepository interface {
+	// SaveGenerator creates the generator or replaces the one of the same problem and name
+	SaveGenerator(ctx context.Context, generator *ProblemGenerator) error
+	GetGenerators(ctx context.Context, problemID string) ([]ProblemGenerator, error)
+	DeleteGenerator(ctx context.Context, problemID string, name string) (int64, error)
+	CreateGeneration(ctx context.Context, generation *TestGeneration) error
+	GetGeneration(ctx context.Context, id string) (*TestGeneration, error)
+	UpdateGeneration(ctx context.Context, generation *TestGeneration) error
+}
+
+type TestGeneratorUseCase interface {
+	SaveGenerator(ctx context.Context, problemID string, req *SaveGeneratorRequest, userID string, role string) (*ProblemGenerator, error)
+	GetGenerators(ctx context.Context, problemID string, userID string, role string) ([]ProblemGenerator, error)
+	DeleteGenerator(ctx context.Context, problemID string, name string, userID string, role string) error
+	// GenerateTests checks a generator script and queues its run
+	GenerateTests(ctx context.Context, problemID string, req *GenerateTestsRequest, userID string, role string) (*TestGeneration, error)
+	GetGeneration(ctx context.Context, problemID string, generationID string, userID string, role string) (*TestGeneration, error)
+}
@@ -1,16 +1,42 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 type TestCase struct {
-	UniqueID       string `json:"unique_id" gorm:"primaryKey;type:uuid"`
-	ProblemID      string `json:"problem_id" gorm:"type:uuid;not null"` // references Problem(UniqueID)
-	Input          string `json:"input" gorm:"type:text;not null"`
-	ExpectedOutput string `json:"output" gorm:"type:text;not null"`
-	IsHidden       bool   `json:"is_hidden" gorm:"not null"`
-	OrderPosition  int    `json:"order_position" gorm:"not null"`
+	UniqueID      string `json:"unique_id" gorm:"primaryKey;type:uuid"`
+	ProblemID     string `json:"problem_id" gorm:"type:uuid;not null;uniqueIndex:idx_test_case_order"` // references Problem(UniqueID)
+	IsHidden      bool   `json:"is_hidden" gorm:"not null"`
+	OrderPosition int    `json:"order_position" gorm:"not null;uniqueIndex:idx_test_case_order"` // unique per problem, tests are judged by increasing position
+
+	// Test data is kept in the blob storage under the sha256 of its content,
+	// the repository loads it into Input and ExpectedOutput
+	Input          string `json:"input" gorm:"-"`
+	ExpectedOutput string `json:"output" gorm:"-"`
+	InputHash      string `json:"input_hash" gorm:"type:char(64)"`
+	OutputHash     string `json:"output_hash" gorm:"type:char(64)"`
+	InputSize      int64  `json:"input_size"`
+	OutputSize     int64  `json:"output_size"`
+
+	// Result of the problem's validator on the input, see TestValidation*
+	ValidationStatus  string `json:"validation_status" gorm:"type:varchar(20);default:unchecked"`
+	ValidationMessage string `json:"validation_message,omitempty" gorm:"type:text"`
+
+	Generator string `json:"generator,omitempty" gorm:"type:text"` // generator invocation the test was generated by
 }
 
+// TestDataPrefix is the blob storage prefix test data is stored under
+const TestDataPrefix = "tests"
+
+// Test case validation states
+const (
+	TestValidationUnchecked = "unchecked" // the problem has no validator or it changed since
+	TestValidationValid     = "valid"
+	TestValidationInvalid   = "invalid" // only tests stored before the validator, invalid inputs are rejected
+)
+
 type CreateTestCaseRequest struct {
 	ProblemID      string `json:"problem_id" binding:"required,uuid"`
 	Input          string `json:"input" binding:"required"`
@@ -20,12 +46,13 @@ type CreateTestCaseRequest struct {
 }
 
 type CreateTestCaseResponse struct {
-	UniqueID       string `json:"unique_id"`
-	ProblemID      string `json:"problem_id"`
-	Input          string `json:"input"`
-	ExpectedOutput string `json:"output"`
-	IsHidden       bool   `json:"is_hidden"`
-	OrderPosition  int    `json:"order_position"`
+	UniqueID         string `json:"unique_id"`
+	ProblemID        string `json:"problem_id"`
+	Input            string `json:"input"`
+	ExpectedOutput   string `json:"output"`
+	IsHidden         bool   `json:"is_hidden"`
+	OrderPosition    int    `json:"order_position"`
+	ValidationStatus string `json:"validation_status"`
 }
 
 type UpdateTestCaseRequest struct {
@@ -38,20 +65,68 @@ type UpdateTestCaseRequest struct {
 }
 
 type UpdateTestCaseResponse struct {
-	UniqueID       string `json:"unique_id"`
-	ProblemID      string `json:"problem_id"`
-	Input          string `json:"input"`
-	ExpectedOutput string `json:"output"`
-	IsHidden       bool   `json:"is_hidden"`
-	OrderPosition  int    `json:"order_position"`
+	UniqueID         string `json:"unique_id"`
+	ProblemID        string `json:"problem_id"`
+	Input            string `json:"input"`
+	ExpectedOutput   string `json:"output"`
+	IsHidden         bool   `json:"is_hidden"`
+	OrderPosition    int    `json:"order_position"`
+	ValidationStatus string `json:"validation_status"`
 }
 
 type BulkTestCaseUploadRequest struct {
 	TestCases []CreateTestCaseRequest `json:"test_cases" binding:"required,dive"`
+	// Mode is atomic when empty, see BulkMode*
+	Mode string `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
 }
 
+// BulkTestCaseUploadResponse lists the created test cases. In atomic mode
+// nothing is created when an item fails.
 type BulkTestCaseUploadResponse struct {
+	SuccessCount     int                      `json:"success_count"`
+	FailedCount      int                      `json:"failed_count"`
 	CreatedTestCases []CreateTestCaseResponse `json:"created_test_cases"`
+	Errors           []BulkItemError          `json:"errors,omitempty"`
+}
+
+// MaxTestArchiveSize is the largest zipped test archive accepted for upload
+const MaxTestArchiveSize = 64 << 20
+
+type TestArchiveUploadRequest struct {
+	// Replace deletes the existing test cases of the problem, the archive's tests are appended after them otherwise
+	Replace bool `form:"replace"`
+}
+
+// TestArchiveEntry is a test case created from the files of a test archive
+type TestArchiveEntry struct {
+	Name             string `json:"name"` // file name without extension
+	UniqueID         string `json:"unique_id"`
+	IsHidden         bool   `json:"is_hidden"`
+	OrderPosition    int    `json:"order_position"`
+	ValidationStatus string `json:"validation_status"`
+}
+
+type TestArchiveUploadResponse struct {
+	ProblemID   string             `json:"problem_id"`
+	Replaced    bool               `json:"replaced"`
+	SampleCount int                `json:"sample_count"`
+	TestCases   []TestArchiveEntry `json:"test_cases"`
+}
+
+type ReorderTestCasesRequest struct {
+	// Every test case of the problem in the new judging order
+	TestCaseIDs []string `json:"test_case_ids" binding:"required,min=1,dive,uuid"`
+}
+
+type TestCaseOrderEntry struct {
+	UniqueID      string `json:"unique_id"`
+	OrderPosition int    `json:"order_position"`
+	IsHidden      bool   `json:"is_hidden"`
+}
+
+type TestCaseOrder struct {
+	ProblemID string               `json:"problem_id"`
+	TestCases []TestCaseOrderEntry `json:"test_cases"`
 }
 
 type TestCaseRepository interface {
@@ -60,13 +135,84 @@ type TestCaseRepository interface {
 	DeleteTestCase(ctx context.Context, uniqueID string) error
 	GetTestCasesByProblemID(ctx context.Context, problemID string) ([]*TestCase, error)
 	GetTestCaseByUniqueID(ctx context.Context, uniqueID string) (*TestCase, error)
+	UpdateValidationStatus(ctx context.Context, uniqueID string, status string, message string) error
+	// ResetValidationStatus marks every test case of a problem unchecked
+	ResetValidationStatus(ctx context.Context, problemID string) error
+	// DeleteGeneratedTestCases deletes the test cases of a problem made by generators
+	DeleteGeneratedTestCases(ctx context.Context, problemID string) error
+	// CreateTestCases stores test cases in one transaction, none are stored when one fails
+	CreateTestCases(ctx context.Context, testCases []*TestCase) error
+	// ReplaceTestCases deletes every test case of a problem and stores new ones in one transaction
+	ReplaceTestCases(ctx context.Context, problemID string, testCases []*TestCase) error
+	// GetTestCaseHashes returns the test cases of a problem in judging order with the hashes of their data,
+	// Input and ExpectedOutput are not loaded
+	GetTestCaseHashes(ctx context.Context, problemID string) ([]TestCase, error)
+	// GetTestCasePositions returns the order position of every test case of a problem by test case ID,
+	// without loading their data
+	GetTestCasePositions(ctx context.Context, problemID string) (map[string]int, error)
+	// ReorderTestCases numbers the given test cases of a problem 1, 2, 3 ... in one transaction
+	ReorderTestCases(ctx context.Context, problemID string, testCaseIDs []string) error
+}
+
+// InputValidator runs the validator program of a problem on a test input.
+// It returns whether the input is valid with the validator's message, an
+// error when the validator could not be run.
+type InputValidator interface {
+	ValidateInput(ctx context.Context, language string, source string, input string) (bool, string, error)
+}
+
+// TestSetNotifier tells the judge workers the test set of a problem changed
+type TestSetNotifier interface {
+	NotifyTestSetChanged(ctx context.Context, problemID string) error
+}
+
+type SetValidatorRequest struct {
+	Language string `json:"language" binding:"required,oneof=python cpp java"`
+	Source   string `json:"source" binding:"required"`
+}
+
+type ProblemValidator struct {
+	ProblemID string `json:"problem_id"`
+	Language  string `json:"language"`
+	Source    string `json:"source"`
+}
+
+type TestValidationResult struct {
+	TestCaseID    string `json:"test_case_id"`
+	OrderPosition int    `json:"order_position"`
+	Status        string `json:"status"`
+	Message       string `json:"message,omitempty"`
+}
+
+type TestValidationReport struct {
+	ProblemID string                 `json:"problem_id"`
+	Valid     int                    `json:"valid"`
+	Invalid   int                    `json:"invalid"`
+	Results   []TestValidationResult `json:"results"`
 }
 
 type TestCaseUseCase interface {
-	CreateNewTestCase(ctx context.Context, req *CreateTestCaseRequest) (*CreateTestCaseResponse, error)
-	GetAllTestCasesForProblem(ctx context.Context, problemID string) ([]*TestCase, error)
-	GetTestCaseDetails(ctx context.Context, uniqueID string) (*TestCase, error)
-	UpdateSingleTestCase(ctx context.Context, req *UpdateTestCaseRequest) (*UpdateTestCaseResponse, error)
-	DeleteSingleTestCase(ctx context.Context, uniqueID string) error
-	UploadTestCasesInBulk(ctx context.Context, req *BulkTestCaseUploadRequest) (*BulkTestCaseUploadResponse, error)
+	CreateNewTestCase(ctx context.Context, req *CreateTestCaseRequest, userID string, role string) (*CreateTestCaseResponse, error)
+	// GetAllTestCasesForProblem returns every test case to the problem's authors and admins, the samples to anyone else
+	GetAllTestCasesForProblem(ctx context.Context, problemID string, userID string, role string) ([]*TestCase, error)
+	GetTestCaseDetails(ctx context.Context, uniqueID string, userID string, role string) (*TestCase, error)
+	UpdateSingleTestCase(ctx context.Context, req *UpdateTestCaseRequest, userID string, role string) (*UpdateTestCaseResponse, error)
+	DeleteSingleTestCase(ctx context.Context, uniqueID string, userID string, role string) error
+	UploadTestCasesInBulk(ctx context.Context, req *BulkTestCaseUploadRequest, userID string, role string) (*BulkTestCaseUploadResponse, error)
+	// SetValidator attaches a validator to a problem, its test cases become unchecked until ValidateTestCases runs
+	SetValidator(ctx context.Context, problemID string, req *SetValidatorRequest, userID string, role string) (*ProblemValidator, error)
+	GetValidator(ctx context.Context, problemID string, userID string, role string) (*ProblemValidator, error)
+	DeleteValidator(ctx context.Context, problemID string, userID string, role string) error
+	// ValidateTestCases runs the validator on every test case of a problem and stores their status
+	ValidateTestCases(ctx context.Context, problemID string, userID string, role string) (*TestValidationReport, error)
+	// StoreGeneratedTests validates generated tests and appends them to the problem as hidden tests, replacing
+	// the tests of earlier generations if asked
+	StoreGeneratedTests(ctx context.Context, problemID string, tests []GeneratedTest, replace bool, userID string) error
+	// UploadTestArchive stores the tests of a zipped test archive, all of them or none
+	UploadTestArchive(ctx context.Context, problemID string, r io.ReaderAt, size int64, req *TestArchiveUploadRequest, userID string, role string) (*TestArchiveUploadResponse, error)
+	// DownloadTestArchive streams the test cases of a problem to w as a zip in
+	// the layout UploadTestArchive reads, nothing is written if access fails
+	DownloadTestArchive(ctx context.Context, problemID string, w io.Writer, userID string, role string) error
+	// ReorderTestCases sets the judging order of every test case of a problem
+	ReorderTestCases(ctx context.Context, problemID string, req *ReorderTestCasesRequest, userID string, role string) (*TestCaseOrder, error)
 }
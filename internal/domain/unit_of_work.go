@@ -0,0 +1,33 @@
+package domain
+
+import "context"
+
+// UnitOfWork runs changes spanning several repositories in one database
+// transaction
+type UnitOfWork interface {
+	// Do runs fn in a transaction, committed when fn returns nil and rolled
+	// back otherwise. Only the repositories of tx take part in it.
+	Do(ctx context.Context, fn func(tx Transaction) error) error
+}
+
+// Transaction gives the repositories bound to a transaction
+type Transaction interface {
+	Problems() ProblemRepository
+	TestCases() TestCaseRepository
+	Revisions() ProblemRevisionRepository
+	Contests() ContestRepository
+	Registrations() ContestRegisterRepository
+	PlagiarismCases() PlagiarismRepository
+}
+
+// Bulk operation modes
+const (
+	BulkModeAtomic     = "atomic"      // every item is stored or none, the default
+	BulkModeBestEffort = "best_effort" // valid items are stored and failed ones reported
+)
+
+// BulkItemError is the failure of one item of a bulk operation
+type BulkItemError struct {
+	Index int    `json:"index"` // position of the item in the request, from 0
+	Error string `json:"error"`
+}
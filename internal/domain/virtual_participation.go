@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// VirtualParticipation is a replay of an ended contest by a single user.
+// The user gets the original contest duration starting from their own StartTime.
+type VirtualParticipation struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	UserID    string    `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_virtual_user_contest"`    // references User(Id)
+	ContestID string    `json:"contest_id" gorm:"type:uuid;not null;uniqueIndex:idx_virtual_user_contest"` // references Contest(Id)
+	StartTime time.Time `json:"start_time" gorm:"not null"`
+	EndTime   time.Time `json:"end_time" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type StartVirtualParticipationRequest struct {
+	ContestID string `json:"contest_id" binding:"required,uuid"`
+}
+
+type VirtualParticipationResponse struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"user_id"`
+	ContestID      string    `json:"contest_id"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+	ElapsedMinutes int       `json:"elapsed_minutes"`
+	IsRunning      bool      `json:"is_running"`
+}
+
+type VirtualParticipationRepository interface {
+	CreateVirtualParticipation(ctx context.Context, participation *VirtualParticipation) error
+	GetVirtualParticipation(ctx context.Context, userID string, contestID string) (*VirtualParticipation, error)
+}
+
+type VirtualParticipationUseCase interface {
+	StartVirtualParticipation(ctx context.Context, userID string, role string, req *StartVirtualParticipationRequest) (*VirtualParticipationResponse, error)
+	GetVirtualParticipation(ctx context.Context, userID string, contestID string) (*VirtualParticipationResponse, error)
+}
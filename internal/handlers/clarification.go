@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/middleware"
+	"algoforces/internal/utils"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClarificationHandler struct {
+	clarificationUseCase domain.ClarificationUseCase
+}
+
+func NewClarificationHandler(clarificationUseCase domain.ClarificationUseCase) *ClarificationHandler {
+	return &ClarificationHandler{
+		clarificationUseCase: clarificationUseCase,
+	}
+}
+
+// AskClarification godoc
+//
+//	@Summary		Ask a clarification
+//	@Description	Ask a question during a running contest, optionally about one of its problems
+//	@Tags			Clarification
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Contest ID"
+//	@Param			request	body		domain.AskClarificationRequest	true	"Clarification question"
+//	@Success		201		{object}	domain.ClarificationResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/{id}/clarifications [post]
+func (h *ClarificationHandler) AskClarification(c *gin.Context) {
+	contestID := c.Param("id")
+	if contestID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	var askRequest domain.AskClarificationRequest
+	if err := c.ShouldBindJSON(&askRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	clarification, err := h.clarificationUseCase.AskClarification(c.Request.Context(), userID, contestID, &askRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to ask clarification")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, clarification, "Clarification asked successfully")
+}
+
+// GetClarifications godoc
+//
+//	@Summary		Get contest clarifications
+//	@Description	Problem setters see every clarification, participants see public ones and their own questions
+//	@Tags			Clarification
+//	@Produce		json
+//	@Param			id	path		string	true	"Contest ID"
+//	@Success		200	{array}		domain.ClarificationResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/{id}/clarifications [get]
+func (h *ClarificationHandler) GetClarifications(c *gin.Context) {
+	contestID := c.Param("id")
+	if contestID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	clarifications, err := h.clarificationUseCase.GetClarifications(c.Request.Context(), userID, role, contestID)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, err, "Failed to get clarifications")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, clarifications, "Clarifications retrieved successfully")
+}
+
+// AnswerClarification godoc
+//
+//	@Summary		Answer a clarification
+//	@Description	Answer a question privately or broadcast the answer to all participants (contest problem setters only)
+//	@Tags			Clarification
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string								true	"Contest ID"
+//	@Param			clarificationId	path		string								true	"Clarification ID"
+//	@Param			request			body		domain.AnswerClarificationRequest	true	"Clarification answer"
+//	@Success		200				{object}	domain.ClarificationResponse
+//	@Failure		400				{object}	utils.ErrorResponse
+//	@Failure		500				{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/{id}/clarifications/{clarificationId}/answer [post]
+func (h *ClarificationHandler) AnswerClarification(c *gin.Context) {
+	contestID := c.Param("id")
+	clarificationID := c.Param("clarificationId")
+	if contestID == "" || clarificationID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID and Clarification ID are required")
+		return
+	}
+
+	var answerRequest domain.AnswerClarificationRequest
+	if err := c.ShouldBindJSON(&answerRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	clarification, err := h.clarificationUseCase.AnswerClarification(c.Request.Context(), userID, role, contestID, clarificationID, &answerRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to answer clarification")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, clarification, "Clarification answered successfully")
+}
+
+// CreateAnnouncement godoc
+//
+//	@Summary		Make an announcement
+//	@Description	Broadcast an announcement to all participants of the contest (contest problem setters only)
+//	@Tags			Clarification
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Contest ID"
+//	@Param			request	body		domain.CreateAnnouncementRequest	true	"Announcement"
+//	@Success		201		{object}	domain.ClarificationResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/{id}/clarifications/announcements [post]
+func (h *ClarificationHandler) CreateAnnouncement(c *gin.Context) {
+	contestID := c.Param("id")
+	if contestID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	var announcementRequest domain.CreateAnnouncementRequest
+	if err := c.ShouldBindJSON(&announcementRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	announcement, err := h.clarificationUseCase.CreateAnnouncement(c.Request.Context(), userID, role, contestID, &announcementRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to create announcement")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, announcement, "Announcement created successfully")
+}
+
+// StreamClarifications godoc
+//
+//	@Summary		Stream clarification events
+//	@Description	Server-sent events stream of announcements, public answers and answers to the user's own questions. Problem setters also receive new questions.
+//	@Tags			Clarification
+//	@Produce		text/event-stream
+//	@Param			id	path		string	true	"Contest ID"
+//	@Success		200	{object}	domain.ClarificationEvent
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/{id}/clarifications/stream [get]
+func (h *ClarificationHandler) StreamClarifications(c *gin.Context) {
+	contestID := c.Param("id")
+	if contestID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	events, unsubscribe, err := h.clarificationUseCase.Subscribe(c.Request.Context(), userID, role, contestID)
+	if err != nil {
+		utils.SendError(c, http.StatusForbidden, err, "Failed to subscribe to clarifications")
+		return
+	}
+	defer unsubscribe()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -57,7 +57,7 @@ func (h *ContestHandler) CreateContest(c *gin.Context) {
 // GetContestDetails godoc
 //
 //	@Summary		Get Contest Details
-//	@Description	Get details of a specific contest by ID
+//	@Description	Get details of a specific contest by ID, hidden and private contests are only visible to the users allowed in them
 //	@Tags			Contest
 //	@Produce		json
 //	@Param			id	path	string	true	"Contest ID"
@@ -73,7 +73,19 @@ func (h *ContestHandler) GetContestDetails(c *gin.Context) {
 		return
 	}
 
-	contestResponse, err := h.contestUseCase.GetContestDetails(c.Request.Context(), contestId)
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	contestResponse, err := h.contestUseCase.GetContestDetails(c.Request.Context(), contestId, userID, role)
 	if err != nil {
 		utils.SendError(c, http.StatusNotFound, err, "Contest not found")
 		return
@@ -159,3 +171,145 @@ func (h *ContestHandler) GetAllContests(c *gin.Context) {
 
 	utils.SendSuccess(c, http.StatusOK, contests, "Contests retrieved successfully")
 }
+
+// AddProblemToContest godoc
+//
+//	@Summary		Add a Problem to a Contest
+//	@Description	Attach an existing problem to a contest under the given index (admin only). Drafts become contest-only, public problems can only be added to ended contests.
+//	@Tags			Contest
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path	string							true	"Contest ID"
+//	@Param			addContestProblemRequest	body	domain.AddContestProblemRequest	true	"Add Contest Problem Request"
+//	@Security		BearerAuth
+//	@Success		201	{object}	domain.ContestProblemResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/contest/{id}/problems [post]
+func (h *ContestHandler) AddProblemToContest(c *gin.Context) {
+	contestId := c.Param("id")
+	if contestId == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	var addContestProblemRequest domain.AddContestProblemRequest
+	if err := c.ShouldBindJSON(&addContestProblemRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	contestProblemResponse, err := h.contestUseCase.AddProblemToContest(c.Request.Context(), contestId, &addContestProblemRequest)
+	if err != nil {
+		if err.Error() == "a public problem cannot be added to a contest that has not ended" {
+			utils.SendError(c, http.StatusBadRequest, err, err.Error())
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to add problem to contest")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, contestProblemResponse, "Problem added to contest successfully")
+}
+
+// GetContestProblems godoc
+//
+//	@Summary		Get Contest Problems
+//	@Description	Get the problems of a contest ordered by index, participants see them once the contest has started
+//	@Tags			Contest
+//	@Produce		json
+//	@Param			id	path	string	true	"Contest ID"
+//	@Security		BearerAuth
+//	@Success		200	{array}		domain.ContestProblemResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/contest/{id}/problems [get]
+func (h *ContestHandler) GetContestProblems(c *gin.Context) {
+	contestId := c.Param("id")
+	if contestId == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	contestProblems, err := h.contestUseCase.GetContestProblems(c.Request.Context(), contestId, userID, role)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, err, "Contest not found")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, contestProblems, "Contest problems retrieved successfully")
+}
+
+// RemoveProblemFromContest godoc
+//
+//	@Summary		Remove a Problem from a Contest
+//	@Description	Detach a problem from a contest (admin only)
+//	@Tags			Contest
+//	@Produce		json
+//	@Param			id			path	string	true	"Contest ID"
+//	@Param			problemId	path	string	true	"Problem ID"
+//	@Security		BearerAuth
+//	@Success		200	{object}	utils.SuccessResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/contest/{id}/problems/{problemId} [delete]
+func (h *ContestHandler) RemoveProblemFromContest(c *gin.Context) {
+	contestId := c.Param("id")
+	problemId := c.Param("problemId")
+	if contestId == "" || problemId == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID and Problem ID are required")
+		return
+	}
+
+	err := h.contestUseCase.RemoveProblemFromContest(c.Request.Context(), contestId, problemId)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to remove problem from contest")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, nil, "Problem removed from contest successfully")
+}
+
+// GetVisibleContests godoc
+//
+//	@Summary		List Contests
+//	@Description	List the contests visible to the current user, unlisted contests only appear once registered
+//	@Tags			Contest
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		domain.CreateContestResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/contest/list [get]
+func (h *ContestHandler) GetVisibleContests(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	contests, err := h.contestUseCase.GetVisibleContests(c.Request.Context(), userID, role)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get contests")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, contests, "Contests retrieved successfully")
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/utils"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportContestParticipants godoc
+//
+//	@Summary		Export contest participants (Admin)
+//	@Description	Stream the final standings of a contest with the username, email, rank, score and per problem results of every participant, for prizes and certificates. Registered participants who did not submit are included with nothing solved. Team contests get one row per team member.
+//	@Tags			Standings
+//	@Produce		text/csv
+//	@Produce		json
+//	@Param			id		path	string	true	"Contest ID"
+//	@Param			format	query	string	false	"Export format"	Enums(csv, json)	default(csv)
+//	@Security		BearerAuth
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/admin/contests/{id}/export [get]
+func (h *StandingsHandler) ExportContestParticipants(c *gin.Context) {
+	contestId := c.Param("id")
+	if contestId == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	format := c.DefaultQuery("format", domain.ExportFormatCSV)
+	var writer exportWriter
+	switch format {
+	case domain.ExportFormatCSV:
+		writer = &csvExportWriter{c: c}
+	case domain.ExportFormatJSON:
+		writer = &jsonExportWriter{c: c}
+	default:
+		utils.SendError(c, http.StatusBadRequest, errors.New("unsupported export format "+format), "Invalid export format")
+		return
+	}
+
+	err := h.standingsUseCase.ExportContestParticipants(c.Request.Context(), contestId, writer)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		// Once streaming started the status is already sent, the export is cut short
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		if err.Error() == "contest not found" {
+			utils.SendError(c, http.StatusNotFound, err, err.Error())
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to export participants")
+	}
+}
+
+// exportWriter streams an export to the response, Close finishes the document
+type exportWriter interface {
+	domain.ParticipantExportWriter
+	Close() error
+}
+
+// startExport sends the headers of an export download
+func startExport(c *gin.Context, contestID string, contentType string, extension string) {
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="contest-`+contestID+`-participants.`+extension+`"`)
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+}
+
+// csvExportWriter writes one line per participant, with the solved flag,
+// rejected attempts and solve minute of every problem in its own columns
+type csvExportWriter struct {
+	c        *gin.Context
+	w        *csv.Writer
+	problems []domain.ContestProblemResponse
+}
+
+func (w *csvExportWriter) WriteHeader(header *domain.ContestExportHeader) error {
+	startExport(w.c, header.ContestID, "text/csv", domain.ExportFormatCSV)
+	w.w = csv.NewWriter(w.c.Writer)
+	w.problems = header.Problems
+
+	columns := []string{"rank", "user_id", "username", "email", "team_id", "team_name", "solved", "penalty"}
+	for _, problem := range header.Problems {
+		columns = append(columns, problem.Index+"_solved", problem.Index+"_attempts", problem.Index+"_minute")
+	}
+	return w.w.Write(columns)
+}
+
+func (w *csvExportWriter) WriteRow(row *domain.ParticipantExportRow) error {
+	record := []string{
+		strconv.Itoa(row.Rank),
+		row.UserID,
+		row.Username,
+		row.Email,
+		row.TeamID,
+		row.TeamName,
+		strconv.Itoa(row.Solved),
+		strconv.Itoa(row.Penalty),
+	}
+
+	results := make(map[string]domain.ProblemStanding, len(row.Problems))
+	for _, result := range row.Problems {
+		results[result.ProblemID] = result
+	}
+	for _, problem := range w.problems {
+		result := results[problem.ProblemID]
+		minute := ""
+		if result.Solved {
+			minute = strconv.Itoa(result.SolvedAtMinute)
+		}
+		record = append(record, strconv.FormatBool(result.Solved), strconv.Itoa(result.Attempts), minute)
+	}
+
+	err := w.w.Write(record)
+	if err != nil {
+		return err
+	}
+	// Flush every row so large exports are not buffered
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvExportWriter) Close() error {
+	if w.w == nil {
+		return nil
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// jsonExportWriter writes the header fields followed by a participants array
+// filled one row at a time
+type jsonExportWriter struct {
+	c    *gin.Context
+	rows int
+}
+
+func (w *jsonExportWriter) WriteHeader(header *domain.ContestExportHeader) error {
+	startExport(w.c, header.ContestID, "application/json", domain.ExportFormatJSON)
+
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	// Reopen the header object to append the participants to it
+	_, err = w.c.Writer.Write(encoded[:len(encoded)-1])
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w.c.Writer, `,"participants":[`)
+	return err
+}
+
+func (w *jsonExportWriter) WriteRow(row *domain.ParticipantExportRow) error {
+	if w.rows > 0 {
+		_, err := io.WriteString(w.c.Writer, ",")
+		if err != nil {
+			return err
+		}
+	}
+	w.rows++
+
+	encoded, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	_, err = w.c.Writer.Write(encoded)
+	return err
+}
+
+func (w *jsonExportWriter) Close() error {
+	if !w.c.Writer.Written() {
+		return nil
+	}
+	_, err := io.WriteString(w.c.Writer, "]}")
+	return err
+}
@@ -139,3 +139,169 @@ func (h *ContestRegisterHandler) GetAllRegistrationsForAdmin(c *gin.Context) {
 
 	utils.SendSuccess(c, http.StatusOK, allRegistrationsResponse, "Retrieved all registrations successfully")
 }
+
+// ApproveRegistrations godoc
+//
+//	@Summary		Approve contest registrations (Admin)
+//	@Description	Approve pending registrations of a contest, registrations over the contest capacity are waitlisted
+//	@Tags			contest-registration
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.RegistrationDecisionRequest	true	"Registrations to approve"
+//	@Success		200		{object}	domain.RegistrationDecisionResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/admin/registrations/approve [post]
+func (h *ContestRegisterHandler) ApproveRegistrations(c *gin.Context) {
+	var req domain.RegistrationDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.contestRegisterUseCase.ApproveRegistrations(c.Request.Context(), &req)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to approve registrations")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, response, "Registrations processed")
+}
+
+// RejectRegistrations godoc
+//
+//	@Summary		Reject contest registrations (Admin)
+//	@Description	Reject pending or waitlisted registrations of a contest
+//	@Tags			contest-registration
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.RegistrationDecisionRequest	true	"Registrations to reject"
+//	@Success		200		{object}	domain.RegistrationDecisionResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/admin/registrations/reject [post]
+func (h *ContestRegisterHandler) RejectRegistrations(c *gin.Context) {
+	var req domain.RegistrationDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	response, err := h.contestRegisterUseCase.RejectRegistrations(c.Request.Context(), &req)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to reject registrations")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, response, "Registrations processed")
+}
+
+// RegisterTeamContest godoc
+//
+//	@Summary		Register a team for a contest
+//	@Description	Register all members of a team for a team contest (captain only)
+//	@Tags			contest-registration
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.TeamContestRegisterRequest	true	"Team contest registration request"
+//	@Success		201		{object}	domain.TeamContestRegisterResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/team/register [post]
+func (h *ContestRegisterHandler) RegisterTeamContest(c *gin.Context) {
+	var teamRegisterRequest domain.TeamContestRegisterRequest
+	if err := c.ShouldBindJSON(&teamRegisterRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	// get user id from middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	teamRegisterResponse, err := h.contestRegisterUseCase.RegisterTeamContest(c.Request.Context(), userID, &teamRegisterRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to register team for contest")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, teamRegisterResponse, "Team registered for contest successfully")
+}
+
+// UnregisterTeamContest godoc
+//
+//	@Summary		Unregister a team from a contest
+//	@Description	Unregister all members of a team from a team contest (captain only)
+//	@Tags			contest-registration
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.TeamContestUnregisterRequest	true	"Team contest unregister request"
+//	@Success		200		{object}	utils.SuccessResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/team/unregister [post]
+func (h *ContestRegisterHandler) UnregisterTeamContest(c *gin.Context) {
+	var teamUnregisterRequest domain.TeamContestUnregisterRequest
+	if err := c.ShouldBindJSON(&teamUnregisterRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	// get user id from middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	err = h.contestRegisterUseCase.UnregisterTeamContest(c.Request.Context(), userID, &teamUnregisterRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to unregister team from contest")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, nil, "Team unregistered from contest successfully")
+}
+
+// StartContestWindow godoc
+//
+//	@Summary		Start contest window
+//	@Description	Start the personal window of a windowed contest, the user then has the contest duration to solve the problems
+//	@Tags			contest-registration
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.StartContestWindowRequest	true	"Start contest window request"
+//	@Success		200		{object}	domain.ContestRegisterResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/window/start [post]
+func (h *ContestRegisterHandler) StartContestWindow(c *gin.Context) {
+	var startWindowRequest domain.StartContestWindowRequest
+	if err := c.ShouldBindJSON(&startWindowRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	// get user id from middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	registration, err := h.contestRegisterUseCase.StartContestWindow(c.Request.Context(), userID, &startWindowRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to start contest window")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, registration, "Contest window started successfully")
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/middleware"
+	"algoforces/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ContestSeriesHandler struct {
+	contestSeriesUseCase domain.ContestSeriesUseCase
+}
+
+func NewContestSeriesHandler(contestSeriesUseCase domain.ContestSeriesUseCase) *ContestSeriesHandler {
+	return &ContestSeriesHandler{
+		contestSeriesUseCase: contestSeriesUseCase,
+	}
+}
+
+// CreateSeries godoc
+//
+//	@Summary		Create a Contest Series
+//	@Description	Create a series grouping parallel contests such as divisions (admin only)
+//	@Tags			Contest
+//	@Accept			json
+//	@Produce		json
+//	@Param			createContestSeriesRequest	body	domain.CreateContestSeriesRequest	true	"Create Contest Series Request"
+//	@Security		BearerAuth
+//	@Success		201	{object}	domain.ContestSeriesResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/contest/series/create [post]
+func (h *ContestSeriesHandler) CreateSeries(c *gin.Context) {
+	var createSeriesRequest domain.CreateContestSeriesRequest
+	if err := c.ShouldBindJSON(&createSeriesRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+	userId, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	seriesResponse, err := h.contestSeriesUseCase.CreateSeries(c.Request.Context(), &createSeriesRequest, userId)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to create contest series")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, seriesResponse, "Contest series created successfully")
+}
+
+// UpdateSeries godoc
+//
+//	@Summary		Update a Contest Series
+//	@Description	Update a contest series and its registration policy (admin only)
+//	@Tags			Contest
+//	@Accept			json
+//	@Produce		json
+//	@Param			updateContestSeriesRequest	body	domain.UpdateContestSeriesRequest	true	"Update Contest Series Request"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.ContestSeriesResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/contest/series/update [put]
+func (h *ContestSeriesHandler) UpdateSeries(c *gin.Context) {
+	var updateSeriesRequest domain.UpdateContestSeriesRequest
+	if err := c.ShouldBindJSON(&updateSeriesRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	seriesResponse, err := h.contestSeriesUseCase.UpdateSeries(c.Request.Context(), &updateSeriesRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to update contest series")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, seriesResponse, "Contest series updated successfully")
+}
+
+// GetSeries godoc
+//
+//	@Summary		Get Contest Series
+//	@Description	Get a contest series along with its contests
+//	@Tags			Contest
+//	@Produce		json
+//	@Param			seriesId	path	string	true	"Contest Series ID"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.ContestSeriesResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/contest/series/{seriesId} [get]
+func (h *ContestSeriesHandler) GetSeries(c *gin.Context) {
+	seriesId := c.Param("seriesId")
+	if seriesId == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest series ID is required")
+		return
+	}
+
+	seriesResponse, err := h.contestSeriesUseCase.GetSeries(c.Request.Context(), seriesId)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, err, "Contest series not found")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, seriesResponse, "Contest series retrieved successfully")
+}
+
+// GetAllSeries godoc
+//
+//	@Summary		Get All Contest Series
+//	@Description	Get all contest series
+//	@Tags			Contest
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		domain.ContestSeriesResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/contest/series/all [get]
+func (h *ContestSeriesHandler) GetAllSeries(c *gin.Context) {
+	seriesResponses, err := h.contestSeriesUseCase.GetAllSeries(c.Request.Context())
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get contest series")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, seriesResponses, "Contest series retrieved successfully")
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/middleware"
+	"algoforces/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PlagiarismHandler struct {
+	plagiarismUseCase domain.PlagiarismUseCase
+}
+
+func NewPlagiarismHandler(plagiarismUseCase domain.PlagiarismUseCase) *PlagiarismHandler {
+	return &PlagiarismHandler{
+		plagiarismUseCase: plagiarismUseCase,
+	}
+}
+
+// RequestCheck godoc
+//
+//	@Summary		Run a plagiarism check (Admin)
+//	@Description	Queue a similarity check of the accepted submissions of an ended contest. Ended contests are checked automatically, this runs the check again.
+//	@Tags			Plagiarism
+//	@Produce		json
+//	@Param			id	path		string	true	"Contest ID"
+//	@Success		202	{object}	utils.SuccessResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/admin/contests/{id}/plagiarism/check [post]
+func (h *PlagiarismHandler) RequestCheck(c *gin.Context) {
+	contestID := c.Param("id")
+	if contestID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	err := h.plagiarismUseCase.RequestCheck(c.Request.Context(), contestID)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Failed to queue plagiarism check")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusAccepted, nil, "Plagiarism check queued")
+}
+
+// GetContestCases godoc
+//
+//	@Summary		Get plagiarism cases (Admin)
+//	@Description	Get the suspicious submission pairs of a contest, most similar first
+//	@Tags			Plagiarism
+//	@Produce		json
+//	@Param			id		path		string	true	"Contest ID"
+//	@Param			status	query		string	false	"Case status"	Enums(suspected, confirmed, dismissed)
+//	@Success		200		{array}		domain.PlagiarismCase
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		404		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/admin/contests/{id}/plagiarism [get]
+func (h *PlagiarismHandler) GetContestCases(c *gin.Context) {
+	contestID := c.Param("id")
+	if contestID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	cases, err := h.plagiarismUseCase.GetContestCases(c.Request.Context(), contestID, c.Query("status"))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, err, "Failed to get plagiarism cases")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, cases, "Plagiarism cases retrieved successfully")
+}
+
+// ReviewCase godoc
+//
+//	@Summary		Review a plagiarism case (Admin)
+//	@Description	Confirm or dismiss a suspected case. Confirming disqualifies the users of the case (or only the given ones), removing them from the standings.
+//	@Tags			Plagiarism
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.ReviewPlagiarismCaseRequest	true	"Review decision"
+//	@Success		200		{object}	domain.PlagiarismCase
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/admin/plagiarism/review [post]
+func (h *PlagiarismHandler) ReviewCase(c *gin.Context) {
+	var req domain.ReviewPlagiarismCaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	plagiarismCase, err := h.plagiarismUseCase.ReviewCase(c.Request.Context(), &req, userID)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Failed to review plagiarism case")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, plagiarismCase, "Plagiarism case reviewed")
+}
@@ -5,6 +5,7 @@ import (
 	"algoforces/internal/middleware"
 	"algoforces/internal/utils"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -52,6 +53,10 @@ func (h *ProblemHandler) CreateProblem(c *gin.Context) {
 			utils.SendError(c, http.StatusForbidden, err, err.Error())
 			return
 		}
+		if strings.HasPrefix(err.Error(), "unbalanced $") || strings.HasPrefix(err.Error(), "co-author ") {
+			utils.SendError(c, http.StatusBadRequest, err, err.Error())
+			return
+		}
 		utils.SendError(c, http.StatusInternalServerError, err, "Failed to create problem")
 		return
 	}
@@ -62,7 +67,7 @@ func (h *ProblemHandler) CreateProblem(c *gin.Context) {
 // CreateProblemsInBulk godoc
 //
 //	@Summary		Create Multiple Problems in Bulk
-//	@Description	Create multiple problems at once (admin or problem-setter only)
+//	@Description	Create multiple problems at once (admin or problem-setter only). In atomic mode, the default, nothing is created when any problem fails and the failures are returned with 422. In best_effort mode every valid problem is created and the failures are reported per item.
 //	@Tags			Problem
 //	@Accept			json
 //	@Produce		json
@@ -71,6 +76,7 @@ func (h *ProblemHandler) CreateProblem(c *gin.Context) {
 //	@Success		201	{object}	domain.BulkProblemCreationResponse
 //	@Failure		400	{object}	utils.ErrorResponse
 //	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		422	{object}	domain.BulkProblemCreationResponse
 //	@Failure		500	{object}	utils.ErrorResponse
 //	@Router			/api/problem/bulk [post]
 func (h *ProblemHandler) CreateProblemsInBulk(c *gin.Context) {
@@ -96,6 +102,11 @@ func (h *ProblemHandler) CreateProblemsInBulk(c *gin.Context) {
 		return
 	}
 
+	if bulkRequest.Mode != domain.BulkModeBestEffort && bulkResponse.FailedCount > 0 {
+		utils.SendSuccess(c, http.StatusUnprocessableEntity, bulkResponse, "No problems were created, some of them are invalid")
+		return
+	}
+
 	utils.SendSuccess(c, http.StatusCreated, bulkResponse, "Bulk problem creation completed")
 }
 
@@ -105,7 +116,9 @@ func (h *ProblemHandler) CreateProblemsInBulk(c *gin.Context) {
 //	@Description	Get a specific problem by its unique ID
 //	@Tags			Problem
 //	@Produce		json
-//	@Param			id	path	string	true	"Problem ID"
+//	@Param			id				path	string	true	"Problem ID"
+//	@Param			lang			query	string	false	"Language to read the problem in, takes precedence over Accept-Language"	Enums(en, ru, hi)
+//	@Param			Accept-Language	header	string	false	"Preferred languages, the problem's own language when none is available"
 //	@Security		BearerAuth
 //	@Success		200	{object}	domain.ProblemCreationResponse
 //	@Failure		404	{object}	utils.ErrorResponse
@@ -118,19 +131,33 @@ func (h *ProblemHandler) GetProblemByID(c *gin.Context) {
 		return
 	}
 
-	problemResponse, err := h.problemUseCase.GetProblemByID(c.Request.Context(), problemID)
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	problemResponse, err := h.problemUseCase.GetProblemByID(c.Request.Context(), problemID, userID, role, preferredLocales(c))
 	if err != nil {
 		utils.SendError(c, http.StatusNotFound, err, "Problem not found")
 		return
 	}
 
+	c.Header("Content-Language", problemResponse.Locale)
+
 	utils.SendSuccess(c, http.StatusOK, problemResponse, "Problem retrieved successfully")
 }
 
 // UpdateProblem godoc
 //
 //	@Summary		Update a Problem
-//	@Description	Update an existing problem (admin or creator only)
+//	@Description	Update an existing problem (admin or problem author only)
 //	@Tags			Problem
 //	@Accept			json
 //	@Produce		json
@@ -157,7 +184,7 @@ func (h *ProblemHandler) UpdateProblem(c *gin.Context) {
 
 	problemResponse, err := h.problemUseCase.UpdateProblem(c.Request.Context(), &problemRequest, userID)
 	if err != nil {
-		if err.Error() == "user can only update their own problems" || err.Error() == "user does not have permission to update problems" {
+		if err.Error() == "user can only manage their own problems" || err.Error() == "user does not have permission to update problems" || err.Error() == "only the creator of a problem can change its co-authors" {
 			utils.SendError(c, http.StatusForbidden, err, err.Error())
 			return
 		}
@@ -165,6 +192,10 @@ func (h *ProblemHandler) UpdateProblem(c *gin.Context) {
 			utils.SendError(c, http.StatusNotFound, err, err.Error())
 			return
 		}
+		if strings.HasPrefix(err.Error(), "unbalanced $") || strings.HasPrefix(err.Error(), "co-author ") {
+			utils.SendError(c, http.StatusBadRequest, err, err.Error())
+			return
+		}
 		utils.SendError(c, http.StatusInternalServerError, err, "Failed to update problem")
 		return
 	}
@@ -175,7 +206,7 @@ func (h *ProblemHandler) UpdateProblem(c *gin.Context) {
 // DeleteProblem godoc
 //
 //	@Summary		Delete a Problem
-//	@Description	Delete a problem by ID (admin or creator only)
+//	@Description	Delete a problem by ID (admin or problem author only)
 //	@Tags			Problem
 //	@Produce		json
 //	@Param			id	path	string	true	"Problem ID"
@@ -201,7 +232,7 @@ func (h *ProblemHandler) DeleteProblem(c *gin.Context) {
 
 	err = h.problemUseCase.DeleteProblem(c.Request.Context(), problemID, userID)
 	if err != nil {
-		if err.Error() == "user can only delete their own problems" || err.Error() == "user does not have permission to delete problems" {
+		if err.Error() == "user can only manage their own problems" || err.Error() == "user does not have permission to delete problems" {
 			utils.SendError(c, http.StatusForbidden, err, err.Error())
 			return
 		}
@@ -219,19 +250,75 @@ func (h *ProblemHandler) DeleteProblem(c *gin.Context) {
 // GetAllProblems godoc
 //
 //	@Summary		Get All Problems
-//	@Description	Get all problems (authenticated users only)
+//	@Description	Search, filter, sort and paginate the problems visible to the user
 //	@Tags			Problem
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Success		200	{array}		domain.ProblemCreationResponse
-//	@Failure		500	{object}	utils.ErrorResponse
+//	@Param			search		query		string		false	"Full-text search over title and statement"
+//	@Param			tags		query		[]string	false	"Problems having all these tags"	collectionFormat(multi)
+//	@Param			difficulty	query		string		false	"Difficulty"	Enums(easy, medium, hard)
+//	@Param			min_rating	query		int			false	"Minimum rating"
+//	@Param			max_rating	query		int			false	"Maximum rating"
+//	@Param			sort_by		query		string		false	"Sort key, relevance by default when searching"	Enums(created_at, rating, title, relevance)
+//	@Param			order		query		string		false	"Sort order"	Enums(asc, desc)	default(desc)
+//	@Param			page		query		int			false	"Page number"	default(1)
+//	@Param			page_size	query		int			false	"Page size"		default(20)
+//	@Param			lang		query		string		false	"Language to read the problems in, takes precedence over Accept-Language"	Enums(en, ru, hi)
+//	@Param			Accept-Language	header	string		false	"Preferred languages, each problem's own language when none is available"
+//	@Success		200			{object}	domain.ProblemListResponse
+//	@Failure		400			{object}	utils.ErrorResponse
+//	@Failure		500			{object}	utils.ErrorResponse
 //	@Router			/api/problem/all [get]
 func (h *ProblemHandler) GetAllProblems(c *gin.Context) {
-	problems, err := h.problemUseCase.GetAllProblems(c.Request.Context())
+	var filter domain.ProblemFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid query parameters")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
 	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get problems")
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	filter.Locales = preferredLocales(c)
+	problems, err := h.problemUseCase.GetAllProblems(c.Request.Context(), &filter, userID, role)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Failed to get problems")
 		return
 	}
 
 	utils.SendSuccess(c, http.StatusOK, problems, "Problems retrieved successfully")
 }
+
+// GetAllTags godoc
+//
+//	@Summary		Get Problem Tags
+//	@Description	Get every tag of the problems visible to the user with the number of those problems using it, most used first
+//	@Tags			Problem
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		domain.TagCount
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/tags [get]
+func (h *ProblemHandler) GetAllTags(c *gin.Context) {
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.problemUseCase.GetAllTags(c.Request.Context(), userID, role)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get tags")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, tags, "Tags retrieved successfully")
+}
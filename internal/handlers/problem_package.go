@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/middleware"
+	"algoforces/internal/utils"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProblemPackageHandler struct {
+	packageUseCase domain.ProblemPackageUseCase
+}
+
+func NewProblemPackageHandler(packageUseCase domain.ProblemPackageUseCase) *ProblemPackageHandler {
+	return &ProblemPackageHandler{
+		packageUseCase: packageUseCase,
+	}
+}
+
+// ImportPackage godoc
+//
+//	@Summary		Import a problem package
+//	@Description	Create a draft problem from a zipped Kattis (problem.yaml, data/sample, data/secret) or Polygon (problem.xml, tests/) package, with its statement, limits, sample and hidden tests, checker and validator. Polygon packages must include their generated tests.
+//	@Tags			Problem
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			package		formData	file	true	"Zipped problem package"
+//	@Param			format		formData	string	false	"Package format, detected when empty"	Enums(kattis, polygon)
+//	@Param			difficulty	formData	string	false	"Problem difficulty, medium when empty"	Enums(easy, medium, hard)
+//	@Param			rating		formData	int		false	"Problem rating"
+//	@Param			tags		formData	[]string	false	"Problem tags"	collectionFormat(multi)
+//	@Security		BearerAuth
+//	@Success		201	{object}	domain.ProblemPackageImportResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		413	{object}	utils.ErrorResponse
+//	@Router			/api/problem/import [post]
+func (h *ProblemPackageHandler) ImportPackage(c *gin.Context) {
+	var req domain.ProblemPackageImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request")
+		return
+	}
+
+	fileHeader, err := c.FormFile("package")
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Package file is required")
+		return
+	}
+	if fileHeader.Size > domain.MaxProblemPackageSize {
+		err = fmt.Errorf("package is larger than %d MB", domain.MaxProblemPackageSize>>20)
+		utils.SendError(c, http.StatusRequestEntityTooLarge, err, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Failed to read package file")
+		return
+	}
+	defer file.Close()
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	response, err := h.packageUseCase.ImportPackage(c.Request.Context(), file, fileHeader.Size, &req, userID)
+	if err != nil {
+		if err.Error() == "user does not have permission to create problems" {
+			utils.SendError(c, http.StatusForbidden, err, err.Error())
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, err, "Failed to import problem package")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, response, "Problem package imported successfully")
+}
+
+// ExportPackage godoc
+//
+//	@Summary		Export a problem package
+//	@Description	Download a problem with all its tests, checker and validator as a zipped Kattis or Polygon package (admin or problem author only)
+//	@Tags			Problem
+//	@Produce		application/zip
+//	@Param			id		path	string	true	"Problem ID"
+//	@Param			format	query	string	false	"Package format"	Enums(kattis, polygon)	default(kattis)
+//	@Security		BearerAuth
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/export [get]
+func (h *ProblemPackageHandler) ExportPackage(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	format := c.DefaultQuery("format", domain.ProblemPackageKattis)
+	if format != domain.ProblemPackageKattis && format != domain.ProblemPackagePolygon {
+		utils.SendError(c, http.StatusBadRequest, errors.New("unsupported package format "+format), "Invalid package format")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	data, err := h.packageUseCase.ExportPackage(c.Request.Context(), problemID, format, userID, role)
+	if err != nil {
+		switch err.Error() {
+		case "problem not found":
+			utils.SendError(c, http.StatusNotFound, err, err.Error())
+		case "user can only manage their own problems":
+			utils.SendError(c, http.StatusForbidden, err, err.Error())
+		default:
+			utils.SendError(c, http.StatusInternalServerError, err, "Failed to export problem package")
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="problem-`+problemID+`-`+format+`.zip"`)
+	c.Data(http.StatusOK, "application/zip", data)
+}
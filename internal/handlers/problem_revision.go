@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/middleware"
+	"algoforces/internal/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProblemRevisionHandler struct {
+	revisionUseCase domain.ProblemRevisionUseCase
+}
+
+func NewProblemRevisionHandler(revisionUseCase domain.ProblemRevisionUseCase) *ProblemRevisionHandler {
+	return &ProblemRevisionHandler{
+		revisionUseCase: revisionUseCase,
+	}
+}
+
+// GetRevisions godoc
+//
+//	@Summary		Get problem revisions
+//	@Description	Get the revision history of a problem, newest first (admin or problem author only). A revision is recorded whenever the statement, the limits or the test set change.
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id	path	string	true	"Problem ID"
+//	@Security		BearerAuth
+//	@Success		200	{array}		domain.ProblemRevision
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/revisions [get]
+func (h *ProblemRevisionHandler) GetRevisions(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.revisionUseCase.GetRevisions(c.Request.Context(), problemID, userID, role)
+	if err != nil {
+		sendRevisionError(c, err, "Failed to get problem revisions")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, revisions, "Problem revisions retrieved successfully")
+}
+
+// DiffRevisions godoc
+//
+//	@Summary		Diff two problem revisions
+//	@Description	Compare two revisions of a problem: the changed fields, a line diff of the statement and whether the test set changed (admin or problem author only)
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id		path	string	true	"Problem ID"
+//	@Param			from	query	int		true	"Revision number to compare from"
+//	@Param			to		query	int		true	"Revision number to compare to"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.ProblemRevisionDiff
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/revisions/diff [get]
+func (h *ProblemRevisionHandler) DiffRevisions(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid from revision")
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid to revision")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	diff, err := h.revisionUseCase.DiffRevisions(c.Request.Context(), problemID, from, to, userID, role)
+	if err != nil {
+		sendRevisionError(c, err, "Failed to diff problem revisions")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, diff, "Problem revisions compared successfully")
+}
+
+// RollbackProblem godoc
+//
+//	@Summary		Roll back a problem
+//	@Description	Restore the statement and limits of an earlier revision, recorded as a new revision (admin or problem author only). Test cases are not restored, compare test_set_hash to see whether they differ.
+//	@Tags			Problem
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string							true	"Problem ID"
+//	@Param			request	body	domain.RollbackProblemRequest	true	"Revision to restore"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.ProblemRevision
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/revisions/rollback [post]
+func (h *ProblemRevisionHandler) RollbackProblem(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	var req domain.RollbackProblemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.revisionUseCase.RollbackProblem(c.Request.Context(), problemID, &req, userID, role)
+	if err != nil {
+		sendRevisionError(c, err, "Failed to roll back problem")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, revision, "Problem rolled back successfully")
+}
+
+// currentUser returns the caller's ID and role, sending an error when they
+// are missing from the context
+func currentUser(c *gin.Context) (string, string, bool) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return "", "", false
+	}
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return "", "", false
+	}
+	return userID, role, true
+}
+
+func sendRevisionError(c *gin.Context, err error, message string) {
+	if err.Error() == "user can only manage their own problems" {
+		utils.SendError(c, http.StatusForbidden, err, err.Error())
+		return
+	}
+	// "problem not found" and "revision ... not found"
+	if strings.HasSuffix(err.Error(), "not found") {
+		utils.SendError(c, http.StatusNotFound, err, err.Error())
+		return
+	}
+	utils.SendError(c, http.StatusBadRequest, err, message)
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetProblemStatement godoc
+//
+//	@Summary		Get a problem statement
+//	@Description	Get the statement of a problem ready to display: its Markdown sections with LaTeX math, the samples taken from the visible test cases and the attachments. Attachment references (attachment:<file name>) are replaced by download URLs.
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id				path	string	true	"Problem ID"
+//	@Param			lang			query	string	false	"Language to read the statement in, takes precedence over Accept-Language"	Enums(en, ru, hi)
+//	@Param			Accept-Language	header	string	false	"Preferred languages, the problem's own language when none is available"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.ProblemStatement
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/statement [get]
+func (h *ProblemHandler) GetProblemStatement(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	statement, err := h.problemUseCase.GetProblemStatement(c.Request.Context(), problemID, userID, role, preferredLocales(c))
+	if err != nil {
+		if err.Error() == "problem not found" {
+			utils.SendError(c, http.StatusNotFound, err, err.Error())
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get problem statement")
+		return
+	}
+
+	c.Header("Content-Language", statement.Locale)
+	utils.SendSuccess(c, http.StatusOK, statement, "Problem statement retrieved successfully")
+}
+
+// UploadAttachment godoc
+//
+//	@Summary		Upload a problem attachment
+//	@Description	Attach an image or file to a problem statement (admin or problem author only). Statements reference it as attachment:<file name>, a file uploaded under an existing name replaces it.
+//	@Tags			Problem
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id			path		string	true	"Problem ID"
+//	@Param			file		formData	file	true	"Attachment, at most 10 MB"
+//	@Param			file_name	formData	string	false	"Name to store the file under, the uploaded file name when empty"
+//	@Security		BearerAuth
+//	@Success		201	{object}	domain.ProblemAttachment
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		413	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/attachments [post]
+func (h *ProblemHandler) UploadAttachment(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "File is required")
+		return
+	}
+	if fileHeader.Size > domain.MaxAttachmentSize {
+		utils.SendError(c, http.StatusRequestEntityTooLarge, nil, "Attachment is larger than 10 MB")
+		return
+	}
+	fileName := c.PostForm("file_name")
+	if fileName == "" {
+		fileName = fileHeader.Filename
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Failed to read file")
+		return
+	}
+	defer file.Close()
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.problemUseCase.UploadAttachment(c.Request.Context(), problemID, fileName, fileHeader.Header.Get("Content-Type"), file, userID, role)
+	if err != nil {
+		sendAttachmentError(c, err, "Failed to upload attachment")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, attachment, "Attachment uploaded successfully")
+}
+
+// GetAttachment godoc
+//
+//	@Summary		Download a problem attachment
+//	@Description	Download a file attached to a problem statement, for users who can view the problem. Images are shown inline, other files are downloaded.
+//	@Tags			Problem
+//	@Produce		octet-stream
+//	@Param			id		path	string	true	"Problem ID"
+//	@Param			name	path	string	true	"Attachment file name"
+//	@Security		BearerAuth
+//	@Success		200	{file}		file
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/attachments/{name} [get]
+func (h *ProblemHandler) GetAttachment(c *gin.Context) {
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.problemUseCase.OpenAttachment(c.Request.Context(), c.Param("id"), c.Param("name"), userID, role)
+	if err != nil {
+		sendAttachmentError(c, err, "Failed to get attachment")
+		return
+	}
+	defer content.Close()
+
+	disposition := "attachment"
+	if strings.HasPrefix(attachment.ContentType, "image/") {
+		disposition = "inline"
+	}
+	// Uploaded files are served from the API origin, never let them run scripts
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":     disposition + `; filename="` + attachment.FileName + `"`,
+		"Content-Security-Policy": "sandbox",
+		"X-Content-Type-Options":  "nosniff",
+	})
+}
+
+// DeleteAttachment godoc
+//
+//	@Summary		Delete a problem attachment
+//	@Description	Delete a file attached to a problem statement (admin or problem author only)
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id		path	string	true	"Problem ID"
+//	@Param			name	path	string	true	"Attachment file name"
+//	@Security		BearerAuth
+//	@Success		200	{object}	utils.SuccessResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/attachments/{name} [delete]
+func (h *ProblemHandler) DeleteAttachment(c *gin.Context) {
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	err := h.problemUseCase.DeleteAttachment(c.Request.Context(), c.Param("id"), c.Param("name"), userID, role)
+	if err != nil {
+		sendAttachmentError(c, err, "Failed to delete attachment")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, nil, "Attachment deleted successfully")
+}
+
+func sendAttachmentError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "problem not found" || err.Error() == "attachment not found":
+		utils.SendError(c, http.StatusNotFound, err, err.Error())
+	case err.Error() == "user can only manage their own problems":
+		utils.SendError(c, http.StatusForbidden, err, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid attachment file name"):
+		utils.SendError(c, http.StatusBadRequest, err, err.Error())
+	case strings.HasPrefix(err.Error(), "attachment is larger than"):
+		utils.SendError(c, http.StatusRequestEntityTooLarge, err, err.Error())
+	default:
+		utils.SendError(c, http.StatusInternalServerError, err, message)
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// preferredLocales returns the languages the client wants to read problems
+// in, most preferred first: the lang query parameter, then Accept-Language
+func preferredLocales(c *gin.Context) []string {
+	var locales []string
+	if lang := strings.ToLower(strings.TrimSpace(c.Query("lang"))); lang != "" {
+		locales = append(locales, lang)
+	}
+	return append(locales, utils.ParseAcceptLanguage(c.GetHeader("Accept-Language"))...)
+}
+
+// SaveTranslation godoc
+//
+//	@Summary		Translate a problem
+//	@Description	Create or replace the translation of a problem's title and statement into a locale other than the one it is written in (admin or problem author only)
+//	@Tags			Problem
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string								true	"Problem ID"
+//	@Param			locale	path	string								true	"Locale"	Enums(en, ru, hi)
+//	@Param			request	body	domain.ProblemTranslationRequest	true	"Translation"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.ProblemTranslation
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/translations/{locale} [put]
+func (h *ProblemHandler) SaveTranslation(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	var req domain.ProblemTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	translation, err := h.problemUseCase.SaveTranslation(c.Request.Context(), problemID, c.Param("locale"), &req, userID, role)
+	if err != nil {
+		sendTranslationError(c, err, "Failed to save translation")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, translation, "Translation saved successfully")
+}
+
+// GetTranslations godoc
+//
+//	@Summary		Get the translations of a problem
+//	@Description	Get every translation of a problem's title and statement
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id	path	string	true	"Problem ID"
+//	@Security		BearerAuth
+//	@Success		200	{array}		domain.ProblemTranslation
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/translations [get]
+func (h *ProblemHandler) GetTranslations(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	translations, err := h.problemUseCase.GetTranslations(c.Request.Context(), problemID, userID, role)
+	if err != nil {
+		sendTranslationError(c, err, "Failed to get translations")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, translations, "Translations retrieved successfully")
+}
+
+// DeleteTranslation godoc
+//
+//	@Summary		Delete a problem translation
+//	@Description	Delete the translation of a problem into a locale (admin or problem author only)
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id		path	string	true	"Problem ID"
+//	@Param			locale	path	string	true	"Locale"	Enums(en, ru, hi)
+//	@Security		BearerAuth
+//	@Success		200	{object}	utils.SuccessResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/translations/{locale} [delete]
+func (h *ProblemHandler) DeleteTranslation(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	err := h.problemUseCase.DeleteTranslation(c.Request.Context(), problemID, c.Param("locale"), userID, role)
+	if err != nil {
+		sendTranslationError(c, err, "Failed to delete translation")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, nil, "Translation deleted successfully")
+}
+
+func sendTranslationError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "problem not found" || err.Error() == "translation not found":
+		utils.SendError(c, http.StatusNotFound, err, err.Error())
+	case err.Error() == "user can only manage their own problems":
+		utils.SendError(c, http.StatusForbidden, err, err.Error())
+	case strings.HasPrefix(err.Error(), "unsupported locale"),
+		strings.HasPrefix(err.Error(), "the problem is written in"),
+		strings.HasPrefix(err.Error(), "unbalanced $"):
+		utils.SendError(c, http.StatusBadRequest, err, err.Error())
+	default:
+		utils.SendError(c, http.StatusInternalServerError, err, message)
+	}
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReferenceSolutionHandler struct {
+	solutionUseCase domain.ReferenceSolutionUseCase
+}
+
+func NewReferenceSolutionHandler(solutionUseCase domain.ReferenceSolutionUseCase) *ReferenceSolutionHandler {
+	return &ReferenceSolutionHandler{
+		solutionUseCase: solutionUseCase,
+	}
+}
+
+// AddSolution godoc
+//
+//	@Summary		Add a reference solution
+//	@Description	Attach a reference solution to a problem and queue its run against every test case (admin or problem author only). Main and correct solutions must be accepted, wrong solutions must fail and time_limit solutions must exceed the time limit. A problem has at most one main solution.
+//	@Tags			Problem
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string									true	"Problem ID"
+//	@Param			request	body	domain.CreateReferenceSolutionRequest	true	"Reference solution"
+//	@Security		BearerAuth
+//	@Success		201	{object}	domain.ReferenceSolution
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		409	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/solutions [post]
+func (h *ReferenceSolutionHandler) AddSolution(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	var req domain.CreateReferenceSolutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	solution, err := h.solutionUseCase.AddSolution(c.Request.Context(), problemID, &req, userID, role)
+	if err != nil {
+		sendReferenceSolutionError(c, err, "Failed to add reference solution")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, solution, "Reference solution added successfully")
+}
+
+// GetSolutions godoc
+//
+//	@Summary		Get the reference solutions of a problem
+//	@Description	Get the reference solutions of a problem with the result of their last run (admin or problem author only)
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id	path	string	true	"Problem ID"
+//	@Security		BearerAuth
+//	@Success		200	{array}		domain.ReferenceSolution
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/solutions [get]
+func (h *ReferenceSolutionHandler) GetSolutions(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	solutions, err := h.solutionUseCase.GetSolutions(c.Request.Context(), problemID, userID, role)
+	if err != nil {
+		sendReferenceSolutionError(c, err, "Failed to get reference solutions")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, solutions, "Reference solutions retrieved successfully")
+}
+
+// DeleteSolution godoc
+//
+//	@Summary		Delete a reference solution
+//	@Description	Delete a reference solution of a problem (admin or problem author only)
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id			path	string	true	"Problem ID"
+//	@Param			solutionId	path	string	true	"Reference solution ID"
+//	@Security		BearerAuth
+//	@Success		200	{object}	utils.SuccessResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/solutions/{solutionId} [delete]
+func (h *ReferenceSolutionHandler) DeleteSolution(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	err := h.solutionUseCase.DeleteSolution(c.Request.Context(), problemID, c.Param("solutionId"), userID, role)
+	if err != nil {
+		sendReferenceSolutionError(c, err, "Failed to delete reference solution")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, nil, "Reference solution deleted successfully")
+}
+
+// RunSolutions godoc
+//
+//	@Summary		Run the reference solutions
+//	@Description	Queue a new run of every reference solution of a problem, e.g. after its tests or limits changed (admin or problem author only)
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id	path	string	true	"Problem ID"
+//	@Security		BearerAuth
+//	@Success		202	{array}		domain.ReferenceSolution
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/solutions/run [post]
+func (h *ReferenceSolutionHandler) RunSolutions(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	solutions, err := h.solutionUseCase.RunSolutions(c.Request.Context(), problemID, userID, role)
+	if err != nil {
+		sendReferenceSolutionError(c, err, "Failed to run reference solutions")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusAccepted, solutions, "Reference solutions queued successfully")
+}
+
+// GetReport godoc
+//
+//	@Summary		Get the reference solution report
+//	@Description	Tell whether every reference solution got its expected verdict on the latest revision of the problem and suggest a time limit: the max runtime of the main solution times a configured factor, rounded up to whole seconds (admin or problem author only)
+//	@Tags			Problem
+//	@Produce		json
+//	@Param			id	path	string	true	"Problem ID"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.ReferenceSolutionReport
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/solutions/report [get]
+func (h *ReferenceSolutionHandler) GetReport(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.solutionUseCase.GetReport(c.Request.Context(), problemID, userID, role)
+	if err != nil {
+		sendReferenceSolutionError(c, err, "Failed to get reference solution report")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, report, "Reference solution report retrieved successfully")
+}
+
+func sendReferenceSolutionError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "problem not found", "reference solution not found":
+		utils.SendError(c, http.StatusNotFound, err, err.Error())
+	case "user can only manage their own problems":
+		utils.SendError(c, http.StatusForbidden, err, err.Error())
+	case "the problem already has a main solution":
+		utils.SendError(c, http.StatusConflict, err, err.Error())
+	case "the problem has no test cases", "the problem has no reference solutions":
+		utils.SendError(c, http.StatusBadRequest, err, err.Error())
+	default:
+		utils.SendError(c, http.StatusInternalServerError, err, message)
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/middleware"
+	"algoforces/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StandingsHandler struct {
+	standingsUseCase domain.StandingsUseCase
+}
+
+func NewStandingsHandler(standingsUseCase domain.StandingsUseCase) *StandingsHandler {
+	return &StandingsHandler{
+		standingsUseCase: standingsUseCase,
+	}
+}
+
+// GetContestStandings godoc
+//
+//	@Summary		Get Contest Standings
+//	@Description	Get the ICPC style standings of a contest (virtual participants excluded)
+//	@Tags			Standings
+//	@Produce		json
+//	@Param			id	path	string	true	"Contest ID"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.StandingsResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/contest/{id}/standings [get]
+func (h *StandingsHandler) GetContestStandings(c *gin.Context) {
+	contestId := c.Param("id")
+	if contestId == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	standings, err := h.standingsUseCase.GetContestStandings(c.Request.Context(), contestId, userID, role)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, err, "Failed to get standings")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, standings, "Standings retrieved successfully")
+}
+
+// GetVirtualStandings godoc
+//
+//	@Summary		Get Virtual Contest Standings
+//	@Description	Get the ghost standings of the current user's virtual participation, merged with the original participants at the same elapsed time
+//	@Tags			Standings
+//	@Produce		json
+//	@Param			id	path	string	true	"Contest ID"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.StandingsResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Router			/api/contest/{id}/standings/virtual [get]
+func (h *StandingsHandler) GetVirtualStandings(c *gin.Context) {
+	contestId := c.Param("id")
+	if contestId == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	role, err := middleware.GetUserRole(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user role")
+		return
+	}
+
+	standings, err := h.standingsUseCase.GetVirtualStandings(c.Request.Context(), contestId, userID, role)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, err, "Failed to get virtual standings")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, standings, "Virtual standings retrieved successfully")
+}
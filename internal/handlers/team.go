@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/middleware"
+	"algoforces/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TeamHandler struct {
+	teamUseCase domain.TeamUseCase
+}
+
+func NewTeamHandler(teamUseCase domain.TeamUseCase) *TeamHandler {
+	return &TeamHandler{
+		teamUseCase: teamUseCase,
+	}
+}
+
+// CreateTeam godoc
+//
+//	@Summary		Create a team
+//	@Description	Create a new team with the current user as captain
+//	@Tags			Team
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.CreateTeamRequest	true	"Create team request"
+//	@Success		201		{object}	domain.TeamResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/team/create [post]
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	var createTeamRequest domain.CreateTeamRequest
+	if err := c.ShouldBindJSON(&createTeamRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	teamResponse, err := h.teamUseCase.CreateTeam(c.Request.Context(), userID, &createTeamRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to create team")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, teamResponse, "Team created successfully")
+}
+
+// JoinTeam godoc
+//
+//	@Summary		Join a team
+//	@Description	Join a team using its invite code
+//	@Tags			Team
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.JoinTeamRequest	true	"Join team request"
+//	@Success		200		{object}	domain.TeamResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/team/join [post]
+func (h *TeamHandler) JoinTeam(c *gin.Context) {
+	var joinTeamRequest domain.JoinTeamRequest
+	if err := c.ShouldBindJSON(&joinTeamRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	teamResponse, err := h.teamUseCase.JoinTeam(c.Request.Context(), userID, &joinTeamRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to join team")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, teamResponse, "Joined team successfully")
+}
+
+// GetMyTeams godoc
+//
+//	@Summary		Get my teams
+//	@Description	Get all teams the current user is a member of
+//	@Tags			Team
+//	@Produce		json
+//	@Success		200	{array}		domain.TeamResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/team/my [get]
+func (h *TeamHandler) GetMyTeams(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	teams, err := h.teamUseCase.GetMyTeams(c.Request.Context(), userID)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get teams")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, teams, "Teams retrieved successfully")
+}
+
+// GetTeam godoc
+//
+//	@Summary		Get team details
+//	@Description	Get a team and its members
+//	@Tags			Team
+//	@Produce		json
+//	@Param			id	path		string	true	"Team ID"
+//	@Success		200	{object}	domain.TeamResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/team/{id} [get]
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	teamID := c.Param("id")
+	if teamID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Team ID is required")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	teamResponse, err := h.teamUseCase.GetTeam(c.Request.Context(), userID, teamID)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, err, "Team not found")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, teamResponse, "Team retrieved successfully")
+}
+
+// LeaveTeam godoc
+//
+//	@Summary		Leave a team
+//	@Description	Leave a team, captaincy passes to the longest standing member
+//	@Tags			Team
+//	@Produce		json
+//	@Param			id	path		string	true	"Team ID"
+//	@Success		200	{object}	utils.SuccessResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/team/{id}/leave [post]
+func (h *TeamHandler) LeaveTeam(c *gin.Context) {
+	teamID := c.Param("id")
+	if teamID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Team ID is required")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	err = h.teamUseCase.LeaveTeam(c.Request.Context(), userID, teamID)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to leave team")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, nil, "Left team successfully")
+}
+
+// RemoveTeamMember godoc
+//
+//	@Summary		Remove a team member
+//	@Description	Remove a member from the team (captain only)
+//	@Tags			Team
+//	@Produce		json
+//	@Param			id		path		string	true	"Team ID"
+//	@Param			userId	path		string	true	"Member user ID"
+//	@Success		200		{object}	utils.SuccessResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/team/{id}/members/{userId} [delete]
+func (h *TeamHandler) RemoveTeamMember(c *gin.Context) {
+	teamID := c.Param("id")
+	memberID := c.Param("userId")
+	if teamID == "" || memberID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Team ID and User ID are required")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	err = h.teamUseCase.RemoveTeamMember(c.Request.Context(), userID, teamID, memberID)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to remove team member")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, nil, "Team member removed successfully")
+}
+
+// RegenerateInviteCode godoc
+//
+//	@Summary		Regenerate team invite code
+//	@Description	Replace the team invite code, the previous one stops working (captain only)
+//	@Tags			Team
+//	@Produce		json
+//	@Param			id	path		string	true	"Team ID"
+//	@Success		200	{object}	domain.TeamResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/team/{id}/invite-code [post]
+func (h *TeamHandler) RegenerateInviteCode(c *gin.Context) {
+	teamID := c.Param("id")
+	if teamID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Team ID is required")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	teamResponse, err := h.teamUseCase.RegenerateInviteCode(c.Request.Context(), userID, teamID)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to regenerate invite code")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, teamResponse, "Invite code regenerated successfully")
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/utils"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UploadTestArchive godoc
+//
+//	@Summary		Upload a test archive
+//	@Description	Add the tests of a zip of NN.in files with their answers in NN.out or NN.ans (admin or problem author only). Tests in a sample/ directory or named sample* are samples, the others are hidden. Samples come first, then tests in natural order of their names. Every input is checked by the problem's validator and nothing is stored unless every test is accepted.
+//	@Tags			TestCase
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string	true	"Problem ID"
+//	@Param			archive	formData	file	true	"Zipped tests"
+//	@Param			replace	formData	bool	false	"Replace the existing test cases instead of appending after them"
+//	@Security		BearerAuth
+//	@Success		201	{object}	domain.TestArchiveUploadResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		413	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/tests/archive [post]
+func (h *TestCaseHandler) UploadTestArchive(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	var req domain.TestArchiveUploadRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request")
+		return
+	}
+
+	fileHeader, err := c.FormFile("archive")
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Archive file is required")
+		return
+	}
+	if fileHeader.Size > domain.MaxTestArchiveSize {
+		err = fmt.Errorf("archive is larger than %d MB", domain.MaxTestArchiveSize>>20)
+		utils.SendError(c, http.StatusRequestEntityTooLarge, err, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Failed to read archive file")
+		return
+	}
+	defer file.Close()
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.testCaseUseCase.UploadTestArchive(c.Request.Context(), problemID, file, fileHeader.Size, &req, userID, role)
+	if err != nil {
+		sendTestCaseError(c, err, "Failed to upload test archive")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, response, "Test archive uploaded successfully")
+}
+
+// DownloadTestArchive godoc
+//
+//	@Summary		Download a test archive
+//	@Description	Download the test cases of a problem as a zip in the layout the upload reads: samples in sample/, hidden tests at the root, numbered in judging order (admin or problem author only)
+//	@Tags			TestCase
+//	@Produce		application/zip
+//	@Param			id	path	string	true	"Problem ID"
+//	@Security		BearerAuth
+//	@Success		200	{file}		file
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/tests/archive [get]
+func (h *TestCaseHandler) DownloadTestArchive(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	w := &archiveWriter{c: c, problemID: problemID}
+	err := h.testCaseUseCase.DownloadTestArchive(c.Request.Context(), problemID, w, userID, role)
+	if err != nil {
+		// Once streaming started the status is already sent, the archive is cut short
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		sendTestCaseError(c, err, "Failed to download test archive")
+	}
+}
+
+// archiveWriter streams a test archive to the response, the download headers
+// are sent with the first bytes so errors before them are still reported
+type archiveWriter struct {
+	c         *gin.Context
+	problemID string
+}
+
+func (w *archiveWriter) Write(p []byte) (int, error) {
+	if !w.c.Writer.Written() {
+		w.c.Header("Content-Type", "application/zip")
+		w.c.Header("Content-Disposition", `attachment; filename="problem-`+w.problemID+`-tests.zip"`)
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
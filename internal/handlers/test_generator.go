@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TestGeneratorHandler struct {
+	generatorUseCase domain.TestGeneratorUseCase
+}
+
+func NewTestGeneratorHandler(generatorUseCase domain.TestGeneratorUseCase) *TestGeneratorHandler {
+	return &TestGeneratorHandler{
+		generatorUseCase: generatorUseCase,
+	}
+}
+
+// SaveGenerator godoc
+//
+//	@Summary		Save a test generator
+//	@Description	Upload a generator program, replacing the generator of the same name (admin or problem author only). A generator prints a test input to stdout from its command line arguments and must be deterministic, pass the seed as an argument.
+//	@Tags			TestCase
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string						true	"Problem ID"
+//	@Param			request	body	domain.SaveGeneratorRequest	true	"Generator"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.ProblemGenerator
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/generators [post]
+func (h *TestGeneratorHandler) SaveGenerator(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	var req domain.SaveGeneratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	generator, err := h.generatorUseCase.SaveGenerator(c.Request.Context(), problemID, &req, userID, role)
+	if err != nil {
+		sendGeneratorError(c, err, "Failed to save generator")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, generator, "Generator saved successfully")
+}
+
+// GetGenerators godoc
+//
+//	@Summary		Get the test generators of a problem
+//	@Description	Get the generator programs of a problem (admin or problem author only)
+//	@Tags			TestCase
+//	@Produce		json
+//	@Param			id	path	string	true	"Problem ID"
+//	@Security		BearerAuth
+//	@Success		200	{array}		domain.ProblemGenerator
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/generators [get]
+func (h *TestGeneratorHandler) GetGenerators(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	generators, err := h.generatorUseCase.GetGenerators(c.Request.Context(), problemID, userID, role)
+	if err != nil {
+		sendGeneratorError(c, err, "Failed to get generators")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, generators, "Generators retrieved successfully")
+}
+
+// DeleteGenerator godoc
+//
+//	@Summary		Delete a test generator
+//	@Description	Delete a generator program of a problem, the tests it generated are kept (admin or problem author only)
+//	@Tags			TestCase
+//	@Produce		json
+//	@Param			id		path	string	true	"Problem ID"
+//	@Param			name	path	string	true	"Generator name"
+//	@Security		BearerAuth
+//	@Success		200	{object}	utils.SuccessResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/generators/{name} [delete]
+func (h *TestGeneratorHandler) DeleteGenerator(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	err := h.generatorUseCase.DeleteGenerator(c.Request.Context(), problemID, c.Param("name"), userID, role)
+	if err != nil {
+		sendGeneratorError(c, err, "Failed to delete generator")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, nil, "Generator deleted successfully")
+}
+
+// GenerateTests godoc
+//
+//	@Summary		Generate test cases
+//	@Description	Queue a generator script (admin or problem author only). Every line invokes a generator with its arguments, e.g. "gen_random 100000 42", and gives a hidden test whose expected output is produced by the main reference solution. Inputs are checked by the problem's validator, nothing is stored unless every line succeeds.
+//	@Tags			TestCase
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string						true	"Problem ID"
+//	@Param			request	body	domain.GenerateTestsRequest	true	"Generator script"
+//	@Security		BearerAuth
+//	@Success		202	{object}	domain.TestGeneration
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/generate [post]
+func (h *TestGeneratorHandler) GenerateTests(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	var req domain.GenerateTestsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	generation, err := h.generatorUseCase.GenerateTests(c.Request.Context(), problemID, &req, userID, role)
+	if err != nil {
+		sendGeneratorError(c, err, "Failed to generate test cases")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusAccepted, generation, "Test generation queued successfully")
+}
+
+// GetGeneration godoc
+//
+//	@Summary		Get a test generation
+//	@Description	Get the status of a generator script run, with the error when it failed (admin or problem author only)
+//	@Tags			TestCase
+//	@Produce		json
+//	@Param			id				path	string	true	"Problem ID"
+//	@Param			generationId	path	string	true	"Test generation ID"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.TestGeneration
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/generations/{generationId} [get]
+func (h *TestGeneratorHandler) GetGeneration(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	generation, err := h.generatorUseCase.GetGeneration(c.Request.Context(), problemID, c.Param("generationId"), userID, role)
+	if err != nil {
+		sendGeneratorError(c, err, "Failed to get test generation")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, generation, "Test generation retrieved successfully")
+}
+
+func sendGeneratorError(c *gin.Context, err error, message string) {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		utils.SendError(c, http.StatusNotFound, err, err.Error())
+	case err.Error() == "user can only manage their own problems":
+		utils.SendError(c, http.StatusForbidden, err, err.Error())
+	case strings.HasPrefix(err.Error(), "the generator script"),
+		strings.HasPrefix(err.Error(), "unknown generator"),
+		strings.HasPrefix(err.Error(), "generator names"),
+		err.Error() == "the problem has no main solution":
+		utils.SendError(c, http.StatusBadRequest, err, err.Error())
+	default:
+		utils.SendError(c, http.StatusInternalServerError, err, message)
+	}
+}
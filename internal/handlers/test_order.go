@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReorderTestCases godoc
+//
+//	@Summary		Reorder test cases
+//	@Description	Set the judging order of the test cases of a problem (admin or problem author only). Every test case must be listed exactly once, they are numbered 1, 2, 3 ... in the given order and a new revision is recorded.
+//	@Tags			TestCase
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string							true	"Problem ID"
+//	@Param			request	body	domain.ReorderTestCasesRequest	true	"Test case IDs in the new order"
+//	@Security		BearerAuth
+//	@Success		200	{object}	domain.TestCaseOrder
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		403	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Failure		500	{object}	utils.ErrorResponse
+//	@Router			/api/problem/{id}/tests/order [put]
+func (h *TestCaseHandler) ReorderTestCases(c *gin.Context) {
+	problemID := c.Param("id")
+	if problemID == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Problem ID is required")
+		return
+	}
+
+	var req domain.ReorderTestCasesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.testCaseUseCase.ReorderTestCases(c.Request.Context(), problemID, &req, userID, role)
+	if err != nil {
+		sendTestCaseError(c, err, "Failed to reorder test cases")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, order, "Test cases reordered successfully")
+}
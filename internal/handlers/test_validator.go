@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetValidator godoc
+// @Summary		Set the validator of a problem
+// @Description	Attaches an input validator to a problem (admin or problem author only). The validator reads a test input on stdin and exits with 0 when it is valid, otherwise with another code and the reason on stderr. New and updated test cases are rejected when invalid, existing ones become unchecked until validated.
+// @Tags			TestCase
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"Problem ID"
+// @Param			request	body		domain.SetValidatorRequest	true	"Validator"
+// @Success		200		{object}	utils.SuccessResponse{data=domain.ProblemValidator}
+// @Failure		400		{object}	utils.ErrorResponse
+// @Failure		403		{object}	utils.ErrorResponse
+// @Failure		404		{object}	utils.ErrorResponse
+// @Failure		500		{object}	utils.ErrorResponse
+// @Security		BearerAuth
+// @Router			/api/problem/{id}/validator [put]
+func (h *TestCaseHandler) SetValidator(ctx *gin.Context) {
+	var setValidatorRequest domain.SetValidatorRequest
+	if err := ctx.ShouldBindJSON(&setValidatorRequest); err != nil {
+		utils.SendError(ctx, http.StatusBadRequest, err, "Invalid Request Body")
+		return
+	}
+
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	validator, err := h.testCaseUseCase.SetValidator(ctx.Request.Context(), ctx.Param("id"), &setValidatorRequest, userID, role)
+	if err != nil {
+		sendTestCaseError(ctx, err, "Failed to set validator")
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, validator, "Validator set successfully")
+}
+
+// GetValidator godoc
+// @Summary		Get the validator of a problem
+// @Description	Retrieves the input validator of a problem (admin or problem author only)
+// @Tags			TestCase
+// @Produce		json
+// @Param			id	path		string	true	"Problem ID"
+// @Success		200	{object}	utils.SuccessResponse{data=domain.ProblemValidator}
+// @Failure		403	{object}	utils.ErrorResponse
+// @Failure		404	{object}	utils.ErrorResponse
+// @Failure		500	{object}	utils.ErrorResponse
+// @Security		BearerAuth
+// @Router			/api/problem/{id}/validator [get]
+func (h *TestCaseHandler) GetValidator(ctx *gin.Context) {
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	validator, err := h.testCaseUseCase.GetValidator(ctx.Request.Context(), ctx.Param("id"), userID, role)
+	if err != nil {
+		sendTestCaseError(ctx, err, "Failed to fetch validator")
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, validator, "Validator fetched successfully")
+}
+
+// DeleteValidator godoc
+// @Summary		Delete the validator of a problem
+// @Description	Removes the input validator of a problem, its test cases become unchecked (admin or problem author only)
+// @Tags			TestCase
+// @Produce		json
+// @Param			id	path		string	true	"Problem ID"
+// @Success		200	{object}	utils.SuccessResponse
+// @Failure		403	{object}	utils.ErrorResponse
+// @Failure		404	{object}	utils.ErrorResponse
+// @Failure		500	{object}	utils.ErrorResponse
+// @Security		BearerAuth
+// @Router			/api/problem/{id}/validator [delete]
+func (h *TestCaseHandler) DeleteValidator(ctx *gin.Context) {
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	err := h.testCaseUseCase.DeleteValidator(ctx.Request.Context(), ctx.Param("id"), userID, role)
+	if err != nil {
+		sendTestCaseError(ctx, err, "Failed to delete validator")
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, nil, "Validator deleted successfully")
+}
+
+// ValidateTestCases godoc
+// @Summary		Validate the test cases of a problem
+// @Description	Runs the validator on every test case of a problem and stores their validation status (admin or problem author only)
+// @Tags			TestCase
+// @Produce		json
+// @Param			id	path		string	true	"Problem ID"
+// @Success		200	{object}	utils.SuccessResponse{data=domain.TestValidationReport}
+// @Failure		403	{object}	utils.ErrorResponse
+// @Failure		404	{object}	utils.ErrorResponse
+// @Failure		422	{object}	utils.ErrorResponse
+// @Failure		500	{object}	utils.ErrorResponse
+// @Security		BearerAuth
+// @Router			/api/problem/{id}/validator/run [post]
+func (h *TestCaseHandler) ValidateTestCases(ctx *gin.Context) {
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := h.testCaseUseCase.ValidateTestCases(ctx.Request.Context(), ctx.Param("id"), userID, role)
+	if err != nil {
+		sendTestCaseError(ctx, err, "Failed to validate test cases")
+		return
+	}
+
+	utils.SendSuccess(ctx, http.StatusOK, report, "Test cases validated successfully")
+}
+
+func sendTestCaseError(ctx *gin.Context, err error, message string) {
+	switch {
+	case strings.HasSuffix(err.Error(), "problem not found"),
+		err.Error() == "test case not found",
+		err.Error() == "the problem has no validator",
+		err.Error() == "the problem has no test cases":
+		utils.SendError(ctx, http.StatusNotFound, err, err.Error())
+	case err.Error() == "user can only manage their own problems":
+		utils.SendError(ctx, http.StatusForbidden, err, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid test archive"),
+		strings.Contains(err.Error(), "test input rejected by the validator"),
+		err.Error() == "the order must list every test case of the problem exactly once":
+		utils.SendError(ctx, http.StatusBadRequest, err, err.Error())
+	case strings.HasPrefix(err.Error(), "order position"):
+		utils.SendError(ctx, http.StatusConflict, err, err.Error())
+	case strings.Contains(err.Error(), "validator"):
+		// The validator itself could not be run
+		utils.SendError(ctx, http.StatusUnprocessableEntity, err, err.Error())
+	default:
+		utils.SendError(ctx, http.StatusInternalServerError, err, message)
+	}
+}
@@ -20,13 +20,16 @@ func NewTestCaseHandler(testCaseUseCase domain.TestCaseUseCase) *TestCaseHandler
 
 // CreateTestCase godoc
 // @Summary		Create a new test case
-// @Description	Creates a new test case for a problem
+// @Description	Creates a new test case for a problem (admin or problem author only)
 // @Tags			TestCase
 // @Accept			json
 // @Produce		json
 // @Param			request	body		domain.CreateTestCaseRequest	true	"Test case creation request"
 // @Success		200		{object}	utils.SuccessResponse{data=domain.CreateTestCaseResponse}
 // @Failure		400		{object}	utils.ErrorResponse
+// @Failure		403		{object}	utils.ErrorResponse
+// @Failure		404		{object}	utils.ErrorResponse
+// @Failure		409		{object}	utils.ErrorResponse
 // @Failure		500		{object}	utils.ErrorResponse
 // @Security		BearerAuth
 // @Router			/api/testcase/create [post]
@@ -37,9 +40,14 @@ func (h *TestCaseHandler) CreateTestCase(ctx *gin.Context) {
 		return
 	}
 
-	createTestCaseResponse, err := h.testCaseUseCase.CreateNewTestCase(ctx.Request.Context(), &createTestCaseRequest)
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	createTestCaseResponse, err := h.testCaseUseCase.CreateNewTestCase(ctx.Request.Context(), &createTestCaseRequest, userID, role)
 	if err != nil {
-		utils.SendError(ctx, http.StatusInternalServerError, err, "Failed to create test case")
+		sendTestCaseError(ctx, err, "Failed to create test case")
 		return
 	}
 
@@ -48,13 +56,14 @@ func (h *TestCaseHandler) CreateTestCase(ctx *gin.Context) {
 
 // GetAllTestCasesForProblem godoc
 // @Summary		Get all test cases for a problem
-// @Description	Retrieves all test cases associated with a specific problem
+// @Description	Retrieves the test cases of a problem. Admins and the problem's authors, its creator and the staff of its contests, get every test case, other users who can see the problem only the samples.
 // @Tags			TestCase
 // @Accept			json
 // @Produce		json
 // @Param			problemId	path		string	true	"Problem ID"
 // @Success		200			{object}	utils.SuccessResponse{data=[]domain.TestCase}
 // @Failure		400			{object}	utils.ErrorResponse
+// @Failure		404			{object}	utils.ErrorResponse
 // @Failure		500			{object}	utils.ErrorResponse
 // @Security		BearerAuth
 // @Router			/api/testcase/problem/{problemId} [get]
@@ -66,9 +75,14 @@ func (h *TestCaseHandler) GetAllTestCasesForProblem(ctx *gin.Context) {
 		return
 	}
 
-	testCases, err := h.testCaseUseCase.GetAllTestCasesForProblem(ctx.Request.Context(), problemID)
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	testCases, err := h.testCaseUseCase.GetAllTestCasesForProblem(ctx.Request.Context(), problemID, userID, role)
 	if err != nil {
-		utils.SendError(ctx, http.StatusInternalServerError, err, "Failed to fetch test cases")
+		sendTestCaseError(ctx, err, "Failed to fetch test cases")
 		return
 	}
 
@@ -77,13 +91,14 @@ func (h *TestCaseHandler) GetAllTestCasesForProblem(ctx *gin.Context) {
 
 // GetTestCaseDetails godoc
 // @Summary		Get test case details
-// @Description	Retrieves details of a specific test case by its unique ID
+// @Description	Retrieves details of a specific test case by its unique ID. Hidden test cases are only found for admins and the problem's authors.
 // @Tags			TestCase
 // @Accept			json
 // @Produce		json
 // @Param			id	path		string	true	"Test Case Unique ID"
 // @Success		200	{object}	utils.SuccessResponse{data=domain.TestCase}
 // @Failure		400	{object}	utils.ErrorResponse
+// @Failure		404	{object}	utils.ErrorResponse
 // @Failure		500	{object}	utils.ErrorResponse
 // @Security		BearerAuth
 // @Router			/api/testcase/{id} [get]
@@ -95,9 +110,14 @@ func (h *TestCaseHandler) GetTestCaseDetails(ctx *gin.Context) {
 		return
 	}
 
-	testCase, err := h.testCaseUseCase.GetTestCaseDetails(ctx.Request.Context(), uniqueID)
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	testCase, err := h.testCaseUseCase.GetTestCaseDetails(ctx.Request.Context(), uniqueID, userID, role)
 	if err != nil {
-		utils.SendError(ctx, http.StatusInternalServerError, err, "Failed to fetch test case details")
+		sendTestCaseError(ctx, err, "Failed to fetch test case details")
 		return
 	}
 
@@ -106,13 +126,16 @@ func (h *TestCaseHandler) GetTestCaseDetails(ctx *gin.Context) {
 
 // UpdateTestCase godoc
 // @Summary		Update a test case
-// @Description	Updates an existing test case
+// @Description	Updates an existing test case (admin or problem author only)
 // @Tags			TestCase
 // @Accept			json
 // @Produce		json
 // @Param			request	body		domain.UpdateTestCaseRequest	true	"Test case update request"
 // @Success		200		{object}	utils.SuccessResponse{data=domain.UpdateTestCaseResponse}
 // @Failure		400		{object}	utils.ErrorResponse
+// @Failure		403		{object}	utils.ErrorResponse
+// @Failure		404		{object}	utils.ErrorResponse
+// @Failure		409		{object}	utils.ErrorResponse
 // @Failure		500		{object}	utils.ErrorResponse
 // @Security		BearerAuth
 // @Router			/api/testcase/update [put]
@@ -123,9 +146,14 @@ func (h *TestCaseHandler) UpdateTestCase(ctx *gin.Context) {
 		return
 	}
 
-	updateTestCaseResponse, err := h.testCaseUseCase.UpdateSingleTestCase(ctx.Request.Context(), &updateTestCaseRequest)
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	updateTestCaseResponse, err := h.testCaseUseCase.UpdateSingleTestCase(ctx.Request.Context(), &updateTestCaseRequest, userID, role)
 	if err != nil {
-		utils.SendError(ctx, http.StatusInternalServerError, err, "Failed to update test case")
+		sendTestCaseError(ctx, err, "Failed to update test case")
 		return
 	}
 
@@ -134,13 +162,15 @@ func (h *TestCaseHandler) UpdateTestCase(ctx *gin.Context) {
 
 // DeleteTestCase godoc
 // @Summary		Delete a test case
-// @Description	Deletes a test case by its unique ID
+// @Description	Deletes a test case by its unique ID (admin or problem author only)
 // @Tags			TestCase
 // @Accept			json
 // @Produce		json
 // @Param			id	path		string	true	"Test Case Unique ID"
 // @Success		200	{object}	utils.SuccessResponse
 // @Failure		400	{object}	utils.ErrorResponse
+// @Failure		403	{object}	utils.ErrorResponse
+// @Failure		404	{object}	utils.ErrorResponse
 // @Failure		500	{object}	utils.ErrorResponse
 // @Security		BearerAuth
 // @Router			/api/testcase/{id} [delete]
@@ -152,9 +182,14 @@ func (h *TestCaseHandler) DeleteTestCase(ctx *gin.Context) {
 		return
 	}
 
-	err := h.testCaseUseCase.DeleteSingleTestCase(ctx.Request.Context(), uniqueID)
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	err := h.testCaseUseCase.DeleteSingleTestCase(ctx.Request.Context(), uniqueID, userID, role)
 	if err != nil {
-		utils.SendError(ctx, http.StatusInternalServerError, err, "Failed to delete test case")
+		sendTestCaseError(ctx, err, "Failed to delete test case")
 		return
 	}
 
@@ -163,13 +198,14 @@ func (h *TestCaseHandler) DeleteTestCase(ctx *gin.Context) {
 
 // UploadTestCasesInBulk godoc
 // @Summary		Upload test cases in bulk
-// @Description	Creates multiple test cases at once for a problem
+// @Description	Creates multiple test cases at once for a problem. In atomic mode, the default, nothing is created when any test case fails and the failures are returned with 422. In best_effort mode every valid test case is created and the failures are reported per item.
 // @Tags			TestCase
 // @Accept			json
 // @Produce		json
 // @Param			request	body		domain.BulkTestCaseUploadRequest	true	"Bulk test case upload request"
 // @Success		200		{object}	utils.SuccessResponse{data=domain.BulkTestCaseUploadResponse}
 // @Failure		400		{object}	utils.ErrorResponse
+// @Failure		422		{object}	utils.SuccessResponse{data=domain.BulkTestCaseUploadResponse}
 // @Failure		500		{object}	utils.ErrorResponse
 // @Security		BearerAuth
 // @Router			/api/testcase/bulk [post]
@@ -180,9 +216,19 @@ func (h *TestCaseHandler) UploadTestCasesInBulk(ctx *gin.Context) {
 		return
 	}
 
-	BulkTestCaseUploadResponse, err := h.testCaseUseCase.UploadTestCasesInBulk(ctx.Request.Context(), &BulkTestCaseUploadRequest)
+	userID, role, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	BulkTestCaseUploadResponse, err := h.testCaseUseCase.UploadTestCasesInBulk(ctx.Request.Context(), &BulkTestCaseUploadRequest, userID, role)
 	if err != nil {
-		utils.SendError(ctx, http.StatusInternalServerError, err, "Failed to upload test cases in bulk")
+		sendTestCaseError(ctx, err, "Failed to upload test cases in bulk")
+		return
+	}
+
+	if BulkTestCaseUploadRequest.Mode != domain.BulkModeBestEffort && BulkTestCaseUploadResponse.FailedCount > 0 {
+		utils.SendSuccess(ctx, http.StatusUnprocessableEntity, BulkTestCaseUploadResponse, "No test cases were uploaded, some of them are invalid")
 		return
 	}
 
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/middleware"
+	"algoforces/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VirtualParticipationHandler struct {
+	virtualParticipationUseCase domain.VirtualParticipationUseCase
+}
+
+func NewVirtualParticipationHandler(virtualParticipationUseCase domain.VirtualParticipationUseCase) *VirtualParticipationHandler {
+	return &VirtualParticipationHandler{
+		virtualParticipationUseCase: virtualParticipationUseCase,
+	}
+}
+
+// StartVirtualParticipation godoc
+//
+//	@Summary		Start a virtual participation
+//	@Description	Replay an ended contest starting now, with the original contest duration
+//	@Tags			contest-registration
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.StartVirtualParticipationRequest	true	"Start virtual participation request"
+//	@Success		201		{object}	domain.VirtualParticipationResponse
+//	@Failure		400		{object}	utils.ErrorResponse
+//	@Failure		500		{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/virtual/start [post]
+func (h *VirtualParticipationHandler) StartVirtualParticipation(c *gin.Context) {
+	var startRequest domain.StartVirtualParticipationRequest
+	if err := c.ShouldBindJSON(&startRequest); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err, "Invalid request body")
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	participationResponse, err := h.virtualParticipationUseCase.StartVirtualParticipation(c.Request.Context(), userID, role, &startRequest)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to start virtual participation")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusCreated, participationResponse, "Virtual participation started successfully")
+}
+
+// GetVirtualParticipation godoc
+//
+//	@Summary		Get virtual participation
+//	@Description	Get the current user's virtual participation in a contest
+//	@Tags			contest-registration
+//	@Produce		json
+//	@Param			id	path		string	true	"Contest ID"
+//	@Success		200	{object}	domain.VirtualParticipationResponse
+//	@Failure		400	{object}	utils.ErrorResponse
+//	@Failure		404	{object}	utils.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/contest/{id}/virtual [get]
+func (h *VirtualParticipationHandler) GetVirtualParticipation(c *gin.Context) {
+	contestId := c.Param("id")
+	if contestId == "" {
+		utils.SendError(c, http.StatusBadRequest, nil, "Contest ID is required")
+		return
+	}
+
+	// get user id from middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err, "Failed to get user ID")
+		return
+	}
+
+	participationResponse, err := h.virtualParticipationUseCase.GetVirtualParticipation(c.Request.Context(), userID, contestId)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, err, "Virtual participation not found")
+		return
+	}
+
+	utils.SendSuccess(c, http.StatusOK, participationResponse, "Virtual participation retrieved successfully")
+}
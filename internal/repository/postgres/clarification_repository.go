@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type clarificationRepository struct {
+	db *gorm.DB
+}
+
+func NewClarificationRepository(db *gorm.DB) domain.ClarificationRepository {
+	return &clarificationRepository{
+		db: db,
+	}
+}
+
+func (r *clarificationRepository) CreateClarification(ctx context.Context, clarification *domain.Clarification) error {
+	return r.db.WithContext(ctx).Create(clarification).Error
+}
+
+func (r *clarificationRepository) GetClarificationByID(ctx context.Context, id string) (*domain.Clarification, error) {
+	var clarification domain.Clarification
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&clarification).Error
+	if err != nil {
+		return nil, err
+	}
+	return &clarification, nil
+}
+
+func (r *clarificationRepository) GetContestClarifications(ctx context.Context, contestID string) ([]domain.Clarification, error) {
+	var clarifications []domain.Clarification
+	err := r.db.WithContext(ctx).Where("contest_id = ?", contestID).Order("created_at DESC").Find(&clarifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return clarifications, nil
+}
+
+// GetUserClarifications returns the public clarifications of a contest along
+// with the ones asked by the user
+func (r *clarificationRepository) GetUserClarifications(ctx context.Context, contestID string, userID string) ([]domain.Clarification, error) {
+	var clarifications []domain.Clarification
+	err := r.db.WithContext(ctx).
+		Where("contest_id = ? AND (is_public = ? OR asked_by = ?)", contestID, true, userID).
+		Order("created_at DESC").
+		Find(&clarifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return clarifications, nil
+}
+
+func (r *clarificationRepository) UpdateClarification(ctx context.Context, clarification *domain.Clarification) error {
+	return r.db.WithContext(ctx).Save(clarification).Error
+}
@@ -3,6 +3,8 @@ package postgres
 import (
 	"algoforces/internal/domain"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -18,12 +20,12 @@ func NewContestRegisterRepository(db *gorm.DB) domain.ContestRegisterRepository
 	}
 }
 
-func (r *contestRegisterRepository) CreateContestRegistration(ctx context.Context, userID string, contestID string) (*domain.ContestRegistration, error) {
+func (r *contestRegisterRepository) CreateContestRegistration(ctx context.Context, userID string, contestID string, status string) (*domain.ContestRegistration, error) {
 	registration := &domain.ContestRegistration{
 		ID:        uuid.New().String(),
 		UserID:    userID,
 		ContestID: contestID,
-		Status:    "registered",
+		Status:    status,
 	}
 	err := r.db.WithContext(ctx).Create(registration).Error
 	if err != nil {
@@ -32,6 +34,15 @@ func (r *contestRegisterRepository) CreateContestRegistration(ctx context.Contex
 	return registration, nil
 }
 
+func (r *contestRegisterRepository) GetRegistrationByID(ctx context.Context, id string) (*domain.ContestRegistration, error) {
+	var registration domain.ContestRegistration
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&registration).Error
+	if err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
 func (r *contestRegisterRepository) GetRegistrationByUserAndContest(ctx context.Context, userID string, contestID string) (*domain.ContestRegistration, error) {
 	var registration domain.ContestRegistration
 	err := r.db.WithContext(ctx).Where("user_id = ? AND contest_id = ?", userID, contestID).First(&registration).Error
@@ -60,3 +71,102 @@ func (r *contestRegisterRepository) GetAllRegistrationsForAdmin(ctx context.Cont
 	}
 	return registrations, nil
 }
+
+// RegisterTeamMembers registers every member of a team for a contest in a single
+// transaction, reusing previous registrations of the members when they exist
+func (r *contestRegisterRepository) RegisterTeamMembers(ctx context.Context, contestID string, teamID string, userIDs []string, status string) ([]domain.ContestRegistration, error) {
+	var registrations []domain.ContestRegistration
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, userID := range userIDs {
+			var registration domain.ContestRegistration
+			err := tx.Where("user_id = ? AND contest_id = ?", userID, contestID).First(&registration).Error
+			if err == nil {
+				registration.TeamID = &teamID
+				registration.Status = status
+				if err := tx.Save(&registration).Error; err != nil {
+					return err
+				}
+			} else if errors.Is(err, gorm.ErrRecordNotFound) {
+				registration = domain.ContestRegistration{
+					ID:        uuid.New().String(),
+					UserID:    userID,
+					ContestID: contestID,
+					TeamID:    &teamID,
+					Status:    status,
+				}
+				if err := tx.Create(&registration).Error; err != nil {
+					return err
+				}
+			} else {
+				return err
+			}
+			registrations = append(registrations, registration)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return registrations, nil
+}
+
+func (r *contestRegisterRepository) UpdateTeamRegistrationStatus(ctx context.Context, teamID string, contestID string, status string) error {
+	err := r.db.WithContext(ctx).Model(&domain.ContestRegistration{}).Where("team_id = ? AND contest_id = ?", teamID, contestID).Update("status", status).Error
+	return err
+}
+
+func (r *contestRegisterRepository) GetRegistrationsByContestID(ctx context.Context, contestID string) ([]domain.ContestRegistration, error) {
+	var registrations []domain.ContestRegistration
+	err := r.db.WithContext(ctx).Where("contest_id = ? AND status IN ?", contestID, domain.ActiveRegistrationStatuses).Find(&registrations).Error
+	if err != nil {
+		return nil, err
+	}
+	return registrations, nil
+}
+
+func (r *contestRegisterRepository) StartContestWindow(ctx context.Context, userID string, contestID string, startedAt time.Time, endsAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&domain.ContestRegistration{}).
+		Where("user_id = ? AND contest_id = ? AND started_at IS NULL", userID, contestID).
+		Updates(map[string]interface{}{"started_at": startedAt, "ends_at": endsAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("contest window already started")
+	}
+	return nil
+}
+
+func (r *contestRegisterRepository) CountActiveParticipants(ctx context.Context, contestID string) (int64, error) {
+	var count int64
+	// Team members share the team ID, so a team is counted once
+	err := r.db.WithContext(ctx).Model(&domain.ContestRegistration{}).
+		Select("COUNT(DISTINCT COALESCE(team_id, id))").
+		Where("contest_id = ? AND status IN ?", contestID, domain.ActiveRegistrationStatuses).
+		Scan(&count).Error
+	return count, err
+}
+
+func (r *contestRegisterRepository) GetWaitlistedRegistrations(ctx context.Context, contestID string) ([]domain.ContestRegistration, error) {
+	var registrations []domain.ContestRegistration
+	err := r.db.WithContext(ctx).
+		Where("contest_id = ? AND status = ?", contestID, domain.RegistrationStatusWaitlisted).
+		Order("updated_at ASC").
+		Find(&registrations).Error
+	if err != nil {
+		return nil, err
+	}
+	return registrations, nil
+}
+
+func (r *contestRegisterRepository) GetDisqualifiedUserIDs(ctx context.Context, contestID string) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).
+		Model(&domain.ContestRegistration{}).
+		Where("contest_id = ? AND status = ?", contestID, domain.RegistrationStatusDisqualified).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type contestRepository struct {
@@ -32,6 +33,15 @@ func (r *contestRepository) GetByID(ctx context.Context, id string) (*domain.Con
 	return &contest, nil
 }
 
+func (r *contestRepository) LockContest(ctx context.Context, id string) (*domain.Contest, error) {
+	var contest domain.Contest
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&contest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contest, nil
+}
+
 func (r *contestRepository) UpdateContest(ctx context.Context, contest *domain.Contest) error {
 	return r.db.WithContext(ctx).Save(contest).Error
 }
@@ -59,3 +69,54 @@ func (r *contestRepository) GetAllContests(ctx context.Context) ([]domain.Contes
 	}
 	return contests, nil
 }
+
+func (r *contestRepository) AddContestProblem(ctx context.Context, contestProblem *domain.ContestProblem) error {
+	return r.db.WithContext(ctx).Create(contestProblem).Error
+}
+
+func (r *contestRepository) RemoveContestProblem(ctx context.Context, contestID string, problemID string) error {
+	return r.db.WithContext(ctx).Where("contest_id = ? AND problem_id = ?", contestID, problemID).Delete(&domain.ContestProblem{}).Error
+}
+
+func (r *contestRepository) GetContestProblems(ctx context.Context, contestID string) ([]domain.ContestProblem, error) {
+	var contestProblems []domain.ContestProblem
+	err := r.db.WithContext(ctx).Where("contest_id = ?", contestID).Order("problem_index ASC").Find(&contestProblems).Error
+	if err != nil {
+		return nil, err
+	}
+	return contestProblems, nil
+}
+
+func (r *contestRepository) GetContestProblemsByProblemID(ctx context.Context, problemID string) ([]domain.ContestProblem, error) {
+	var contestProblems []domain.ContestProblem
+	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Find(&contestProblems).Error
+	if err != nil {
+		return nil, err
+	}
+	return contestProblems, nil
+}
+
+func (r *contestRepository) GetContestsBySeriesID(ctx context.Context, seriesID string) ([]domain.Contest, error) {
+	var contests []domain.Contest
+	err := r.db.WithContext(ctx).Where("series_id = ?", seriesID).Order("start_time ASC").Find(&contests).Error
+	if err != nil {
+		return nil, err
+	}
+	return contests, nil
+}
+
+func (r *contestRepository) GetContestsPendingPlagiarismCheck(ctx context.Context, endedBefore time.Time) ([]domain.Contest, error) {
+	var contests []domain.Contest
+	err := r.db.WithContext(ctx).
+		Where("end_time < ? AND plagiarism_checked_at IS NULL", endedBefore).
+		Order("end_time ASC").
+		Find(&contests).Error
+	if err != nil {
+		return nil, err
+	}
+	return contests, nil
+}
+
+func (r *contestRepository) SetPlagiarismCheckedAt(ctx context.Context, contestID string, checkedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.Contest{}).Where("id = ?", contestID).Update("plagiarism_checked_at", checkedAt).Error
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type contestSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewContestSeriesRepository(db *gorm.DB) domain.ContestSeriesRepository {
+	return &contestSeriesRepository{
+		db: db,
+	}
+}
+
+func (r *contestSeriesRepository) CreateSeries(ctx context.Context, series *domain.ContestSeries) error {
+	return r.db.WithContext(ctx).Create(series).Error
+}
+
+func (r *contestSeriesRepository) GetSeriesByID(ctx context.Context, id string) (*domain.ContestSeries, error) {
+	var series domain.ContestSeries
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&series).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *contestSeriesRepository) GetAllSeries(ctx context.Context) ([]domain.ContestSeries, error) {
+	var series []domain.ContestSeries
+	err := r.db.WithContext(ctx).Order("created_at DESC").Find(&series).Error
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (r *contestSeriesRepository) UpdateSeries(ctx context.Context, series *domain.ContestSeries) error {
+	return r.db.WithContext(ctx).Save(series).Error
+}
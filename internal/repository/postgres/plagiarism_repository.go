@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type plagiarismRepository struct {
+	db *gorm.DB
+}
+
+func NewPlagiarismRepository(db *gorm.DB) domain.PlagiarismRepository {
+	return &plagiarismRepository{
+		db: db,
+	}
+}
+
+func (r *plagiarismRepository) CreateCase(ctx context.Context, plagiarismCase *domain.PlagiarismCase) error {
+	// Checks can run again on the same contest, reviewed cases must be kept
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "submission_a_id"}, {Name: "submission_b_id"}},
+			DoNothing: true,
+		}).
+		Create(plagiarismCase).Error
+}
+
+func (r *plagiarismRepository) GetCaseByID(ctx context.Context, id string) (*domain.PlagiarismCase, error) {
+	var plagiarismCase domain.PlagiarismCase
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&plagiarismCase).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plagiarismCase, nil
+}
+
+func (r *plagiarismRepository) GetContestCases(ctx context.Context, contestID string, status string) ([]domain.PlagiarismCase, error) {
+	var cases []domain.PlagiarismCase
+	query := r.db.WithContext(ctx).Where("contest_id = ?", contestID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("similarity DESC").Find(&cases).Error
+	if err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
+
+func (r *plagiarismRepository) UpdateCase(ctx context.Context, plagiarismCase *domain.PlagiarismCase) error {
+	return r.db.WithContext(ctx).Save(plagiarismCase).Error
+}
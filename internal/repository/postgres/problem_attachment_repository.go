@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type problemAttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewProblemAttachmentRepository(db *gorm.DB) domain.ProblemAttachmentRepository {
+	return &problemAttachmentRepository{
+		db: db,
+	}
+}
+
+func (r *problemAttachmentRepository) CreateAttachment(ctx context.Context, attachment *domain.ProblemAttachment) error {
+	return r.db.WithContext(ctx).Create(attachment).Error
+}
+
+func (r *problemAttachmentRepository) GetAttachment(ctx context.Context, problemID string, fileName string) (*domain.ProblemAttachment, error) {
+	var attachment domain.ProblemAttachment
+	err := r.db.WithContext(ctx).Where("problem_id = ? AND file_name = ?", problemID, fileName).First(&attachment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *problemAttachmentRepository) GetAttachments(ctx context.Context, problemID string) ([]domain.ProblemAttachment, error) {
+	var attachments []domain.ProblemAttachment
+	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Order("file_name ASC").Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *problemAttachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&domain.ProblemAttachment{}, "id = ?", id).Error
+}
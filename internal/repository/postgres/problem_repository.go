@@ -3,8 +3,12 @@ package postgres
 import (
 	"algoforces/internal/domain"
 	"context"
+	"errors"
+	"time"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type problemRepository struct {
@@ -30,6 +34,15 @@ func (r *problemRepository) GetProblemByID(ctx context.Context, id string) (*dom
 	return &problem, nil
 }
 
+func (r *problemRepository) LockProblem(ctx context.Context, id string) (*domain.Problem, error) {
+	var problem domain.Problem
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("unique_id = ?", id).Take(&problem).Error
+	if err != nil {
+		return nil, err
+	}
+	return &problem, nil
+}
+
 func (r *problemRepository) UpdateProblem(ctx context.Context, problem *domain.Problem) error {
 	return r.db.WithContext(ctx).Save(problem).Error
 }
@@ -38,11 +51,141 @@ func (r *problemRepository) DeleteProblem(ctx context.Context, id string) error
 	return r.db.WithContext(ctx).Where("unique_id = ?", id).Delete(&domain.Problem{}).Error
 }
 
-func (r *problemRepository) GetAllProblems(ctx context.Context) ([]domain.Problem, error) {
+func (r *problemRepository) SearchProblems(ctx context.Context, filter *domain.ProblemFilter) ([]domain.Problem, int64, error) {
+	sortBy := filter.SortBy
+	if sortBy == "" {
+		sortBy = domain.ProblemSortCreatedAt
+		if filter.Search != "" {
+			sortBy = domain.ProblemSortRelevance
+		}
+	}
+	if sortBy == domain.ProblemSortRelevance && filter.Search == "" {
+		return nil, 0, errors.New("sorting by relevance requires a search query")
+	}
+
+	query := r.db.WithContext(ctx).Model(&domain.Problem{})
+
+	if filter.Search != "" {
+		query = query.Where("search_vector @@ websearch_to_tsquery('english', ?)", filter.Search)
+	}
+	if len(filter.Tags) > 0 {
+		query = query.Where("tags @> ?", pq.StringArray(filter.Tags))
+	}
+	if filter.Difficulty != "" {
+		query = query.Where("difficulty = ?", filter.Difficulty)
+	}
+	if filter.MinRating > 0 {
+		query = query.Where("rating >= ?", filter.MinRating)
+	}
+	if filter.MaxRating > 0 {
+		query = query.Where("rating <= ?", filter.MaxRating)
+	}
+	if filter.RestrictVisibility {
+		query = query.Where(r.visibleTo(filter.ViewerID))
+	}
+
+	// The count and the page are two queries built from the same conditions
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	direction := "DESC"
+	if filter.Order == "asc" {
+		direction = "ASC"
+	}
+	switch sortBy {
+	case domain.ProblemSortRelevance:
+		query = query.Clauses(clause.OrderBy{
+			Expression: clause.Expr{
+				SQL:  "ts_rank(search_vector, websearch_to_tsquery('english', ?)) " + direction,
+				Vars: []interface{}{filter.Search},
+			},
+		})
+	case domain.ProblemSortRating, domain.ProblemSortTitle, domain.ProblemSortCreatedAt:
+		query = query.Order(sortBy + " " + direction)
+	}
+	// Stable pages for equal sort keys
+	query = query.Order("unique_id ASC")
+
+	page, pageSize := filter.Page, filter.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = domain.DefaultProblemPageSize
+	}
+
 	var problems []domain.Problem
-	err := r.db.WithContext(ctx).Order("created_at DESC").Find(&problems).Error
+	err = query.Offset((page - 1) * pageSize).Limit(pageSize).Find(&problems).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return problems, total, nil
+}
+
+func (r *problemRepository) GetAllTags(ctx context.Context, viewerID string) ([]domain.TagCount, error) {
+	problems := r.db.WithContext(ctx).Model(&domain.Problem{})
+	if viewerID != "" {
+		problems = problems.Where(r.visibleTo(viewerID))
+	}
+
+	var tags []domain.TagCount
+	err := problems.
+		Select("tag, COUNT(*) AS count").
+		Joins("CROSS JOIN unnest(tags) AS tag").
+		Group("tag").
+		Order("count DESC, tag ASC").
+		Scan(&tags).Error
 	if err != nil {
 		return nil, err
 	}
-	return problems, nil
+	return tags, nil
+}
+
+// visibleTo is the rule of problemAccess.canView for a user who is not an
+// admin: authored problems, public problems and contest problems of a contest
+// whose problems the user can see, as contestAccess.canSeeProblems decides
+func (r *problemRepository) visibleTo(viewerID string) *gorm.DB {
+	return r.db.Where("created_by = @viewer OR @viewer = ANY(co_authors) OR visibility = @public", map[string]interface{}{
+		"viewer": viewerID,
+		"public": domain.ProblemVisibilityPublic,
+	}).Or(`visibility = @contest AND EXISTS (
+		SELECT 1 FROM contest_problems cp JOIN contests c ON c.id = cp.contest_id
+		WHERE cp.problem_id = problems.unique_id AND (
+			c.created_by::text = @viewer OR @viewer = ANY(c.problem_setters)
+			OR (c.start_time <= NOW() AND c.visible AND (
+				c.visibility <> @private
+				OR EXISTS (
+					SELECT 1 FROM contest_registrations cr
+					WHERE cr.contest_id = c.id AND cr.user_id::text = @viewer AND cr.status IN @active
+				)
+				OR EXISTS (
+					SELECT 1 FROM users u
+					WHERE u.id::text = @viewer AND (
+						LOWER(u.email) IN (SELECT LOWER(e) FROM unnest(c.allowed_emails) AS e)
+						OR SUBSTRING(LOWER(u.email) FROM '@([^@]*)$') IN (SELECT LTRIM(LOWER(d), '@') FROM unnest(c.allowed_domains) AS d)
+					)
+				)
+			))
+		)
+	)`, map[string]interface{}{
+		"viewer":  viewerID,
+		"contest": domain.ProblemVisibilityContest,
+		"private": domain.ContestVisibilityPrivate,
+		"active":  []string{domain.RegistrationStatusRegistered, domain.RegistrationStatusApproved},
+	})
+}
+
+func (r *problemRepository) PublishEndedContestProblems(ctx context.Context, endedBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Problem{}).
+		Where("visibility = ?", domain.ProblemVisibilityContest).
+		Where("EXISTS (SELECT 1 FROM contest_problems cp WHERE cp.problem_id = problems.unique_id)").
+		Where("NOT EXISTS (SELECT 1 FROM contest_problems cp JOIN contests c ON c.id = cp.contest_id WHERE cp.problem_id = problems.unique_id AND c.end_time >= ?)", endedBefore).
+		Update("visibility", domain.ProblemVisibilityPublic)
+	return result.RowsAffected, result.Error
 }
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type problemRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewProblemRevisionRepository(db *gorm.DB) domain.ProblemRevisionRepository {
+	return &problemRevisionRepository{
+		db: db,
+	}
+}
+
+func (r *problemRevisionRepository) CreateNextRevision(ctx context.Context, revision *domain.ProblemRevision, unchanged func(latest *domain.ProblemRevision) bool) (*domain.ProblemRevision, error) {
+	result := revision
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The problem row serializes the numbering of its revisions
+		var problem domain.Problem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("unique_id").
+			Where("unique_id = ?", revision.ProblemID).
+			Take(&problem).Error
+		if err != nil {
+			return err
+		}
+
+		var latest domain.ProblemRevision
+		err = tx.Where("problem_id = ?", revision.ProblemID).Order("number DESC").Take(&latest).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			revision.Number = 1
+		case err != nil:
+			return err
+		case unchanged(&latest):
+			result = &latest
+			return nil
+		default:
+			revision.Number = latest.Number + 1
+		}
+		return tx.Create(revision).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *problemRevisionRepository) GetRevision(ctx context.Context, problemID string, number int) (*domain.ProblemRevision, error) {
+	var revision domain.ProblemRevision
+	err := r.db.WithContext(ctx).Where("problem_id = ? AND number = ?", problemID, number).First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *problemRevisionRepository) GetRevisionByID(ctx context.Context, id string) (*domain.ProblemRevision, error) {
+	var revision domain.ProblemRevision
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *problemRevisionRepository) GetLatestRevision(ctx context.Context, problemID string) (*domain.ProblemRevision, error) {
+	var revision domain.ProblemRevision
+	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Order("number DESC").First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *problemRevisionRepository) GetRevisions(ctx context.Context, problemID string) ([]domain.ProblemRevision, error) {
+	var revisions []domain.ProblemRevision
+	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Order("number DESC").Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *problemRevisionRepository) GetRevisionTests(ctx context.Context, revisionID string) ([]domain.ProblemRevisionTest, error) {
+	var tests []domain.ProblemRevisionTest
+	err := r.db.WithContext(ctx).Where("revision_id = ?", revisionID).Order("order_position ASC").Find(&tests).Error
+	if err != nil {
+		return nil, err
+	}
+	return tests, nil
+}
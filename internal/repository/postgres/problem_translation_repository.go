@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type problemTranslationRepository struct {
+	db *gorm.DB
+}
+
+func NewProblemTranslationRepository(db *gorm.DB) domain.ProblemTranslationRepository {
+	return &problemTranslationRepository{
+		db: db,
+	}
+}
+
+func (r *problemTranslationRepository) SaveTranslation(ctx context.Context, translation *domain.ProblemTranslation) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "problem_id"}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns([]string{"title", "statement", "input_format", "output_format", "constraints", "notes", "updated_by", "updated_at"}),
+		}).
+		Create(translation).Error
+}
+
+func (r *problemTranslationRepository) GetTranslations(ctx context.Context, problemID string) ([]domain.ProblemTranslation, error) {
+	var translations []domain.ProblemTranslation
+	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Order("locale ASC").Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+func (r *problemTranslationRepository) GetTranslationsForProblems(ctx context.Context, problemIDs []string, locales []string) ([]domain.ProblemTranslation, error) {
+	var translations []domain.ProblemTranslation
+	if len(problemIDs) == 0 || len(locales) == 0 {
+		return translations, nil
+	}
+	err := r.db.WithContext(ctx).Where("problem_id IN ? AND locale IN ?", problemIDs, locales).Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+func (r *problemTranslationRepository) DeleteTranslation(ctx context.Context, problemID string, locale string) (int64, error) {
+	result := r.db.WithContext(ctx).Delete(&domain.ProblemTranslation{}, "problem_id = ? AND locale = ?", problemID, locale)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// MigrateProblemVisibility adds the visibility column to problems created
+// before it existed. Problems of contests that have not ended become
+// contest-only so they stay hidden until they are published, the others
+// public. It runs before AutoMigrate and does nothing once the column exists.
+func MigrateProblemVisibility(ctx context.Context, db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&domain.Problem{}) || migrator.HasColumn(&domain.Problem{}, "visibility") {
+		return nil
+	}
+
+	// The column and the embargo are added together, a run stopping between
+	// them would leave contest problems public
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Migrator().AddColumn(&domain.Problem{}, "Visibility")
+		if err != nil {
+			return err
+		}
+		if !tx.Migrator().HasTable(&domain.ContestProblem{}) {
+			return nil
+		}
+
+		result := tx.Exec(`
+			UPDATE problems SET visibility = ?
+			WHERE EXISTS (
+				SELECT 1 FROM contest_problems cp JOIN contests c ON c.id = cp.contest_id
+				WHERE cp.problem_id = problems.unique_id AND c.end_time >= NOW()
+			)`, domain.ProblemVisibilityContest)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("Made %d problems of unfinished contests contest-only", result.RowsAffected)
+		}
+		return nil
+	})
+}
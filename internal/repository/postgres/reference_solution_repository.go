@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type referenceSolutionRepository struct {
+	db *gorm.DB
+}
+
+func NewReferenceSolutionRepository(db *gorm.DB) domain.ReferenceSolutionRepository {
+	return &referenceSolutionRepository{
+		db: db,
+	}
+}
+
+func (r *referenceSolutionRepository) CreateSolution(ctx context.Context, solution *domain.ReferenceSolution) error {
+	return r.db.WithContext(ctx).Create(solution).Error
+}
+
+func (r *referenceSolutionRepository) GetSolution(ctx context.Context, id string) (*domain.ReferenceSolution, error) {
+	var solution domain.ReferenceSolution
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&solution).Error
+	if err != nil {
+		return nil, err
+	}
+	return &solution, nil
+}
+
+func (r *referenceSolutionRepository) GetSolutions(ctx context.Context, problemID string) ([]domain.ReferenceSolution, error) {
+	var solutions []domain.ReferenceSolution
+	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Order("created_at ASC").Find(&solutions).Error
+	if err != nil {
+		return nil, err
+	}
+	return solutions, nil
+}
+
+func (r *referenceSolutionRepository) DeleteSolution(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&domain.ReferenceSolution{}).Error
+}
+
+func (r *referenceSolutionRepository) StartRun(ctx context.Context, id string, runID string) error {
+	// A map so the zero values are written too
+	return r.db.WithContext(ctx).Model(&domain.ReferenceSolution{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"run_id":              runID,
+			"status":              domain.ReferenceRunPending,
+			"problem_revision_id": nil,
+			"verdict":             "",
+			"test_cases_passed":   0,
+			"total_test_cases":    0,
+			"max_time_in_ms":      0,
+			"max_memory_in_kb":    0,
+			"test_case_results":   nil,
+			"expectation_met":     nil,
+			"judged_at":           nil,
+		}).Error
+}
+
+func (r *referenceSolutionRepository) UpdateRunStatus(ctx context.Context, id string, runID string, status string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.ReferenceSolution{}).
+		Where("id = ? AND run_id = ?", id, runID).
+		Update("status", status)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *referenceSolutionRepository) SaveRunResult(ctx context.Context, solution *domain.ReferenceSolution) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.ReferenceSolution{}).
+		Where("id = ? AND run_id = ?", solution.ID, solution.RunID).
+		Updates(map[string]interface{}{
+			"status":              domain.ReferenceRunDone,
+			"problem_revision_id": solution.ProblemRevisionID,
+			"verdict":             solution.Verdict,
+			"test_cases_passed":   solution.TestCasesPassed,
+			"total_test_cases":    solution.TotalTestCases,
+			"max_time_in_ms":      solution.MaxTimeInMS,
+			"max_memory_in_kb":    solution.MaxMemoryInKB,
+			"test_case_results":   solution.TestCaseResults,
+			"expectation_met":     solution.ExpectationMet,
+			"judged_at":           solution.JudgedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
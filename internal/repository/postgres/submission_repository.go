@@ -18,6 +18,10 @@ func NewSubmissionRepository(db *gorm.DB) domain.SubmissionRepository {
 	}
 }
 
+// submittedDuringContest keeps the submissions made between the start and the
+// end of their contest, practice after the contest is left out
+const submittedDuringContest = "EXISTS (SELECT 1 FROM contests c WHERE c.id = submissions.contest_id AND submissions.submitted_at BETWEEN c.start_time AND c.end_time)"
+
 func (r *submissionRepository) CreateNewSubmission(ctx context.Context, submission *domain.Submission) error {
 	return r.db.WithContext(ctx).Create(submission).Error
 }
@@ -39,7 +43,7 @@ func (r *submissionRepository) UpdateSubmissionStatus(ctx context.Context, submi
 
 func (r *submissionRepository) GetAllTestCasesForProblem(ctx context.Context, problemID string) ([]domain.TestCase, error) {
 	var testCases []domain.TestCase
-	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Find(&testCases).Error
+	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Order("order_position ASC").Find(&testCases).Error
 	if err != nil {
 		return nil, err
 	}
@@ -49,3 +53,70 @@ func (r *submissionRepository) GetAllTestCasesForProblem(ctx context.Context, pr
 func (r *submissionRepository) UpdateSubmissionResult(ctx context.Context, submissionID string, result *domain.Submission) error {
 	return r.db.WithContext(ctx).Model(&domain.Submission{}).Where("unique_id = ?", submissionID).Updates(result).Error
 }
+
+// GetContestSubmissions returns the submissions of a contest ordered by submission time.
+// The code column is skipped since standings only need the verdicts.
+func (r *submissionRepository) GetContestSubmissions(ctx context.Context, contestID string) ([]domain.Submission, error) {
+	var submissions []domain.Submission
+	err := r.db.WithContext(ctx).
+		Select("unique_id", "user_id", "contest_id", "problem_id", "language", "verdict", "submitted_at", "is_virtual", "team_id").
+		Where("contest_id = ?", contestID).
+		Order("submitted_at ASC").
+		Find(&submissions).Error
+	if err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// GetAcceptedProblemSubmissions returns the accepted official submissions of a
+// contest problem made during the contest with their code, oldest first
+func (r *submissionRepository) GetAcceptedProblemSubmissions(ctx context.Context, contestID string, problemID string) ([]domain.Submission, error) {
+	var submissions []domain.Submission
+	err := r.db.WithContext(ctx).
+		Where("contest_id = ? AND problem_id = ? AND verdict = ? AND is_virtual = ?", contestID, problemID, domain.VerdictAccepted, false).
+		Where(submittedDuringContest).
+		Order("submitted_at ASC").
+		Find(&submissions).Error
+	if err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// StreamContestSubmissions calls fn for every submission of a contest in
+// submission order without loading them all in memory
+func (r *submissionRepository) StreamContestSubmissions(ctx context.Context, contestID string, fn func(submission *domain.Submission) error) error {
+	rows, err := r.db.WithContext(ctx).
+		Model(&domain.Submission{}).
+		Select("unique_id", "user_id", "contest_id", "problem_id", "language", "verdict", "submitted_at", "is_virtual", "team_id").
+		Where("contest_id = ?", contestID).
+		Order("submitted_at ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var submission domain.Submission
+		err = r.db.ScanRows(rows, &submission)
+		if err != nil {
+			return err
+		}
+		err = fn(&submission)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *submissionRepository) CountOfficialSubmissions(ctx context.Context, userID string, contestID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Submission{}).
+		Where("user_id = ? AND contest_id = ? AND is_virtual = ?", userID, contestID, false).
+		Where(submittedDuringContest).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type teamRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamRepository(db *gorm.DB) domain.TeamRepository {
+	return &teamRepository{
+		db: db,
+	}
+}
+
+// CreateTeam creates the team together with its captain membership
+func (r *teamRepository) CreateTeam(ctx context.Context, team *domain.Team, captain *domain.TeamMember) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(team).Error; err != nil {
+			return err
+		}
+		return tx.Create(captain).Error
+	})
+}
+
+func (r *teamRepository) GetTeamByID(ctx context.Context, id string) (*domain.Team, error) {
+	var team domain.Team
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&team).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *teamRepository) GetTeamsByIDs(ctx context.Context, ids []string) ([]domain.Team, error) {
+	var teams []domain.Team
+	if len(ids) == 0 {
+		return teams, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&teams).Error
+	return teams, err
+}
+
+func (r *teamRepository) GetTeamByInviteCode(ctx context.Context, inviteCode string) (*domain.Team, error) {
+	var team domain.Team
+	err := r.db.WithContext(ctx).Where("invite_code = ?", inviteCode).First(&team).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *teamRepository) GetTeamsByUserID(ctx context.Context, userID string) ([]domain.Team, error) {
+	var teams []domain.Team
+	err := r.db.WithContext(ctx).
+		Joins("JOIN team_members ON team_members.team_id = teams.id").
+		Where("team_members.user_id = ?", userID).
+		Order("teams.created_at DESC").
+		Find(&teams).Error
+	return teams, err
+}
+
+func (r *teamRepository) UpdateTeam(ctx context.Context, team *domain.Team) error {
+	return r.db.WithContext(ctx).Save(team).Error
+}
+
+// DeleteTeam removes the team and all of its memberships
+func (r *teamRepository) DeleteTeam(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("team_id = ?", id).Delete(&domain.TeamMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.Team{}).Error
+	})
+}
+
+func (r *teamRepository) AddTeamMember(ctx context.Context, member *domain.TeamMember) error {
+	return r.db.WithContext(ctx).Create(member).Error
+}
+
+func (r *teamRepository) RemoveTeamMember(ctx context.Context, teamID string, userID string) error {
+	return r.db.WithContext(ctx).Where("team_id = ? AND user_id = ?", teamID, userID).Delete(&domain.TeamMember{}).Error
+}
+
+func (r *teamRepository) GetTeamMembers(ctx context.Context, teamID string) ([]domain.TeamMember, error) {
+	var members []domain.TeamMember
+	err := r.db.WithContext(ctx).Where("team_id = ?", teamID).Order("joined_at ASC").Find(&members).Error
+	return members, err
+}
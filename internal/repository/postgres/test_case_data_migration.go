@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/storage"
+	"context"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// legacyTestCaseData is a test case row from before test data was moved to
+// the blob storage
+type legacyTestCaseData struct {
+	UniqueID       string
+	Input          string
+	ExpectedOutput string
+}
+
+// MigrateTestCaseData moves the input and expected output of test cases
+// stored in Postgres text columns to the blob storage, then drops the
+// columns. It does nothing once the columns are gone.
+func MigrateTestCaseData(ctx context.Context, db *gorm.DB, testData *storage.ContentStore) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&domain.TestCase{}, "input") {
+		// A previous run may have stopped between dropping the two columns
+		if migrator.HasColumn(&domain.TestCase{}, "expected_output") {
+			return migrator.DropColumn(&domain.TestCase{}, "expected_output")
+		}
+		return nil
+	}
+
+	migrated := 0
+	for {
+		var rows []legacyTestCaseData
+		err := db.WithContext(ctx).Model(&domain.TestCase{}).
+			Select("unique_id, input, expected_output").
+			Where("input_hash IS NULL OR input_hash = ''").
+			Limit(100).
+			Find(&rows).Error
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			inputHash, err := testData.Put(ctx, []byte(row.Input))
+			if err != nil {
+				return err
+			}
+			outputHash, err := testData.Put(ctx, []byte(row.ExpectedOutput))
+			if err != nil {
+				return err
+			}
+
+			err = db.WithContext(ctx).Model(&domain.TestCase{}).
+				Where("unique_id = ?", row.UniqueID).
+				Updates(map[string]interface{}{
+					"input_hash":  inputHash,
+					"output_hash": outputHash,
+					"input_size":  len(row.Input),
+					"output_size": len(row.ExpectedOutput),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		migrated += len(rows)
+	}
+	log.Printf("Moved the data of %d test cases to the blob storage", migrated)
+
+	err := migrator.DropColumn(&domain.TestCase{}, "input")
+	if err != nil {
+		return err
+	}
+	return migrator.DropColumn(&domain.TestCase{}, "expected_output")
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// MigrateTestCaseOrder renumbers the test cases of problems where several
+// share an order position, keeping their judging order, so the unique index
+// on positions can be created. It runs before AutoMigrate and does nothing
+// once the index exists.
+func MigrateTestCaseOrder(ctx context.Context, db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&domain.TestCase{}) || migrator.HasIndex(&domain.TestCase{}, "idx_test_case_order") {
+		return nil
+	}
+
+	result := db.WithContext(ctx).Exec(`
+		UPDATE test_cases SET order_position = numbered.position
+		FROM (
+			SELECT unique_id, ROW_NUMBER() OVER (PARTITION BY problem_id ORDER BY order_position, unique_id) AS position
+			FROM test_cases
+			WHERE problem_id IN (
+				SELECT problem_id FROM test_cases GROUP BY problem_id, order_position HAVING COUNT(*) > 1
+			)
+		) AS numbered
+		WHERE test_cases.unique_id = numbered.unique_id`)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Renumbered %d test cases sharing an order position", result.RowsAffected)
+	}
+	return nil
+}
@@ -2,26 +2,38 @@ package postgres
 
 import (
 	"algoforces/internal/domain"
+	"algoforces/pkg/storage"
 	"context"
+	"fmt"
 
 	"gorm.io/gorm"
 )
 
 type testCaseRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	testData *storage.ContentStore
 }
 
-func NewTestCaseRepository(db *gorm.DB) *testCaseRepository {
+func NewTestCaseRepository(db *gorm.DB, testData *storage.ContentStore) *testCaseRepository {
 	return &testCaseRepository{
-		db: db,
+		db:       db,
+		testData: testData,
 	}
 }
 
 func (r *testCaseRepository) CreateTestCase(ctx context.Context, testCase *domain.TestCase) error {
+	err := r.storeData(ctx, testCase)
+	if err != nil {
+		return err
+	}
 	return r.db.WithContext(ctx).Create(testCase).Error
 }
 
 func (r *testCaseRepository) UpdateTestCase(ctx context.Context, testCase *domain.TestCase) error {
+	err := r.storeData(ctx, testCase)
+	if err != nil {
+		return err
+	}
 	return r.db.WithContext(ctx).Save(testCase).Error
 }
 
@@ -35,6 +47,12 @@ func (r *testCaseRepository) GetTestCasesByProblemID(ctx context.Context, proble
 	if err != nil {
 		return nil, err
 	}
+	for _, testCase := range testCases {
+		err = r.loadData(ctx, testCase)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return testCases, nil
 }
 
@@ -44,5 +62,139 @@ func (r *testCaseRepository) GetTestCaseByUniqueID(ctx context.Context, uniqueID
 	if err != nil {
 		return nil, err
 	}
+	err = r.loadData(ctx, &testCase)
+	if err != nil {
+		return nil, err
+	}
 	return &testCase, nil
 }
+
+func (r *testCaseRepository) UpdateValidationStatus(ctx context.Context, uniqueID string, status string, message string) error {
+	return r.db.WithContext(ctx).Model(&domain.TestCase{}).
+		Where("unique_id = ?", uniqueID).
+		Updates(map[string]interface{}{"validation_status": status, "validation_message": message}).Error
+}
+
+func (r *testCaseRepository) ResetValidationStatus(ctx context.Context, problemID string) error {
+	return r.db.WithContext(ctx).Model(&domain.TestCase{}).
+		Where("problem_id = ?", problemID).
+		Updates(map[string]interface{}{"validation_status": domain.TestValidationUnchecked, "validation_message": ""}).Error
+}
+
+func (r *testCaseRepository) DeleteGeneratedTestCases(ctx context.Context, problemID string) error {
+	return r.db.WithContext(ctx).Where("problem_id = ? AND generator <> ''", problemID).Delete(&domain.TestCase{}).Error
+}
+
+func (r *testCaseRepository) CreateTestCases(ctx context.Context, testCases []*domain.TestCase) error {
+	// Blobs are written first, content no row references is harmless
+	for _, testCase := range testCases {
+		err := r.storeData(ctx, testCase)
+		if err != nil {
+			return err
+		}
+	}
+	if len(testCases) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(testCases).Error
+	})
+}
+
+func (r *testCaseRepository) ReplaceTestCases(ctx context.Context, problemID string, testCases []*domain.TestCase) error {
+	for _, testCase := range testCases {
+		err := r.storeData(ctx, testCase)
+		if err != nil {
+			return err
+		}
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("problem_id = ?", problemID).Delete(&domain.TestCase{}).Error
+		if err != nil {
+			return err
+		}
+		if len(testCases) == 0 {
+			return nil
+		}
+		return tx.Create(testCases).Error
+	})
+}
+
+func (r *testCaseRepository) GetTestCaseHashes(ctx context.Context, problemID string) ([]domain.TestCase, error) {
+	var testCases []domain.TestCase
+	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Order("order_position ASC").Find(&testCases).Error
+	if err != nil {
+		return nil, err
+	}
+	return testCases, nil
+}
+
+func (r *testCaseRepository) GetTestCasePositions(ctx context.Context, problemID string) (map[string]int, error) {
+	var testCases []domain.TestCase
+	err := r.db.WithContext(ctx).Select("unique_id, order_position").Where("problem_id = ?", problemID).Find(&testCases).Error
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[string]int, len(testCases))
+	for _, testCase := range testCases {
+		positions[testCase.UniqueID] = testCase.OrderPosition
+	}
+	return positions, nil
+}
+
+func (r *testCaseRepository) ReorderTestCases(ctx context.Context, problemID string, testCaseIDs []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Positions are unique, the test cases are moved out of the way first
+		err := tx.Model(&domain.TestCase{}).
+			Where("problem_id = ?", problemID).
+			Update("order_position", gorm.Expr("-order_position")).Error
+		if err != nil {
+			return err
+		}
+		for i, testCaseID := range testCaseIDs {
+			err = tx.Model(&domain.TestCase{}).
+				Where("problem_id = ? AND unique_id = ?", problemID, testCaseID).
+				Update("order_position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// storeData writes the input and expected output of a test case to the blob
+// storage and sets their hashes
+func (r *testCaseRepository) storeData(ctx context.Context, testCase *domain.TestCase) error {
+	inputHash, err := r.testData.Put(ctx, []byte(testCase.Input))
+	if err != nil {
+		return fmt.Errorf("failed to store test input: %w", err)
+	}
+	outputHash, err := r.testData.Put(ctx, []byte(testCase.ExpectedOutput))
+	if err != nil {
+		return fmt.Errorf("failed to store test output: %w", err)
+	}
+
+	testCase.InputHash = inputHash
+	testCase.OutputHash = outputHash
+	testCase.InputSize = int64(len(testCase.Input))
+	testCase.OutputSize = int64(len(testCase.ExpectedOutput))
+	return nil
+}
+
+// loadData reads the input and expected output of a test case from the blob
+// storage
+func (r *testCaseRepository) loadData(ctx context.Context, testCase *domain.TestCase) error {
+	input, err := r.testData.Get(ctx, testCase.InputHash)
+	if err != nil {
+		return fmt.Errorf("failed to load input of test case %s: %w", testCase.UniqueID, err)
+	}
+	output, err := r.testData.Get(ctx, testCase.OutputHash)
+	if err != nil {
+		return fmt.Errorf("failed to load output of test case %s: %w", testCase.UniqueID, err)
+	}
+
+	testCase.Input = string(input)
+	testCase.ExpectedOutput = string(output)
+	return nil
+}
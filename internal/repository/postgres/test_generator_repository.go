@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type testGeneratorRepository struct {
+	db *gorm.DB
+}
+
+func NewTestGeneratorRepository(db *gorm.DB) domain.TestGeneratorRepository {
+	return &testGeneratorRepository{
+		db: db,
+	}
+}
+
+func (r *testGeneratorRepository) SaveGenerator(ctx context.Context, generator *domain.ProblemGenerator) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "problem_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"language", "source", "updated_at"}),
+		}).
+		Create(generator).Error
+}
+
+func (r *testGeneratorRepository) GetGenerators(ctx context.Context, problemID string) ([]domain.ProblemGenerator, error) {
+	var generators []domain.ProblemGenerator
+	err := r.db.WithContext(ctx).Where("problem_id = ?", problemID).Order("name ASC").Find(&generators).Error
+	if err != nil {
+		return nil, err
+	}
+	return generators, nil
+}
+
+func (r *testGeneratorRepository) DeleteGenerator(ctx context.Context, problemID string, name string) (int64, error) {
+	result := r.db.WithContext(ctx).Where("problem_id = ? AND name = ?", problemID, name).Delete(&domain.ProblemGenerator{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *testGeneratorRepository) CreateGeneration(ctx context.Context, generation *domain.TestGeneration) error {
+	return r.db.WithContext(ctx).Create(generation).Error
+}
+
+func (r *testGeneratorRepository) GetGeneration(ctx context.Context, id string) (*domain.TestGeneration, error) {
+	var generation domain.TestGeneration
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&generation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &generation, nil
+}
+
+func (r *testGeneratorRepository) UpdateGeneration(ctx context.Context, generation *domain.TestGeneration) error {
+	return r.db.WithContext(ctx).Save(generation).Error
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/storage"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type unitOfWork struct {
+	db       *gorm.DB
+	testData *storage.ContentStore
+}
+
+func NewUnitOfWork(db *gorm.DB, testData *storage.ContentStore) domain.UnitOfWork {
+	return &unitOfWork{
+		db:       db,
+		testData: testData,
+	}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(tx domain.Transaction) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&transaction{db: tx, testData: u.testData})
+	})
+}
+
+// transaction builds repositories on a transaction. Transactions the
+// repositories open themselves become savepoints of it.
+type transaction struct {
+	db       *gorm.DB
+	testData *storage.ContentStore
+}
+
+func (t *transaction) Problems() domain.ProblemRepository {
+	return NewProblemRepository(t.db)
+}
+
+func (t *transaction) TestCases() domain.TestCaseRepository {
+	return NewTestCaseRepository(t.db, t.testData)
+}
+
+func (t *transaction) Revisions() domain.ProblemRevisionRepository {
+	return NewProblemRevisionRepository(t.db)
+}
+
+func (t *transaction) Contests() domain.ContestRepository {
+	return NewContestRepository(t.db)
+}
+
+func (t *transaction) Registrations() domain.ContestRegisterRepository {
+	return NewContestRegisterRepository(t.db)
+}
+
+func (t *transaction) PlagiarismCases() domain.PlagiarismRepository {
+	return NewPlagiarismRepository(t.db)
+}
@@ -46,6 +46,15 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 	return &user, nil
 }
 
+func (r *userRepository) GetByIDs(ctx context.Context, ids []string) ([]domain.User, error) {
+	var users []domain.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
 func (r *userRepository) UpdateByID(ctx context.Context, id string, user *domain.User) error {
 	fmt.Printf("DEBUG: Repository UpdateByID called for user ID: %s\n", id)
 	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", id).Updates(user).Error
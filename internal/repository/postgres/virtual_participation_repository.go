@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"algoforces/internal/domain"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type virtualParticipationRepository struct {
+	db *gorm.DB
+}
+
+func NewVirtualParticipationRepository(db *gorm.DB) domain.VirtualParticipationRepository {
+	return &virtualParticipationRepository{
+		db: db,
+	}
+}
+
+func (r *virtualParticipationRepository) CreateVirtualParticipation(ctx context.Context, participation *domain.VirtualParticipation) error {
+	return r.db.WithContext(ctx).Create(participation).Error
+}
+
+func (r *virtualParticipationRepository) GetVirtualParticipation(ctx context.Context, userID string, contestID string) (*domain.VirtualParticipation, error) {
+	var participation domain.VirtualParticipation
+	err := r.db.WithContext(ctx).Where("user_id = ? AND contest_id = ?", userID, contestID).First(&participation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &participation, nil
+}
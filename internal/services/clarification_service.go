@@ -0,0 +1,325 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// clarificationEventBuffer is the number of events a slow subscriber can lag
+// behind before further events are dropped for it
+const clarificationEventBuffer = 16
+
+type clarificationService struct {
+	clarificationRepo   domain.ClarificationRepository
+	contestRepo         domain.ContestRepository
+	contestRegisterRepo domain.ContestRegisterRepository
+	access              *contestAccess
+	hub                 *clarificationHub
+}
+
+func NewClarificationService(clarificationRepo domain.ClarificationRepository, contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, userRepo domain.UserRepository) domain.ClarificationUseCase {
+	return &clarificationService{
+		clarificationRepo:   clarificationRepo,
+		contestRepo:         contestRepo,
+		contestRegisterRepo: contestRegisterRepo,
+		access:              newContestAccess(userRepo, contestRegisterRepo),
+		hub:                 newClarificationHub(),
+	}
+}
+
+func (s *clarificationService) AskClarification(ctx context.Context, userID string, contestID string, req *domain.AskClarificationRequest) (*domain.ClarificationResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	now := time.Now()
+	if now.Before(contest.StartTime) || now.After(contest.EndTime) {
+		return nil, errors.New("clarifications can only be asked while the contest is running")
+	}
+
+	registration, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, userID, contestID)
+	if err != nil || !registration.IsActive() {
+		return nil, errors.New("user is not registered for this contest")
+	}
+
+	if req.ProblemID != nil {
+		err = s.checkContestProblem(ctx, contestID, *req.ProblemID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	clarification := &domain.Clarification{
+		ID:        uuid.New().String(),
+		ContestID: contestID,
+		ProblemID: req.ProblemID,
+		AskedBy:   userID,
+		Question:  req.Question,
+	}
+
+	err = s.clarificationRepo.CreateClarification(ctx, clarification)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toClarificationResponse(clarification)
+	s.hub.publish(contestID, userID, domain.ClarificationEvent{
+		Type:          domain.ClarificationEventQuestion,
+		Clarification: *response,
+	})
+
+	return response, nil
+}
+
+func (s *clarificationService) AnswerClarification(ctx context.Context, userID string, role string, contestID string, clarificationID string, req *domain.AnswerClarificationRequest) (*domain.ClarificationResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	if !isContestStaff(contest, userID, role) {
+		return nil, errors.New("only the contest problem setters can answer clarifications")
+	}
+
+	clarification, err := s.clarificationRepo.GetClarificationByID(ctx, clarificationID)
+	if err != nil || clarification.ContestID != contestID {
+		return nil, errors.New("clarification not found")
+	}
+
+	if clarification.IsAnnouncement {
+		return nil, errors.New("announcements cannot be answered")
+	}
+
+	answeredAt := time.Now()
+	clarification.Answer = req.Answer
+	clarification.AnsweredBy = &userID
+	clarification.AnsweredAt = &answeredAt
+	clarification.IsPublic = req.IsPublic
+
+	err = s.clarificationRepo.UpdateClarification(ctx, clarification)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toClarificationResponse(clarification)
+	s.hub.publish(contestID, clarification.AskedBy, domain.ClarificationEvent{
+		Type:          domain.ClarificationEventAnswer,
+		Clarification: *response,
+	})
+
+	return response, nil
+}
+
+func (s *clarificationService) CreateAnnouncement(ctx context.Context, userID string, role string, contestID string, req *domain.CreateAnnouncementRequest) (*domain.ClarificationResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	if !isContestStaff(contest, userID, role) {
+		return nil, errors.New("only the contest problem setters can make announcements")
+	}
+
+	if req.ProblemID != nil {
+		err = s.checkContestProblem(ctx, contestID, *req.ProblemID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	clarification := &domain.Clarification{
+		ID:             uuid.New().String(),
+		ContestID:      contestID,
+		ProblemID:      req.ProblemID,
+		AskedBy:        userID,
+		Answer:         req.Message,
+		AnsweredBy:     &userID,
+		AnsweredAt:     &now,
+		IsPublic:       true,
+		IsAnnouncement: true,
+	}
+
+	err = s.clarificationRepo.CreateClarification(ctx, clarification)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toClarificationResponse(clarification)
+	s.hub.publish(contestID, userID, domain.ClarificationEvent{
+		Type:          domain.ClarificationEventAnnouncement,
+		Clarification: *response,
+	})
+
+	return response, nil
+}
+
+// GetClarifications returns every clarification of the contest to the staff,
+// and the public ones plus their own questions to participants
+func (s *clarificationService) GetClarifications(ctx context.Context, userID string, role string, contestID string) ([]domain.ClarificationResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	canView, err := s.access.canView(ctx, contest, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("contest not found")
+	}
+
+	var clarifications []domain.Clarification
+	if isContestStaff(contest, userID, role) {
+		clarifications, err = s.clarificationRepo.GetContestClarifications(ctx, contestID)
+	} else {
+		clarifications, err = s.clarificationRepo.GetUserClarifications(ctx, contestID, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []domain.ClarificationResponse{}
+	for i := range clarifications {
+		responses = append(responses, *toClarificationResponse(&clarifications[i]))
+	}
+
+	return responses, nil
+}
+
+func (s *clarificationService) Subscribe(ctx context.Context, userID string, role string, contestID string) (<-chan domain.ClarificationEvent, func(), error) {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return nil, nil, errors.New("contest not found")
+	}
+
+	isStaff := isContestStaff(contest, userID, role)
+	if !isStaff {
+		registration, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, userID, contestID)
+		if err != nil || !registration.IsActive() {
+			return nil, nil, errors.New("user is not registered for this contest")
+		}
+	}
+
+	events, unsubscribe := s.hub.subscribe(contestID, userID, isStaff)
+	return events, unsubscribe, nil
+}
+
+func (s *clarificationService) checkContestProblem(ctx context.Context, contestID string, problemID string) error {
+	contestProblems, err := s.contestRepo.GetContestProblems(ctx, contestID)
+	if err != nil {
+		return err
+	}
+
+	for _, contestProblem := range contestProblems {
+		if contestProblem.ProblemID == problemID {
+			return nil
+		}
+	}
+	return errors.New("problem is not part of this contest")
+}
+
+// isContestStaff reports whether the user can moderate the contest: admins,
+// the contest creator and its problem setters
+func isContestStaff(contest *domain.Contest, userID string, role string) bool {
+	if role == "admin" || contest.CreatedBy == userID {
+		return true
+	}
+	for _, problemSetter := range contest.ProblemSetters {
+		if problemSetter == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func toClarificationResponse(clarification *domain.Clarification) *domain.ClarificationResponse {
+	return &domain.ClarificationResponse{
+		ID:             clarification.ID,
+		ContestID:      clarification.ContestID,
+		ProblemID:      clarification.ProblemID,
+		AskedBy:        clarification.AskedBy,
+		Question:       clarification.Question,
+		Answer:         clarification.Answer,
+		AnsweredBy:     clarification.AnsweredBy,
+		AnsweredAt:     clarification.AnsweredAt,
+		IsPublic:       clarification.IsPublic,
+		IsAnnouncement: clarification.IsAnnouncement,
+		CreatedAt:      clarification.CreatedAt,
+	}
+}
+
+type clarificationSubscriber struct {
+	userID  string
+	isStaff bool
+	events  chan domain.ClarificationEvent
+}
+
+// clarificationHub fans clarification events out to the subscribers of a
+// contest. It lives in process, so subscribers only receive events published
+// by the same API instance.
+type clarificationHub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*clarificationSubscriber]struct{}
+}
+
+func newClarificationHub() *clarificationHub {
+	return &clarificationHub{
+		subscribers: map[string]map[*clarificationSubscriber]struct{}{},
+	}
+}
+
+func (h *clarificationHub) subscribe(contestID string, userID string, isStaff bool) (<-chan domain.ClarificationEvent, func()) {
+	subscriber := &clarificationSubscriber{
+		userID:  userID,
+		isStaff: isStaff,
+		events:  make(chan domain.ClarificationEvent, clarificationEventBuffer),
+	}
+
+	h.mu.Lock()
+	if h.subscribers[contestID] == nil {
+		h.subscribers[contestID] = map[*clarificationSubscriber]struct{}{}
+	}
+	h.subscribers[contestID][subscriber] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[contestID], subscriber)
+			if len(h.subscribers[contestID]) == 0 {
+				delete(h.subscribers, contestID)
+			}
+			h.mu.Unlock()
+			close(subscriber.events)
+		})
+	}
+
+	return subscriber.events, unsubscribe
+}
+
+// publish delivers the event to the staff, to the user it concerns and, when
+// the clarification is public, to every participant. Events are dropped for
+// subscribers whose buffer is full rather than blocking the publisher.
+func (h *clarificationHub) publish(contestID string, userID string, event domain.ClarificationEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for subscriber := range h.subscribers[contestID] {
+		if !subscriber.isStaff && !event.Clarification.IsPublic && subscriber.userID != userID {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// contestAccess decides who can see a contest, its problems and its standings
+type contestAccess struct {
+	userRepo            domain.UserRepository
+	contestRegisterRepo domain.ContestRegisterRepository
+}
+
+func newContestAccess(userRepo domain.UserRepository, contestRegisterRepo domain.ContestRegisterRepository) *contestAccess {
+	return &contestAccess{
+		userRepo:            userRepo,
+		contestRegisterRepo: contestRegisterRepo,
+	}
+}
+
+// canView reports whether the user can see the contest. The staff always can,
+// hidden contests are staff only and private contests require a registration
+// or a matching allowlist entry.
+func (a *contestAccess) canView(ctx context.Context, contest *domain.Contest, userID string, role string) (bool, error) {
+	if isContestStaff(contest, userID, role) {
+		return true, nil
+	}
+
+	if !contest.Visible {
+		return false, nil
+	}
+
+	if contest.Visibility != domain.ContestVisibilityPrivate {
+		return true, nil
+	}
+
+	registration, err := a.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, userID, contest.Id)
+	if err == nil && registration.IsActive() {
+		return true, nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	user, err := a.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false, errors.New("user not found")
+	}
+	return isAllowlisted(contest, user.Email), nil
+}
+
+// canList reports whether the contest shows up in the user's contest list,
+// unlisted contests only do once the user registered for them
+func (a *contestAccess) canList(ctx context.Context, contest *domain.Contest, userID string, role string) (bool, error) {
+	if contest.Visibility == domain.ContestVisibilityUnlisted && !isContestStaff(contest, userID, role) {
+		registration, err := a.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, userID, contest.Id)
+		if err != nil || !registration.IsActive() {
+			return false, nil
+		}
+	}
+	return a.canView(ctx, contest, userID, role)
+}
+
+// canSeeProblems reports whether the user can see the problems of a contest:
+// the staff always, other users once the contest started if they can view it
+func (a *contestAccess) canSeeProblems(ctx context.Context, contest *domain.Contest, userID string, role string) (bool, error) {
+	if isContestStaff(contest, userID, role) {
+		return true, nil
+	}
+	if time.Now().Before(contest.StartTime) {
+		return false, nil
+	}
+	return a.canView(ctx, contest, userID, role)
+}
+
+// checkRegistration verifies that the users can register for the contest.
+// Private contests accept the contest invite code, or users that are all on
+// the allowlist.
+func (a *contestAccess) checkRegistration(ctx context.Context, contest *domain.Contest, userIDs []string, inviteCode string) error {
+	if !contest.Visible {
+		return errors.New("contest not found")
+	}
+
+	if contest.Visibility != domain.ContestVisibilityPrivate {
+		return nil
+	}
+
+	if contest.InviteCode != "" && inviteCode == contest.InviteCode {
+		return nil
+	}
+
+	for _, userID := range userIDs {
+		user, err := a.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return errors.New("user not found")
+		}
+		if !isAllowlisted(contest, user.Email) {
+			return errors.New("contest is private, a valid invite code is required")
+		}
+	}
+	return nil
+}
+
+// isAllowlisted reports whether the email or its domain is on the allowlist of the contest
+func isAllowlisted(contest *domain.Contest, email string) bool {
+	email = strings.ToLower(email)
+	for _, allowed := range contest.AllowedEmails {
+		if strings.ToLower(allowed) == email {
+			return true
+		}
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domainName := email[at+1:]
+	for _, allowed := range contest.AllowedDomains {
+		if strings.TrimPrefix(strings.ToLower(allowed), "@") == domainName {
+			return true
+		}
+	}
+	return false
+}
@@ -13,13 +13,21 @@ type contestRegisterService struct {
 	contestRegisterRepo domain.ContestRegisterRepository
 	contestRepo         domain.ContestRepository
 	userRepo            domain.UserRepository
+	teamRepo            domain.TeamRepository
+	seriesRepo          domain.ContestSeriesRepository
+	unitOfWork          domain.UnitOfWork
+	access              *contestAccess
 }
 
-func NewContestRegisterService(contestRegisterRepo domain.ContestRegisterRepository, contestRepo domain.ContestRepository, userRepo domain.UserRepository) domain.ContestRegisterUseCase {
+func NewContestRegisterService(contestRegisterRepo domain.ContestRegisterRepository, contestRepo domain.ContestRepository, userRepo domain.UserRepository, teamRepo domain.TeamRepository, seriesRepo domain.ContestSeriesRepository, unitOfWork domain.UnitOfWork) domain.ContestRegisterUseCase {
 	return &contestRegisterService{
 		contestRegisterRepo: contestRegisterRepo,
 		contestRepo:         contestRepo,
 		userRepo:            userRepo,
+		teamRepo:            teamRepo,
+		seriesRepo:          seriesRepo,
+		unitOfWork:          unitOfWork,
+		access:              newContestAccess(userRepo, contestRegisterRepo),
 	}
 }
 
@@ -36,50 +44,90 @@ func (s *contestRegisterService) RegisterContest(ctx context.Context, userID str
 		return nil, errors.New("contest not found")
 	}
 
-	// Verify contest is open for registration it should be 5 minutes before contest startTime
-	contestStartTime := contest.StartTime
-	if time.Until(contestStartTime) < 5*time.Minute {
-		return nil, errors.New("contest registration is closed")
+	if contest.IsTeamContest {
+		return nil, errors.New("this is a team contest, register with a team instead")
 	}
 
-	// Check if already registered
-	existingRegistration, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, userID, req.ContestID)
-	if err == nil && existingRegistration != nil {
-		if existingRegistration.Status == "registered" {
-			return nil, errors.New("already registered for this contest")
+	err = s.access.checkRegistration(ctx, contest, []string{userID}, req.InviteCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify contest is open for registration
+	err = checkRegistrationOpen(contest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkSeriesRegistrationPolicy(ctx, contest, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Seats are counted and taken under the contest lock, concurrent
+	// registrations cannot exceed MaxParticipants
+	var response *domain.ContestRegisterResponse
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		locked, err := tx.Contests().LockContest(ctx, req.ContestID)
+		if err != nil {
+			return err
+		}
+		registrations := tx.Registrations()
+
+		// Check if already registered
+		existingRegistration, err := registrations.GetRegistrationByUserAndContest(ctx, userID, req.ContestID)
+		if err == nil && existingRegistration != nil {
+			err = checkExistingRegistration(existingRegistration)
+			if err != nil {
+				return err
+			}
+			status, err := initialRegistrationStatus(ctx, registrations, locked)
+			if err != nil {
+				return err
+			}
+			// If previously unregistered, register again
+			err = registrations.UpdateRegistrationStatus(ctx, userID, req.ContestID, status)
+			if err != nil {
+				return err
+			}
+			response = &domain.ContestRegisterResponse{
+				ID:           existingRegistration.ID,
+				UserID:       existingRegistration.UserID,
+				ContestID:    existingRegistration.ContestID,
+				RegisteredAt: existingRegistration.RegisteredAt,
+				Status:       status,
+			}
+			return nil
 		}
-		// If previously unregistered, update status to registered
-		err = s.contestRegisterRepo.UpdateRegistrationStatus(ctx, userID, req.ContestID, "registered")
+
+		status, err := initialRegistrationStatus(ctx, registrations, locked)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return &domain.ContestRegisterResponse{
-			ID:           existingRegistration.ID,
-			UserID:       existingRegistration.UserID,
-			ContestID:    existingRegistration.ContestID,
-			RegisteredAt: existingRegistration.RegisteredAt,
-			Status:       "registered",
-		}, nil
-	}
 
-	// Create new registration
-	registration, err := s.contestRegisterRepo.CreateContestRegistration(ctx, userID, req.ContestID)
+		// Create new registration
+		registration, err := registrations.CreateContestRegistration(ctx, userID, req.ContestID, status)
+		if err != nil {
+			return err
+		}
+		response = &domain.ContestRegisterResponse{
+			ID:           registration.ID,
+			UserID:       registration.UserID,
+			ContestID:    registration.ContestID,
+			RegisteredAt: registration.RegisteredAt,
+			Status:       registration.Status,
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	return &domain.ContestRegisterResponse{
-		ID:           registration.ID,
-		UserID:       registration.UserID,
-		ContestID:    registration.ContestID,
-		RegisteredAt: registration.RegisteredAt,
-		Status:       registration.Status,
-	}, nil
+	return response, nil
 }
 
 func (s *contestRegisterService) UnregisterContest(ctx context.Context, userID string, req *domain.ContestUnregisterRequest) error {
 	// Check if registered
-	_, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, userID, req.ContestID)
+	registration, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, userID, req.ContestID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("not registered for this contest")
@@ -87,6 +135,17 @@ func (s *contestRegisterService) UnregisterContest(ctx context.Context, userID s
 		return err
 	}
 
+	if registration.Status == domain.RegistrationStatusUnregistered || registration.Status == domain.RegistrationStatusRejected {
+		return errors.New("not registered for this contest")
+	}
+	if registration.Status == domain.RegistrationStatusDisqualified {
+		return errors.New("disqualified from this contest")
+	}
+
+	if registration.TeamID != nil {
+		return errors.New("registered as part of a team, the captain must unregister the team")
+	}
+
 	// Check the time constraint: unregistration allowed only if more than 2 minutes before contest start time
 
 	contest, err := s.contestRepo.GetByID(ctx, req.ContestID)
@@ -94,18 +153,29 @@ func (s *contestRegisterService) UnregisterContest(ctx context.Context, userID s
 		return errors.New("contest not found")
 	}
 
-	contestStartTime := contest.StartTime
-	if time.Until(contestStartTime) < 2*time.Minute {
+	// In windowed contests users can unregister until they start their window
+	if contest.IsWindowed && registration.StartedAt != nil {
+		return errors.New("contest window already started")
+	}
+	if time.Now().After(contest.UnregistrationCloseTime()) {
 		return errors.New("unregistration period has passed")
 	}
 
-	// Update status to unregistered
-	err = s.contestRegisterRepo.UpdateRegistrationStatus(ctx, userID, req.ContestID, "unregistered")
-	if err != nil {
-		return err
-	}
+	// The freed seat goes to the waitlist under the contest lock
+	return s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		locked, err := tx.Contests().LockContest(ctx, req.ContestID)
+		if err != nil {
+			return err
+		}
+		registrations := tx.Registrations()
 
-	return nil
+		// Update status to unregistered
+		err = registrations.UpdateRegistrationStatus(ctx, userID, req.ContestID, domain.RegistrationStatusUnregistered)
+		if err != nil {
+			return err
+		}
+		return promoteFromWaitlist(ctx, registrations, locked)
+	})
 }
 
 func (s *contestRegisterService) GetAllRegistrations(ctx context.Context, userID string) (*domain.AllRegisteredContestForUserResponse, error) {
@@ -120,8 +190,11 @@ func (s *contestRegisterService) GetAllRegistrations(ctx context.Context, userID
 			ID:           reg.ID,
 			UserID:       reg.UserID,
 			ContestID:    reg.ContestID,
+			TeamID:       reg.TeamID,
 			RegisteredAt: reg.RegisteredAt,
 			Status:       reg.Status,
+			StartedAt:    reg.StartedAt,
+			EndsAt:       reg.EndsAt,
 		})
 	}
 
@@ -142,8 +215,11 @@ func (s *contestRegisterService) GetAllRegistrationsForAdmin(ctx context.Context
 			ID:           reg.ID,
 			UserID:       reg.UserID,
 			ContestID:    reg.ContestID,
+			TeamID:       reg.TeamID,
 			RegisteredAt: reg.RegisteredAt,
 			Status:       reg.Status,
+			StartedAt:    reg.StartedAt,
+			EndsAt:       reg.EndsAt,
 		})
 	}
 
@@ -151,3 +227,441 @@ func (s *contestRegisterService) GetAllRegistrationsForAdmin(ctx context.Context
 		Registrations: responses,
 	}, nil
 }
+
+func (s *contestRegisterService) RegisterTeamContest(ctx context.Context, userID string, req *domain.TeamContestRegisterRequest) (*domain.TeamContestRegisterResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, req.ContestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	if !contest.IsTeamContest {
+		return nil, errors.New("this is not a team contest")
+	}
+
+	// Same registration window as individual registration
+	err = checkRegistrationOpen(contest)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetTeamByID(ctx, req.TeamID)
+	if err != nil {
+		return nil, errors.New("team not found")
+	}
+
+	if team.CaptainID != userID {
+		return nil, errors.New("only the team captain can register the team")
+	}
+
+	members, err := s.teamRepo.GetTeamMembers(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Members are registered as they are now, later roster changes do not affect the registration
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+
+	err = s.access.checkRegistration(ctx, contest, userIDs, req.InviteCode)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkSeriesRegistrationPolicy(ctx, contest, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The team takes one seat, counted under the contest lock with the
+	// members' registrations checked under it too
+	var registrations []domain.ContestRegistration
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		locked, err := tx.Contests().LockContest(ctx, req.ContestID)
+		if err != nil {
+			return err
+		}
+		for _, memberID := range userIDs {
+			existing, err := tx.Registrations().GetRegistrationByUserAndContest(ctx, memberID, req.ContestID)
+			if err == nil && existing != nil {
+				err = checkExistingRegistration(existing)
+				if err != nil {
+					return errors.New("user " + memberID + ": " + err.Error())
+				}
+			}
+		}
+		status, err := initialRegistrationStatus(ctx, tx.Registrations(), locked)
+		if err != nil {
+			return err
+		}
+		registrations, err = tx.Registrations().RegisterTeamMembers(ctx, req.ContestID, team.ID, userIDs, status)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.TeamContestRegisterResponse{
+		TeamID:        team.ID,
+		ContestID:     req.ContestID,
+		Registrations: []domain.ContestRegisterResponse{},
+	}
+	for _, reg := range registrations {
+		response.Registrations = append(response.Registrations, domain.ContestRegisterResponse{
+			ID:           reg.ID,
+			UserID:       reg.UserID,
+			ContestID:    reg.ContestID,
+			TeamID:       reg.TeamID,
+			RegisteredAt: reg.RegisteredAt,
+			Status:       reg.Status,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *contestRegisterService) UnregisterTeamContest(ctx context.Context, userID string, req *domain.TeamContestUnregisterRequest) error {
+	team, err := s.teamRepo.GetTeamByID(ctx, req.TeamID)
+	if err != nil {
+		return errors.New("team not found")
+	}
+
+	if team.CaptainID != userID {
+		return errors.New("only the team captain can unregister the team")
+	}
+
+	contest, err := s.contestRepo.GetByID(ctx, req.ContestID)
+	if err != nil {
+		return errors.New("contest not found")
+	}
+
+	// Every member has the team's status, the captain's registration stands for it
+	registration, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, team.CaptainID, req.ContestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("team is not registered for this contest")
+		}
+		return err
+	}
+	if registration.TeamID == nil || *registration.TeamID != team.ID {
+		return errors.New("team is not registered for this contest")
+	}
+
+	if registration.Status == domain.RegistrationStatusUnregistered || registration.Status == domain.RegistrationStatusRejected {
+		return errors.New("team is not registered for this contest")
+	}
+	if registration.Status == domain.RegistrationStatusDisqualified {
+		return errors.New("team is disqualified from this contest")
+	}
+
+	if contest.IsWindowed && registration.StartedAt != nil {
+		return errors.New("contest window already started")
+	}
+	if time.Now().After(contest.UnregistrationCloseTime()) {
+		return errors.New("unregistration period has passed")
+	}
+
+	return s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		locked, err := tx.Contests().LockContest(ctx, req.ContestID)
+		if err != nil {
+			return err
+		}
+		registrations := tx.Registrations()
+
+		err = registrations.UpdateTeamRegistrationStatus(ctx, team.ID, req.ContestID, domain.RegistrationStatusUnregistered)
+		if err != nil {
+			return err
+		}
+
+		// Only a team with an active registration held a seat for the waitlist
+		if !registration.IsActive() {
+			return nil
+		}
+		return promoteFromWaitlist(ctx, registrations, locked)
+	})
+}
+
+// StartContestWindow starts the personal window of a registered user in a
+// windowed contest. The window lasts Duration minutes and never extends past
+// the end of the contest.
+func (s *contestRegisterService) StartContestWindow(ctx context.Context, userID string, req *domain.StartContestWindowRequest) (*domain.ContestRegisterResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, req.ContestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	if !contest.IsWindowed {
+		return nil, errors.New("this is not a windowed contest")
+	}
+
+	now := time.Now()
+	if now.Before(contest.StartTime) {
+		return nil, errors.New("contest has not started yet")
+	}
+	if now.After(contest.EndTime) {
+		return nil, errors.New("contest has ended")
+	}
+
+	registration, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, userID, req.ContestID)
+	if err != nil || !registration.IsActive() {
+		return nil, errors.New("not registered for this contest")
+	}
+
+	if registration.StartedAt != nil {
+		return nil, errors.New("contest window already started")
+	}
+
+	endsAt := now.Add(time.Duration(contest.Duration) * time.Minute)
+	if endsAt.After(contest.EndTime) {
+		endsAt = contest.EndTime
+	}
+
+	err = s.contestRegisterRepo.StartContestWindow(ctx, userID, req.ContestID, now, endsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ContestRegisterResponse{
+		ID:           registration.ID,
+		UserID:       registration.UserID,
+		ContestID:    registration.ContestID,
+		RegisteredAt: registration.RegisteredAt,
+		Status:       registration.Status,
+		StartedAt:    &now,
+		EndsAt:       &endsAt,
+	}, nil
+}
+
+// checkSeriesRegistrationPolicy rejects users already registered in an
+// overlapping contest of the same series, unless the series allows it
+func (s *contestRegisterService) checkSeriesRegistrationPolicy(ctx context.Context, contest *domain.Contest, userIDs []string) error {
+	if contest.SeriesID == nil {
+		return nil
+	}
+
+	series, err := s.seriesRepo.GetSeriesByID(ctx, *contest.SeriesID)
+	if err != nil {
+		return errors.New("contest series not found")
+	}
+	if series.AllowMultipleRegistrations {
+		return nil
+	}
+
+	contests, err := s.contestRepo.GetContestsBySeriesID(ctx, series.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range contests {
+		if other.Id == contest.Id || !other.StartTime.Before(contest.EndTime) || !other.EndTime.After(contest.StartTime) {
+			continue
+		}
+		for _, userID := range userIDs {
+			registration, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, userID, other.Id)
+			if err == nil && registration.IsActive() {
+				return errors.New("user " + userID + " is already registered for " + other.Name + " which runs at the same time in this series")
+			}
+		}
+	}
+	return nil
+}
+
+// ApproveRegistrations approves pending registrations, registrations that do
+// not fit in the contest anymore are waitlisted instead
+func (s *contestRegisterService) ApproveRegistrations(ctx context.Context, req *domain.RegistrationDecisionRequest) (*domain.RegistrationDecisionResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, req.ContestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	return s.decideRegistrations(ctx, contest, req.RegistrationIDs, func(registrations domain.ContestRegisterRepository, contest *domain.Contest, registration *domain.ContestRegistration) (string, error) {
+		if registration.Status != domain.RegistrationStatusPending {
+			return "", errors.New("registration is not pending")
+		}
+		full, err := isContestFull(ctx, registrations, contest)
+		if err != nil {
+			return "", err
+		}
+		if full {
+			return domain.RegistrationStatusWaitlisted, nil
+		}
+		return domain.RegistrationStatusApproved, nil
+	})
+}
+
+// RejectRegistrations rejects pending and waitlisted registrations
+func (s *contestRegisterService) RejectRegistrations(ctx context.Context, req *domain.RegistrationDecisionRequest) (*domain.RegistrationDecisionResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, req.ContestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	return s.decideRegistrations(ctx, contest, req.RegistrationIDs, func(_ domain.ContestRegisterRepository, _ *domain.Contest, registration *domain.ContestRegistration) (string, error) {
+		if registration.Status != domain.RegistrationStatusPending && registration.Status != domain.RegistrationStatusWaitlisted {
+			return "", errors.New("registration is not pending or waitlisted")
+		}
+		return domain.RegistrationStatusRejected, nil
+	})
+}
+
+// decideRegistrations applies the status returned by decide to every
+// registration, the whole team is updated for team registrations. Each
+// decision is made under the contest lock with the repositories of its
+// transaction. Failures are reported per registration without stopping the
+// batch.
+func (s *contestRegisterService) decideRegistrations(ctx context.Context, contest *domain.Contest, registrationIDs []string, decide func(domain.ContestRegisterRepository, *domain.Contest, *domain.ContestRegistration) (string, error)) (*domain.RegistrationDecisionResponse, error) {
+	response := &domain.RegistrationDecisionResponse{
+		Registrations: []domain.ContestRegisterResponse{},
+	}
+
+	for _, registrationID := range registrationIDs {
+		var decided domain.ContestRegisterResponse
+		err := s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+			locked, err := tx.Contests().LockContest(ctx, contest.Id)
+			if err != nil {
+				return err
+			}
+			registrations := tx.Registrations()
+
+			registration, err := registrations.GetRegistrationByID(ctx, registrationID)
+			if err != nil || registration.ContestID != contest.Id {
+				return errors.New("not found in this contest")
+			}
+
+			status, err := decide(registrations, locked, registration)
+			if err != nil {
+				return err
+			}
+
+			if registration.TeamID != nil {
+				err = registrations.UpdateTeamRegistrationStatus(ctx, *registration.TeamID, contest.Id, status)
+			} else {
+				err = registrations.UpdateRegistrationStatus(ctx, registration.UserID, contest.Id, status)
+			}
+			if err != nil {
+				return err
+			}
+
+			decided = domain.ContestRegisterResponse{
+				ID:           registration.ID,
+				UserID:       registration.UserID,
+				ContestID:    registration.ContestID,
+				TeamID:       registration.TeamID,
+				RegisteredAt: registration.RegisteredAt,
+				Status:       status,
+			}
+			return nil
+		})
+		if err != nil {
+			response.Errors = append(response.Errors, "registration "+registrationID+": "+err.Error())
+			continue
+		}
+		response.Registrations = append(response.Registrations, decided)
+	}
+
+	return response, nil
+}
+
+// checkRegistrationOpen rejects registrations outside the registration
+// window of the contest
+func checkRegistrationOpen(contest *domain.Contest) error {
+	now := time.Now()
+	if contest.RegistrationOpensAt != nil && now.Before(*contest.RegistrationOpensAt) {
+		return errors.New("contest registration is not open yet")
+	}
+	if now.After(contest.RegistrationCloseTime()) {
+		return errors.New("contest registration is closed")
+	}
+	return nil
+}
+
+// checkExistingRegistration rejects registering again unless the previous
+// registration was withdrawn
+func checkExistingRegistration(registration *domain.ContestRegistration) error {
+	switch registration.Status {
+	case domain.RegistrationStatusUnregistered:
+		return nil
+	case domain.RegistrationStatusPending:
+		return errors.New("registration for this contest is pending approval")
+	case domain.RegistrationStatusWaitlisted:
+		return errors.New("already on the waitlist for this contest")
+	case domain.RegistrationStatusRejected:
+		return errors.New("registration for this contest was rejected")
+	case domain.RegistrationStatusDisqualified:
+		return errors.New("disqualified from this contest")
+	default:
+		return errors.New("already registered for this contest")
+	}
+}
+
+// initialRegistrationStatus returns the status of a new registration: pending
+// when the contest requires approval, waitlisted when it is full. Callers hold
+// the contest lock until the registration is stored.
+func initialRegistrationStatus(ctx context.Context, registrations domain.ContestRegisterRepository, contest *domain.Contest) (string, error) {
+	if contest.RequiresApproval {
+		return domain.RegistrationStatusPending, nil
+	}
+
+	full, err := isContestFull(ctx, registrations, contest)
+	if err != nil {
+		return "", err
+	}
+	if full {
+		return domain.RegistrationStatusWaitlisted, nil
+	}
+	return domain.RegistrationStatusRegistered, nil
+}
+
+func isContestFull(ctx context.Context, registrations domain.ContestRegisterRepository, contest *domain.Contest) (bool, error) {
+	if contest.MaxParticipants <= 0 {
+		return false, nil
+	}
+
+	count, err := registrations.CountActiveParticipants(ctx, contest.Id)
+	if err != nil {
+		return false, err
+	}
+	return count >= int64(contest.MaxParticipants), nil
+}
+
+// promoteFromWaitlist fills the free seats of the contest with the longest
+// waiting registrations. Teams are promoted as a whole. Callers hold the
+// contest lock.
+func promoteFromWaitlist(ctx context.Context, registrations domain.ContestRegisterRepository, contest *domain.Contest) error {
+	waitlisted, err := registrations.GetWaitlistedRegistrations(ctx, contest.Id)
+	if err != nil {
+		return err
+	}
+
+	status := domain.RegistrationStatusRegistered
+	if contest.RequiresApproval {
+		status = domain.RegistrationStatusApproved
+	}
+
+	promotedTeams := map[string]bool{}
+	for _, registration := range waitlisted {
+		if registration.TeamID != nil && promotedTeams[*registration.TeamID] {
+			continue
+		}
+
+		full, err := isContestFull(ctx, registrations, contest)
+		if err != nil {
+			return err
+		}
+		if full {
+			return nil
+		}
+
+		if registration.TeamID != nil {
+			promotedTeams[*registration.TeamID] = true
+			err = registrations.UpdateTeamRegistrationStatus(ctx, *registration.TeamID, contest.Id, status)
+		} else {
+			err = registrations.UpdateRegistrationStatus(ctx, registration.UserID, contest.Id, status)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type contestSeriesService struct {
+	seriesRepo  domain.ContestSeriesRepository
+	contestRepo domain.ContestRepository
+}
+
+func NewContestSeriesService(seriesRepo domain.ContestSeriesRepository, contestRepo domain.ContestRepository) domain.ContestSeriesUseCase {
+	return &contestSeriesService{
+		seriesRepo:  seriesRepo,
+		contestRepo: contestRepo,
+	}
+}
+
+func (s *contestSeriesService) CreateSeries(ctx context.Context, req *domain.CreateContestSeriesRequest, userID string) (*domain.ContestSeriesResponse, error) {
+	series := &domain.ContestSeries{
+		ID:                         uuid.New().String(),
+		Name:                       req.Name,
+		Description:                req.Description,
+		AllowMultipleRegistrations: req.AllowMultipleRegistrations,
+		CreatedBy:                  userID,
+	}
+
+	err := s.seriesRepo.CreateSeries(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+
+	return toContestSeriesResponse(series, nil), nil
+}
+
+func (s *contestSeriesService) UpdateSeries(ctx context.Context, req *domain.UpdateContestSeriesRequest) (*domain.ContestSeriesResponse, error) {
+	series, err := s.seriesRepo.GetSeriesByID(ctx, req.ID)
+	if err != nil {
+		return nil, errors.New("contest series not found")
+	}
+
+	series.Name = req.Name
+	series.Description = req.Description
+	series.AllowMultipleRegistrations = req.AllowMultipleRegistrations
+
+	err = s.seriesRepo.UpdateSeries(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+
+	return toContestSeriesResponse(series, nil), nil
+}
+
+func (s *contestSeriesService) GetSeries(ctx context.Context, id string) (*domain.ContestSeriesResponse, error) {
+	series, err := s.seriesRepo.GetSeriesByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("contest series not found")
+	}
+
+	contests, err := s.contestRepo.GetContestsBySeriesID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	contestResponses := []domain.CreateContestResponse{}
+	for i := range contests {
+		contestResponses = append(contestResponses, *toContestResponse(&contests[i], true))
+	}
+
+	return toContestSeriesResponse(series, contestResponses), nil
+}
+
+func (s *contestSeriesService) GetAllSeries(ctx context.Context) ([]domain.ContestSeriesResponse, error) {
+	allSeries, err := s.seriesRepo.GetAllSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []domain.ContestSeriesResponse{}
+	for i := range allSeries {
+		responses = append(responses, *toContestSeriesResponse(&allSeries[i], nil))
+	}
+
+	return responses, nil
+}
+
+func toContestSeriesResponse(series *domain.ContestSeries, contests []domain.CreateContestResponse) *domain.ContestSeriesResponse {
+	return &domain.ContestSeriesResponse{
+		ID:                         series.ID,
+		Name:                       series.Name,
+		Description:                series.Description,
+		AllowMultipleRegistrations: series.AllowMultipleRegistrations,
+		Contests:                   contests,
+		CreatedBy:                  series.CreatedBy,
+		CreatedAt:                  series.CreatedAt,
+		UpdatedAt:                  series.UpdatedAt,
+	}
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"algoforces/internal/domain"
+	"algoforces/internal/utils"
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,23 +13,32 @@ import (
 type contestService struct {
 	contestRepo domain.ContestRepository
 	userRepo    domain.UserRepository
+	problemRepo domain.ProblemRepository
+	seriesRepo  domain.ContestSeriesRepository
+	unitOfWork  domain.UnitOfWork
+	access      *contestAccess
 }
 
-func NewContestService(contestRepo domain.ContestRepository, userRepo domain.UserRepository) domain.ContestUseCase {
+func NewContestService(contestRepo domain.ContestRepository, userRepo domain.UserRepository, problemRepo domain.ProblemRepository, seriesRepo domain.ContestSeriesRepository, contestRegisterRepo domain.ContestRegisterRepository, unitOfWork domain.UnitOfWork) domain.ContestUseCase {
 	return &contestService{
 		contestRepo: contestRepo,
 		userRepo:    userRepo,
+		problemRepo: problemRepo,
+		seriesRepo:  seriesRepo,
+		unitOfWork:  unitOfWork,
+		access:      newContestAccess(userRepo, contestRegisterRepo),
 	}
 }
 
 func (s *contestService) CreateContest(ctx context.Context, req *domain.CreateContestRequest, userId string) (*domain.CreateContestResponse, error) {
-	contestList, err := s.contestRepo.CheckContestInTimeWindow(ctx, req.StartTime, req.EndTime)
+	err := validateContestFormat(req.StartTime, req.EndTime, req.Duration, req.IsWindowed, req.IsTeamContest)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(contestList) > 0 {
-		return nil, errors.New("already a contest exists in the given time window")
+	err = s.checkContestOverlap(ctx, "", req.StartTime, req.EndTime, req.SeriesID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check if correct problem setters are provided
@@ -42,50 +53,60 @@ func (s *contestService) CreateContest(ctx context.Context, req *domain.CreateCo
 	}
 
 	contest := &domain.Contest{
-		Id:             uuid.New().String(),
-		Name:           req.Name,
-		Description:    req.Description,
-		StartTime:      req.StartTime,
-		EndTime:        req.EndTime,
-		Duration:       req.Duration,
-		Visible:        req.Visible,
-		IsActive:       req.IsActive,
-		ProblemSetters: req.ProblemSetters,
-		CreatedBy:      userId,
+		Id:                     uuid.New().String(),
+		Name:                   req.Name,
+		Description:            req.Description,
+		StartTime:              req.StartTime,
+		EndTime:                req.EndTime,
+		Duration:               req.Duration,
+		Visible:                req.Visible,
+		IsActive:               req.IsActive,
+		IsTeamContest:          req.IsTeamContest,
+		IsWindowed:             req.IsWindowed,
+		SeriesID:               req.SeriesID,
+		Division:               req.Division,
+		Visibility:             req.Visibility,
+		InviteCode:             req.InviteCode,
+		AllowedEmails:          req.AllowedEmails,
+		AllowedDomains:         req.AllowedDomains,
+		MaxParticipants:        req.MaxParticipants,
+		RequiresApproval:       req.RequiresApproval,
+		RegistrationOpensAt:    req.RegistrationOpensAt,
+		RegistrationClosesAt:   req.RegistrationClosesAt,
+		UnregistrationClosesAt: req.UnregistrationClosesAt,
+		AllowLateRegistration:  req.AllowLateRegistration,
+		ProblemSetters:         req.ProblemSetters,
+		CreatedBy:              userId,
 	}
 
-	err = s.contestRepo.CreateContest(ctx, contest)
+	err = validateRegistrationWindow(contest)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &domain.CreateContestResponse{
-		Id:             contest.Id,
-		Name:           contest.Name,
-		Description:    contest.Description,
-		StartTime:      contest.StartTime,
-		EndTime:        contest.EndTime,
-		Duration:       contest.Duration,
-		Visible:        contest.Visible,
-		IsActive:       contest.IsActive,
-		ProblemSetters: contest.ProblemSetters,
-		CreatedBy:      contest.CreatedBy,
-		CreatedAt:      contest.CreatedAt,
-		UpdatedAt:      contest.UpdatedAt,
+	err = setContestInviteCode(contest)
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	err = s.contestRepo.CreateContest(ctx, contest)
+	if err != nil {
+		return nil, err
+	}
+
+	return toContestResponse(contest, true), nil
 }
 
 func (s *contestService) UpdateContest(ctx context.Context, req *domain.UpdateContestRequest) (*domain.UpdateContestResponse, error) {
 	// Pre Update validations for time window and problem setters
-	contestList, err := s.contestRepo.CheckContestInTimeWindow(ctx, req.StartTime, req.EndTime)
+	err := validateContestFormat(req.StartTime, req.EndTime, req.Duration, req.IsWindowed, req.IsTeamContest)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(contestList) > 0 {
-		return nil, errors.New("already a contest exists in the given time window")
+	err = s.checkContestOverlap(ctx, req.Id, req.StartTime, req.EndTime, req.SeriesID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check if correct problem setters are provided
@@ -99,65 +120,108 @@ func (s *contestService) UpdateContest(ctx context.Context, req *domain.UpdateCo
 		}
 	}
 
-	contest, err := s.contestRepo.GetByID(ctx, req.Id)
-	if err != nil {
-		return nil, err
-	}
+	// The contest is updated under its lock, seats freed by a higher
+	// MaxParticipants go to the waitlist in the same transaction
+	var contest *domain.Contest
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		contest, err = tx.Contests().LockContest(ctx, req.Id)
+		if err != nil {
+			return err
+		}
 
-	contest.Name = req.Name
-	contest.Description = req.Description
-	contest.StartTime = req.StartTime
-	contest.EndTime = req.EndTime
-	contest.Duration = req.Duration
-	contest.Visible = req.Visible
-	contest.IsActive = req.IsActive
-	contest.ProblemSetters = req.ProblemSetters
+		contest.Name = req.Name
+		contest.Description = req.Description
+		contest.StartTime = req.StartTime
+		contest.EndTime = req.EndTime
+		contest.Duration = req.Duration
+		contest.Visible = req.Visible
+		contest.IsActive = req.IsActive
+		contest.IsTeamContest = req.IsTeamContest
+		contest.IsWindowed = req.IsWindowed
+		contest.SeriesID = req.SeriesID
+		contest.Division = req.Division
+		contest.Visibility = req.Visibility
+		// Invites already sent keep working unless a new code is given
+		if req.InviteCode != "" {
+			contest.InviteCode = req.InviteCode
+		}
+		contest.AllowedEmails = req.AllowedEmails
+		contest.AllowedDomains = req.AllowedDomains
+		contest.MaxParticipants = req.MaxParticipants
+		contest.RequiresApproval = req.RequiresApproval
+		contest.RegistrationOpensAt = req.RegistrationOpensAt
+		contest.RegistrationClosesAt = req.RegistrationClosesAt
+		contest.UnregistrationClosesAt = req.UnregistrationClosesAt
+		contest.AllowLateRegistration = req.AllowLateRegistration
+		contest.ProblemSetters = req.ProblemSetters
+
+		err = validateRegistrationWindow(contest)
+		if err != nil {
+			return err
+		}
+
+		err = setContestInviteCode(contest)
+		if err != nil {
+			return err
+		}
 
-	err = s.contestRepo.UpdateContest(ctx, contest)
+		err = tx.Contests().UpdateContest(ctx, contest)
+		if err != nil {
+			return err
+		}
+		return promoteFromWaitlist(ctx, tx.Registrations(), contest)
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	response := &domain.UpdateContestResponse{
-		Id:             contest.Id,
-		Name:           contest.Name,
-		Description:    contest.Description,
-		StartTime:      contest.StartTime,
-		EndTime:        contest.EndTime,
-		Duration:       contest.Duration,
-		Visible:        contest.Visible,
-		IsActive:       contest.IsActive,
-		ProblemSetters: contest.ProblemSetters,
-		CreatedBy:      contest.CreatedBy,
-		CreatedAt:      contest.CreatedAt,
-		UpdatedAt:      contest.UpdatedAt,
+		Id:                     contest.Id,
+		Name:                   contest.Name,
+		Description:            contest.Description,
+		StartTime:              contest.StartTime,
+		EndTime:                contest.EndTime,
+		Duration:               contest.Duration,
+		Visible:                contest.Visible,
+		IsActive:               contest.IsActive,
+		IsTeamContest:          contest.IsTeamContest,
+		IsWindowed:             contest.IsWindowed,
+		SeriesID:               contest.SeriesID,
+		Division:               contest.Division,
+		Visibility:             contest.Visibility,
+		InviteCode:             contest.InviteCode,
+		AllowedEmails:          contest.AllowedEmails,
+		AllowedDomains:         contest.AllowedDomains,
+		MaxParticipants:        contest.MaxParticipants,
+		RequiresApproval:       contest.RequiresApproval,
+		RegistrationOpensAt:    contest.RegistrationOpensAt,
+		RegistrationClosesAt:   contest.RegistrationCloseTime(),
+		UnregistrationClosesAt: contest.UnregistrationCloseTime(),
+		AllowLateRegistration:  contest.AllowLateRegistration,
+		ProblemSetters:         contest.ProblemSetters,
+		CreatedBy:              contest.CreatedBy,
+		CreatedAt:              contest.CreatedAt,
+		UpdatedAt:              contest.UpdatedAt,
 	}
 
 	return response, nil
 }
 
-func (s *contestService) GetContestDetails(ctx context.Context, id string) (*domain.CreateContestResponse, error) {
+func (s *contestService) GetContestDetails(ctx context.Context, id string, userID string, role string) (*domain.CreateContestResponse, error) {
 	contest, err := s.contestRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &domain.CreateContestResponse{
-		Id:             contest.Id,
-		Name:           contest.Name,
-		Description:    contest.Description,
-		StartTime:      contest.StartTime,
-		EndTime:        contest.EndTime,
-		Duration:       contest.Duration,
-		Visible:        contest.Visible,
-		IsActive:       contest.IsActive,
-		ProblemSetters: contest.ProblemSetters,
-		CreatedBy:      contest.CreatedBy,
-		CreatedAt:      contest.CreatedAt,
-		UpdatedAt:      contest.UpdatedAt,
+	canView, err := s.access.canView(ctx, contest, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("contest not found")
 	}
 
-	return response, nil
+	return toContestResponse(contest, isContestStaff(contest, userID, role)), nil
 }
 
 func (s *contestService) DeleteContest(ctx context.Context, req *domain.DeleteContestRequest) error {
@@ -182,22 +246,286 @@ func (s *contestService) GetAllContests(ctx context.Context) ([]domain.CreateCon
 	}
 
 	var contestResponses []domain.CreateContestResponse
-	for _, contest := range contests {
-		contestResponses = append(contestResponses, domain.CreateContestResponse{
-			Id:             contest.Id,
-			Name:           contest.Name,
-			Description:    contest.Description,
-			StartTime:      contest.StartTime,
-			EndTime:        contest.EndTime,
-			Duration:       contest.Duration,
-			Visible:        contest.Visible,
-			IsActive:       contest.IsActive,
-			ProblemSetters: contest.ProblemSetters,
-			CreatedBy:      contest.CreatedBy,
-			CreatedAt:      contest.CreatedAt,
-			UpdatedAt:      contest.UpdatedAt,
-		})
+	for i := range contests {
+		contestResponses = append(contestResponses, *toContestResponse(&contests[i], true))
+	}
+
+	return contestResponses, nil
+}
+
+// GetVisibleContests lists the contests the user can see, unlisted contests
+// only appear once the user registered for them
+func (s *contestService) GetVisibleContests(ctx context.Context, userID string, role string) ([]domain.CreateContestResponse, error) {
+	contests, err := s.contestRepo.GetAllContests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contestResponses := []domain.CreateContestResponse{}
+	for i := range contests {
+		canList, err := s.access.canList(ctx, &contests[i], userID, role)
+		if err != nil {
+			return nil, err
+		}
+		if canList {
+			contestResponses = append(contestResponses, *toContestResponse(&contests[i], isContestStaff(&contests[i], userID, role)))
+		}
 	}
 
 	return contestResponses, nil
 }
+
+func (s *contestService) AddProblemToContest(ctx context.Context, contestID string, req *domain.AddContestProblemRequest) (*domain.ContestProblemResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	problem, err := s.problemRepo.GetProblemByID(ctx, req.ProblemID)
+	if err != nil {
+		return nil, errors.New("problem not found")
+	}
+
+	// Contestants must not have seen a problem before the contest ends
+	if problem.Visibility == domain.ProblemVisibilityPublic && time.Now().Before(contest.EndTime) {
+		return nil, errors.New("a public problem cannot be added to a contest that has not ended")
+	}
+
+	// A problem can only be shared between the contests of a series
+	existing, err := s.contestRepo.GetContestProblemsByProblemID(ctx, req.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+	for _, cp := range existing {
+		if cp.ContestID == contestID {
+			return nil, errors.New("problem already belongs to this contest")
+		}
+		other, err := s.contestRepo.GetByID(ctx, cp.ContestID)
+		if err != nil {
+			return nil, err
+		}
+		if !sameSeries(contest, other) {
+			return nil, errors.New("problem already belongs to a contest outside this series")
+		}
+	}
+
+	// Problem indexes must be unique inside a contest
+	contestProblems, err := s.contestRepo.GetContestProblems(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+	for _, cp := range contestProblems {
+		if cp.Index == req.Index {
+			return nil, errors.New("problem index " + req.Index + " is already used in this contest")
+		}
+	}
+
+	contestProblem := &domain.ContestProblem{
+		ID:        uuid.New().String(),
+		ContestID: contestID,
+		ProblemID: problem.UniqueID,
+		Index:     req.Index,
+	}
+
+	err = s.contestRepo.AddContestProblem(ctx, contestProblem)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drafts become contest-only, they are published when the contest ends
+	if problem.Visibility == domain.ProblemVisibilityDraft {
+		problem.Visibility = domain.ProblemVisibilityContest
+		err = s.problemRepo.UpdateProblem(ctx, problem)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.ContestProblemResponse{
+		ContestID: contestProblem.ContestID,
+		ProblemID: contestProblem.ProblemID,
+		Index:     contestProblem.Index,
+		Title:     problem.Title,
+	}, nil
+}
+
+func (s *contestService) RemoveProblemFromContest(ctx context.Context, contestID string, problemID string) error {
+	return s.contestRepo.RemoveContestProblem(ctx, contestID, problemID)
+}
+
+// GetContestProblems lists the problems of a contest. Participants only see
+// them once the contest has started.
+func (s *contestService) GetContestProblems(ctx context.Context, contestID string, userID string, role string) ([]domain.ContestProblemResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	canView, err := s.access.canView(ctx, contest, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("contest not found")
+	}
+
+	canSeeProblems, err := s.access.canSeeProblems(ctx, contest, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !canSeeProblems {
+		return nil, errors.New("contest has not started yet")
+	}
+
+	return getContestProblemResponses(ctx, s.contestRepo, s.problemRepo, contestID)
+}
+
+// getContestProblemResponses lists the problems of a contest along with their titles
+func getContestProblemResponses(ctx context.Context, contestRepo domain.ContestRepository, problemRepo domain.ProblemRepository, contestID string) ([]domain.ContestProblemResponse, error) {
+	contestProblems, err := contestRepo.GetContestProblems(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []domain.ContestProblemResponse{}
+	for _, cp := range contestProblems {
+		response := domain.ContestProblemResponse{
+			ContestID: cp.ContestID,
+			ProblemID: cp.ProblemID,
+			Index:     cp.Index,
+		}
+		if problem, err := problemRepo.GetProblemByID(ctx, cp.ProblemID); err == nil {
+			response.Title = problem.Title
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// toContestResponse maps a contest to its response, the invite code and the
+// allowlists are only included for the contest staff
+func toContestResponse(contest *domain.Contest, isStaff bool) *domain.CreateContestResponse {
+	response := &domain.CreateContestResponse{
+		Id:                     contest.Id,
+		Name:                   contest.Name,
+		Description:            contest.Description,
+		StartTime:              contest.StartTime,
+		EndTime:                contest.EndTime,
+		Duration:               contest.Duration,
+		Visible:                contest.Visible,
+		IsActive:               contest.IsActive,
+		IsTeamContest:          contest.IsTeamContest,
+		IsWindowed:             contest.IsWindowed,
+		SeriesID:               contest.SeriesID,
+		Division:               contest.Division,
+		Visibility:             contest.Visibility,
+		MaxParticipants:        contest.MaxParticipants,
+		RequiresApproval:       contest.RequiresApproval,
+		RegistrationOpensAt:    contest.RegistrationOpensAt,
+		RegistrationClosesAt:   contest.RegistrationCloseTime(),
+		UnregistrationClosesAt: contest.UnregistrationCloseTime(),
+		AllowLateRegistration:  contest.AllowLateRegistration,
+		ProblemSetters:         contest.ProblemSetters,
+		CreatedBy:              contest.CreatedBy,
+		CreatedAt:              contest.CreatedAt,
+		UpdatedAt:              contest.UpdatedAt,
+	}
+	if isStaff {
+		response.InviteCode = contest.InviteCode
+		response.AllowedEmails = contest.AllowedEmails
+		response.AllowedDomains = contest.AllowedDomains
+	}
+	return response
+}
+
+// setContestInviteCode defaults the visibility to public and generates an
+// invite code for private contests that have none
+func setContestInviteCode(contest *domain.Contest) error {
+	if contest.Visibility == "" {
+		contest.Visibility = domain.ContestVisibilityPublic
+	}
+	if contest.Visibility != domain.ContestVisibilityPrivate || contest.InviteCode != "" {
+		return nil
+	}
+
+	inviteCode, err := utils.GenerateInviteCode(inviteCodeLength)
+	if err != nil {
+		return err
+	}
+	contest.InviteCode = inviteCode
+	return nil
+}
+
+// checkContestOverlap rejects a contest overlapping another one in time,
+// unless both belong to the same series. contestID is the contest being
+// updated, empty on creation.
+func (s *contestService) checkContestOverlap(ctx context.Context, contestID string, startTime, endTime time.Time, seriesID *string) error {
+	if seriesID != nil {
+		_, err := s.seriesRepo.GetSeriesByID(ctx, *seriesID)
+		if err != nil {
+			return errors.New("contest series not found")
+		}
+	}
+
+	contestList, err := s.contestRepo.CheckContestInTimeWindow(ctx, startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	candidate := &domain.Contest{Id: contestID, SeriesID: seriesID}
+	for i := range contestList {
+		if contestList[i].Id == contestID || sameSeries(candidate, &contestList[i]) {
+			continue
+		}
+		return errors.New("already a contest exists in the given time window")
+	}
+	return nil
+}
+
+// sameSeries reports whether both contests belong to the same series
+func sameSeries(a, b *domain.Contest) bool {
+	return a.SeriesID != nil && b.SeriesID != nil && *a.SeriesID == *b.SeriesID
+}
+
+// validateContestFormat checks that the duration of a windowed contest fits in
+// its window. Windowed contests are individual only, as team members would
+// each pick their own start time.
+func validateContestFormat(startTime, endTime time.Time, duration int, isWindowed bool, isTeamContest bool) error {
+	if !isWindowed {
+		return nil
+	}
+	if isTeamContest {
+		return errors.New("team contests cannot be windowed")
+	}
+	if time.Duration(duration)*time.Minute > endTime.Sub(startTime) {
+		return errors.New("contest duration exceeds the contest time window")
+	}
+	return nil
+}
+
+// validateRegistrationWindow checks the registration and unregistration
+// cutoffs of a contest. Registration may only stay open after the start when
+// late registration is allowed or the contest is windowed.
+func validateRegistrationWindow(contest *domain.Contest) error {
+	closesAt := contest.RegistrationCloseTime()
+	if contest.RegistrationOpensAt != nil && !contest.RegistrationOpensAt.Before(closesAt) {
+		return errors.New("registration must open before it closes")
+	}
+	if closesAt.After(contest.EndTime) {
+		return errors.New("registration cannot close after the contest ends")
+	}
+	if !contest.IsWindowed && !contest.AllowLateRegistration && closesAt.After(contest.StartTime) {
+		return errors.New("registration must close before the contest starts unless late registration is allowed")
+	}
+
+	unregistrationClosesAt := contest.UnregistrationCloseTime()
+	if contest.IsWindowed {
+		if unregistrationClosesAt.After(contest.EndTime) {
+			return errors.New("unregistration cannot close after the contest ends")
+		}
+	} else if unregistrationClosesAt.After(contest.StartTime) {
+		return errors.New("unregistration must close before the contest starts")
+	}
+	return nil
+}
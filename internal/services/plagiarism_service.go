@@ -0,0 +1,114 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/queue"
+	"context"
+	"errors"
+	"time"
+)
+
+type plagiarismService struct {
+	plagiarismRepo      domain.PlagiarismRepository
+	contestRepo         domain.ContestRepository
+	contestRegisterRepo domain.ContestRegisterRepository
+	plagiarismQueue     queue.PlagiarismQueueInterface
+	unitOfWork          domain.UnitOfWork
+}
+
+func NewPlagiarismService(plagiarismRepo domain.PlagiarismRepository, contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, plagiarismQueue queue.PlagiarismQueueInterface, unitOfWork domain.UnitOfWork) domain.PlagiarismUseCase {
+	return &plagiarismService{
+		plagiarismRepo:      plagiarismRepo,
+		contestRepo:         contestRepo,
+		contestRegisterRepo: contestRegisterRepo,
+		plagiarismQueue:     plagiarismQueue,
+		unitOfWork:          unitOfWork,
+	}
+}
+
+// RequestCheck queues a plagiarism check of an ended contest. Ended contests
+// are also checked automatically by the worker, this runs the check again.
+func (s *plagiarismService) RequestCheck(ctx context.Context, contestID string) error {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return errors.New("contest not found")
+	}
+
+	if time.Now().Before(contest.EndTime) {
+		return errors.New("contest has not ended yet")
+	}
+
+	return s.plagiarismQueue.EnqueueCheck(ctx, contestID)
+}
+
+func (s *plagiarismService) GetContestCases(ctx context.Context, contestID string, status string) ([]domain.PlagiarismCase, error) {
+	_, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	return s.plagiarismRepo.GetContestCases(ctx, contestID, status)
+}
+
+// ReviewCase confirms or dismisses a suspected case. Confirming disqualifies
+// the users of the case, or their whole team in team contests, which removes
+// them from the standings.
+func (s *plagiarismService) ReviewCase(ctx context.Context, req *domain.ReviewPlagiarismCaseRequest, reviewerID string) (*domain.PlagiarismCase, error) {
+	plagiarismCase, err := s.plagiarismRepo.GetCaseByID(ctx, req.CaseID)
+	if err != nil {
+		return nil, errors.New("plagiarism case not found")
+	}
+
+	if plagiarismCase.Status != domain.PlagiarismStatusSuspected {
+		return nil, errors.New("plagiarism case has already been reviewed")
+	}
+
+	var userIDs []string
+	if req.Status == domain.PlagiarismStatusConfirmed {
+		userIDs = req.DisqualifyUserIDs
+		if len(userIDs) == 0 {
+			userIDs = []string{plagiarismCase.UserAID, plagiarismCase.UserBID}
+		}
+		for _, userID := range userIDs {
+			if userID != plagiarismCase.UserAID && userID != plagiarismCase.UserBID {
+				return nil, errors.New("user " + userID + " is not part of this plagiarism case")
+			}
+		}
+	}
+
+	now := time.Now()
+	plagiarismCase.Status = req.Status
+	plagiarismCase.Note = req.Note
+	plagiarismCase.ReviewedBy = &reviewerID
+	plagiarismCase.ReviewedAt = &now
+
+	// The disqualifications and the review are stored together, a failure
+	// leaves the case suspected and nobody disqualified
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		for _, userID := range userIDs {
+			err := disqualify(ctx, tx.Registrations(), plagiarismCase.ContestID, userID)
+			if err != nil {
+				return err
+			}
+		}
+		return tx.PlagiarismCases().UpdateCase(ctx, plagiarismCase)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plagiarismCase, nil
+}
+
+// disqualify marks the registration of a user as disqualified, the whole team
+// for team registrations
+func disqualify(ctx context.Context, registrations domain.ContestRegisterRepository, contestID string, userID string) error {
+	registration, err := registrations.GetRegistrationByUserAndContest(ctx, userID, contestID)
+	if err != nil {
+		return errors.New("user " + userID + " is not registered for this contest")
+	}
+
+	if registration.TeamID != nil {
+		return registrations.UpdateTeamRegistrationStatus(ctx, *registration.TeamID, contestID, domain.RegistrationStatusDisqualified)
+	}
+	return registrations.UpdateRegistrationStatus(ctx, userID, contestID, domain.RegistrationStatusDisqualified)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+	"slices"
+)
+
+var (
+	errProblemNotFound  = errors.New("problem not found")
+	errNotProblemAuthor = errors.New("user can only manage their own problems")
+)
+
+// problemAccess decides who can see a problem and who can manage it
+type problemAccess struct {
+	problemRepo domain.ProblemRepository
+	contestRepo domain.ContestRepository
+	contests    *contestAccess
+}
+
+func newProblemAccess(problemRepo domain.ProblemRepository, contestRepo domain.ContestRepository, contests *contestAccess) *problemAccess {
+	return &problemAccess{
+		problemRepo: problemRepo,
+		contestRepo: contestRepo,
+		contests:    contests,
+	}
+}
+
+// canView reports whether the user can see a problem. Authors and admins see
+// every problem and everyone sees public ones. Contest-only problems are
+// shown to users who can see the problems of one of their contests, drafts to
+// nobody else. contestVisibility caches the result per contest across calls.
+func (a *problemAccess) canView(ctx context.Context, problem *domain.Problem, userID string, role string, contestVisibility map[string]bool) (bool, error) {
+	if a.isAuthor(problem, userID, role) {
+		return true, nil
+	}
+
+	if problem.Visibility == domain.ProblemVisibilityPublic {
+		return true, nil
+	}
+	if problem.Visibility != domain.ProblemVisibilityContest {
+		return false, nil
+	}
+
+	contestProblems, err := a.contestRepo.GetContestProblemsByProblemID(ctx, problem.UniqueID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, cp := range contestProblems {
+		canView, ok := contestVisibility[cp.ContestID]
+		if !ok {
+			contest, err := a.contestRepo.GetByID(ctx, cp.ContestID)
+			if err != nil {
+				return false, err
+			}
+			canView, err = a.contests.canSeeProblems(ctx, contest, userID, role)
+			if err != nil {
+				return false, err
+			}
+			contestVisibility[cp.ContestID] = canView
+		}
+		if canView {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isAuthor reports whether the user can manage a problem, its tests,
+// statement, reference solutions and history: admins, its creator and its
+// co-authors
+func (a *problemAccess) isAuthor(problem *domain.Problem, userID string, role string) bool {
+	return role == "admin" || problem.CreatedBy == userID || slices.Contains(problem.CoAuthors, userID)
+}
+
+// requireAuthor returns errNotProblemAuthor unless the user is an author of
+// the problem
+func (a *problemAccess) requireAuthor(problem *domain.Problem, userID string, role string) error {
+	if !a.isAuthor(problem, userID, role) {
+		return errNotProblemAuthor
+	}
+	return nil
+}
+
+// getOwnProblem returns a problem the user is an author of
+func (a *problemAccess) getOwnProblem(ctx context.Context, problemID string, userID string, role string) (*domain.Problem, error) {
+	problem, err := a.problemRepo.GetProblemByID(ctx, problemID)
+	if err != nil {
+		return nil, errProblemNotFound
+	}
+	err = a.requireAuthor(problem, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return problem, nil
+}
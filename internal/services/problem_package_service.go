@@ -0,0 +1,192 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/problempackage"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type problemPackageService struct {
+	problemRepo  domain.ProblemRepository
+	testCaseRepo domain.TestCaseRepository
+	userRepo     domain.UserRepository
+	access       *problemAccess
+	revisions    *problemRevisions
+}
+
+func NewProblemPackageService(problemRepo domain.ProblemRepository, testCaseRepo domain.TestCaseRepository, userRepo domain.UserRepository, contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, revisionRepo domain.ProblemRevisionRepository) domain.ProblemPackageUseCase {
+	return &problemPackageService{
+		problemRepo:  problemRepo,
+		testCaseRepo: testCaseRepo,
+		userRepo:     userRepo,
+		access:       newProblemAccess(problemRepo, contestRepo, newContestAccess(userRepo, contestRegisterRepo)),
+		revisions:    newProblemRevisions(revisionRepo, testCaseRepo),
+	}
+}
+
+func (s *problemPackageService) ImportPackage(ctx context.Context, r io.ReaderAt, size int64, req *domain.ProblemPackageImportRequest, userID string) (*domain.ProblemPackageImportResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.Role != "admin" && user.Role != "problem_setter" {
+		return nil, errors.New("user does not have permission to create problems")
+	}
+
+	pkg, err := problempackage.Read(r, size, req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	difficulty := req.Difficulty
+	if difficulty == "" {
+		difficulty = "medium"
+	}
+
+	problem := &domain.Problem{
+		UniqueID:           uuid.New().String(),
+		Title:              pkg.Title,
+		Statement:          pkg.Statement,
+		Difficulty:         difficulty,
+		Rating:             req.Rating,
+		Tags:               normalizeTags(req.Tags),
+		Visibility:         domain.ProblemVisibilityDraft,
+		Locale:             domain.DefaultLocale,
+		TimeLimitInSeconds: pkg.TimeLimitInSeconds,
+		MemoryLimitInMB:    pkg.MemoryLimitInMB,
+		CreatedBy:          userID,
+		StatementSections: domain.StatementSections{
+			InputFormat:  pkg.InputFormat,
+			OutputFormat: pkg.OutputFormat,
+			Constraints:  pkg.Constraints,
+			Notes:        pkg.Notes,
+		},
+	}
+	// Same defaults as problems created by hand
+	if problem.TimeLimitInSeconds <= 0 {
+		problem.TimeLimitInSeconds = 1
+	}
+	if problem.MemoryLimitInMB <= 0 {
+		problem.MemoryLimitInMB = 256
+	}
+	if pkg.Checker != nil {
+		problem.Checker = pkg.Checker.Source
+		problem.CheckerLanguage = pkg.Checker.Language
+	}
+	if pkg.Validator != nil {
+		problem.Validator = pkg.Validator.Source
+		problem.ValidatorLanguage = pkg.Validator.Language
+	}
+
+	err = s.problemRepo.CreateProblem(ctx, problem)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.ProblemPackageImportResponse{
+		TestCount:    len(pkg.Tests),
+		HasChecker:   pkg.Checker != nil,
+		HasValidator: pkg.Validator != nil,
+	}
+	var created []string
+	for i, test := range pkg.Tests {
+		testCase := &domain.TestCase{
+			UniqueID:       uuid.New().String(),
+			ProblemID:      problem.UniqueID,
+			Input:          test.Input,
+			ExpectedOutput: test.Answer,
+			IsHidden:       !test.Sample,
+			OrderPosition:  i + 1,
+		}
+		err = s.testCaseRepo.CreateTestCase(ctx, testCase)
+		if err != nil {
+			s.discardImport(ctx, problem.UniqueID, created)
+			return nil, errors.New("failed to store test " + test.Name + ": " + err.Error())
+		}
+		created = append(created, testCase.UniqueID)
+		if test.Sample {
+			response.SampleCount++
+		}
+	}
+
+	_, err = s.revisions.record(ctx, problem, userID, "imported from "+pkg.Format+" package")
+	if err != nil {
+		return nil, err
+	}
+
+	response.Problem = domain.ProblemCreationResponse{
+		UniqueID:           problem.UniqueID,
+		Title:              problem.Title,
+		Statement:          problem.Statement,
+		StatementSections:  problem.StatementSections,
+		Difficulty:         problem.Difficulty,
+		Rating:             problem.Rating,
+		Tags:               problem.Tags,
+		Visibility:         problem.Visibility,
+		Locale:             problem.Locale,
+		TimeLimitInSeconds: problem.TimeLimitInSeconds,
+		MemoryLimitInMB:    problem.MemoryLimitInMB,
+		CreatedBy:          problem.CreatedBy,
+		CreatedAt:          problem.CreatedAt,
+		UpdatedAt:          problem.UpdatedAt,
+	}
+	return response, nil
+}
+
+// discardImport removes what a failed import stored, an import creates the
+// whole problem or nothing
+func (s *problemPackageService) discardImport(ctx context.Context, problemID string, testCaseIDs []string) {
+	for _, id := range testCaseIDs {
+		_ = s.testCaseRepo.DeleteTestCase(ctx, id)
+	}
+	_ = s.problemRepo.DeleteProblem(ctx, problemID)
+}
+
+func (s *problemPackageService) ExportPackage(ctx context.Context, problemID string, format string, userID string, role string) ([]byte, error) {
+	// Packages include the hidden tests
+	problem, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	testCases, err := s.testCaseRepo.GetTestCasesByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &problempackage.Package{
+		Title:              problem.Title,
+		Statement:          problem.Statement,
+		InputFormat:        problem.InputFormat,
+		OutputFormat:       problem.OutputFormat,
+		Constraints:        problem.Constraints,
+		Notes:              problem.Notes,
+		TimeLimitInSeconds: problem.TimeLimitInSeconds,
+		MemoryLimitInMB:    problem.MemoryLimitInMB,
+	}
+	for _, testCase := range testCases {
+		pkg.Tests = append(pkg.Tests, problempackage.Test{
+			Input:  testCase.Input,
+			Answer: testCase.ExpectedOutput,
+			Sample: !testCase.IsHidden,
+		})
+	}
+	if problem.Checker != "" {
+		pkg.Checker = &problempackage.Program{Language: problem.CheckerLanguage, Source: problem.Checker}
+	}
+	if problem.Validator != "" {
+		pkg.Validator = &problempackage.Program{Language: problem.ValidatorLanguage, Source: problem.Validator}
+	}
+
+	var buf bytes.Buffer
+	err = problempackage.Write(&buf, pkg, format)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type problemRevisionService struct {
+	problemRepo domain.ProblemRepository
+	access      *problemAccess
+	revisions   *problemRevisions
+	unitOfWork  domain.UnitOfWork
+}
+
+func NewProblemRevisionService(problemRepo domain.ProblemRepository, userRepo domain.UserRepository, contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, revisionRepo domain.ProblemRevisionRepository, testCaseRepo domain.TestCaseRepository, unitOfWork domain.UnitOfWork) domain.ProblemRevisionUseCase {
+	return &problemRevisionService{
+		problemRepo: problemRepo,
+		access:      newProblemAccess(problemRepo, contestRepo, newContestAccess(userRepo, contestRegisterRepo)),
+		revisions:   newProblemRevisions(revisionRepo, testCaseRepo),
+		unitOfWork:  unitOfWork,
+	}
+}
+
+func (s *problemRevisionService) GetRevisions(ctx context.Context, problemID string, userID string, role string) ([]domain.ProblemRevision, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return s.revisions.revisionRepo.GetRevisions(ctx, problemID)
+}
+
+func (s *problemRevisionService) DiffRevisions(ctx context.Context, problemID string, from int, to int, userID string, role string) (*domain.ProblemRevisionDiff, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.revisions.revisionRepo.GetRevision(ctx, problemID, from)
+	if err != nil {
+		return nil, errors.New("revision " + strconv.Itoa(from) + " not found")
+	}
+	toRevision, err := s.revisions.revisionRepo.GetRevision(ctx, problemID, to)
+	if err != nil {
+		return nil, errors.New("revision " + strconv.Itoa(to) + " not found")
+	}
+
+	diff := &domain.ProblemRevisionDiff{
+		ProblemID:      problemID,
+		From:           from,
+		To:             to,
+		Changes:        []domain.FieldChange{},
+		StatementDiff:  []domain.DiffLine{},
+		SectionDiffs:   map[string][]domain.DiffLine{},
+		TestSetChanged: fromRevision.TestSetHash != toRevision.TestSetHash,
+	}
+
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"title", fromRevision.Title, toRevision.Title},
+		{"difficulty", fromRevision.Difficulty, toRevision.Difficulty},
+		{"rating", strconv.Itoa(fromRevision.Rating), strconv.Itoa(toRevision.Rating)},
+		{"tags", strings.Join(fromRevision.Tags, ","), strings.Join(toRevision.Tags, ",")},
+		{"time_limit_in_seconds", strconv.Itoa(fromRevision.TimeLimitInSeconds), strconv.Itoa(toRevision.TimeLimitInSeconds)},
+		{"memory_limit_in_mb", strconv.Itoa(fromRevision.MemoryLimitInMB), strconv.Itoa(toRevision.MemoryLimitInMB)},
+		{"test_count", strconv.Itoa(fromRevision.TestCount), strconv.Itoa(toRevision.TestCount)},
+	}
+	for _, field := range fields {
+		if field.from != field.to {
+			diff.Changes = append(diff.Changes, domain.FieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+
+	if fromRevision.Statement != toRevision.Statement {
+		diff.StatementDiff = diffText(fromRevision.Statement, toRevision.Statement)
+	}
+	sections := []struct {
+		name     string
+		from, to string
+	}{
+		{"input_format", fromRevision.InputFormat, toRevision.InputFormat},
+		{"output_format", fromRevision.OutputFormat, toRevision.OutputFormat},
+		{"constraints", fromRevision.Constraints, toRevision.Constraints},
+		{"notes", fromRevision.Notes, toRevision.Notes},
+	}
+	for _, section := range sections {
+		if section.from != section.to {
+			diff.SectionDiffs[section.name] = diffText(section.from, section.to)
+		}
+	}
+
+	return diff, nil
+}
+
+func (s *problemRevisionService) RollbackProblem(ctx context.Context, problemID string, req *domain.RollbackProblemRequest, userID string, role string) (*domain.ProblemRevision, error) {
+	problem, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.revisions.revisionRepo.GetRevision(ctx, problemID, req.Revision)
+	if err != nil {
+		return nil, errors.New("revision not found")
+	}
+
+	// Only the statement and limits are restored, test cases are edited on
+	// their own and a differing test set shows in the new revision's hash
+	problem.Title = target.Title
+	problem.Statement = target.Statement
+	problem.StatementSections = target.StatementSections
+	problem.Difficulty = target.Difficulty
+	problem.Rating = target.Rating
+	problem.Tags = target.Tags
+	problem.TimeLimitInSeconds = target.TimeLimitInSeconds
+	problem.MemoryLimitInMB = target.MemoryLimitInMB
+
+	// The restored problem and its revision are stored together
+	var revision *domain.ProblemRevision
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		err := tx.Problems().UpdateProblem(ctx, problem)
+		if err != nil {
+			return err
+		}
+		revisions := newProblemRevisions(tx.Revisions(), tx.TestCases())
+		revision, err = revisions.record(ctx, problem, userID, "rollback to revision "+strconv.Itoa(target.Number))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// problemRevisions records problem revisions, it is shared by the services
+// changing a problem or its test cases
+type problemRevisions struct {
+	revisionRepo domain.ProblemRevisionRepository
+	testCaseRepo domain.TestCaseRepository
+}
+
+func newProblemRevisions(revisionRepo domain.ProblemRevisionRepository, testCaseRepo domain.TestCaseRepository) *problemRevisions {
+	return &problemRevisions{
+		revisionRepo: revisionRepo,
+		testCaseRepo: testCaseRepo,
+	}
+}
+
+// record stores the current state of a problem as a new revision, unless it
+// matches the latest revision which is returned instead
+func (r *problemRevisions) record(ctx context.Context, problem *domain.Problem, authorID string, note string) (*domain.ProblemRevision, error) {
+	// The test set is hashed from the hashes of its data, which is not loaded
+	tests, err := r.testCaseRepo.GetTestCaseHashes(ctx, problem.UniqueID)
+	if err != nil {
+		return nil, err
+	}
+	return r.recordWithTests(ctx, problem, tests, authorID, note)
+}
+
+// recordWithTests is record for callers that already loaded the test cases
+// of the problem
+func (r *problemRevisions) recordWithTests(ctx context.Context, problem *domain.Problem, tests []domain.TestCase, authorID string, note string) (*domain.ProblemRevision, error) {
+	revision := &domain.ProblemRevision{
+		ID:                 uuid.New().String(),
+		ProblemID:          problem.UniqueID,
+		Title:              problem.Title,
+		Statement:          problem.Statement,
+		Difficulty:         problem.Difficulty,
+		Rating:             problem.Rating,
+		Tags:               problem.Tags,
+		TimeLimitInSeconds: problem.TimeLimitInSeconds,
+		MemoryLimitInMB:    problem.MemoryLimitInMB,
+		TestSetHash:        domain.HashTestSet(tests),
+		TestCount:          len(tests),
+		Note:               note,
+		CreatedBy:          authorID,
+		StatementSections:  problem.StatementSections,
+	}
+
+	// Most calls, every submission among them, find the problem unchanged
+	// and are answered without taking the problem lock
+	latest, err := r.revisionRepo.GetLatestRevision(ctx, problem.UniqueID)
+	if err == nil && sameRevisionContent(latest, revision) {
+		return latest, nil
+	}
+
+	// Submissions pinned to the revision are judged against these tests
+	revision.Tests = domain.NewProblemRevisionTests(revision.ID, tests)
+
+	return r.revisionRepo.CreateNextRevision(ctx, revision, func(latest *domain.ProblemRevision) bool {
+		return sameRevisionContent(latest, revision)
+	})
+}
+
+func sameRevisionContent(a, b *domain.ProblemRevision) bool {
+	return a.Title == b.Title &&
+		a.Statement == b.Statement &&
+		a.StatementSections == b.StatementSections &&
+		a.Difficulty == b.Difficulty &&
+		a.Rating == b.Rating &&
+		slices.Equal(a.Tags, b.Tags) &&
+		a.TimeLimitInSeconds == b.TimeLimitInSeconds &&
+		a.MemoryLimitInMB == b.MemoryLimitInMB &&
+		a.TestSetHash == b.TestSetHash
+}
+
+func diffText(from, to string) []domain.DiffLine {
+	return diffLines(strings.Split(from, "\n"), strings.Split(to, "\n"))
+}
+
+// diffLines returns a line diff of two texts built from their longest
+// common subsequence
+func diffLines(from, to []string) []domain.DiffLine {
+	// common[i][j] is the length of the longest common subsequence of from[i:] and to[j:]
+	common := make([][]int, len(from)+1)
+	for i := range common {
+		common[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var lines []domain.DiffLine
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			lines = append(lines, domain.DiffLine{Op: "=", Text: from[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, domain.DiffLine{Op: "-", Text: from[i]})
+			i++
+		default:
+			lines = append(lines, domain.DiffLine{Op: "+", Text: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		lines = append(lines, domain.DiffLine{Op: "-", Text: from[i]})
+	}
+	for ; j < len(to); j++ {
+		lines = append(lines, domain.DiffLine{Op: "+", Text: to[j]})
+	}
+	return lines
+}
@@ -2,8 +2,11 @@ package services
 
 import (
 	"algoforces/internal/domain"
+	"algoforces/pkg/storage"
 	"context"
 	"errors"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -11,12 +14,30 @@ import (
 type problemService struct {
 	problemRepo domain.ProblemRepository
 	userRepo    domain.UserRepository
+	contestRepo domain.ContestRepository
+	access      *contestAccess
+	problems    *problemAccess
+
+	testCaseRepo    domain.TestCaseRepository
+	attachmentRepo  domain.ProblemAttachmentRepository
+	translationRepo domain.ProblemTranslationRepository
+	unitOfWork      domain.UnitOfWork
+	storage         storage.BlobStorage
 }
 
-func NewProblemService(problemRepo domain.ProblemRepository, userRepo domain.UserRepository) domain.ProblemUseCase {
+func NewProblemService(problemRepo domain.ProblemRepository, userRepo domain.UserRepository, contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, testCaseRepo domain.TestCaseRepository, attachmentRepo domain.ProblemAttachmentRepository, translationRepo domain.ProblemTranslationRepository, unitOfWork domain.UnitOfWork, storage storage.BlobStorage) domain.ProblemUseCase {
+	access := newContestAccess(userRepo, contestRegisterRepo)
 	return &problemService{
-		problemRepo: problemRepo,
-		userRepo:    userRepo,
+		problemRepo:     problemRepo,
+		userRepo:        userRepo,
+		contestRepo:     contestRepo,
+		access:          access,
+		problems:        newProblemAccess(problemRepo, contestRepo, access),
+		testCaseRepo:    testCaseRepo,
+		attachmentRepo:  attachmentRepo,
+		translationRepo: translationRepo,
+		unitOfWork:      unitOfWork,
+		storage:         storage,
 	}
 }
 
@@ -31,6 +52,16 @@ func (s *problemService) CreateProblem(ctx context.Context, req *domain.ProblemC
 		return nil, errors.New("user does not have permission to create problems")
 	}
 
+	err = s.checkCoAuthors(ctx, req.CoAuthors)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateStatementMath(req.Statement, req.StatementSections)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set defaults if not provided
 	if req.TimeLimitInSeconds == 0 {
 		req.TimeLimitInSeconds = 1 // default 1 second
@@ -43,13 +74,27 @@ func (s *problemService) CreateProblem(ctx context.Context, req *domain.ProblemC
 		UniqueID:           uuid.New().String(),
 		Title:              req.Title,
 		Statement:          req.Statement,
+		StatementSections:  req.StatementSections,
 		Difficulty:         req.Difficulty,
+		Rating:             req.Rating,
+		Tags:               normalizeTags(req.Tags),
+		Visibility:         problemVisibility(req.Visibility),
+		Locale:             problemLocale(req.Locale),
 		TimeLimitInSeconds: req.TimeLimitInSeconds,
 		MemoryLimitInMB:    req.MemoryLimitInMB,
 		CreatedBy:          createdBy,
+		CoAuthors:          req.CoAuthors,
 	}
 
-	err = s.problemRepo.CreateProblem(ctx, problem)
+	// The problem and its initial revision are stored together
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		err := tx.Problems().CreateProblem(ctx, problem)
+		if err != nil {
+			return err
+		}
+		_, err = newProblemRevisions(tx.Revisions(), tx.TestCases()).record(ctx, problem, createdBy, "initial revision")
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -58,10 +103,16 @@ func (s *problemService) CreateProblem(ctx context.Context, req *domain.ProblemC
 		UniqueID:           problem.UniqueID,
 		Title:              problem.Title,
 		Statement:          problem.Statement,
+		StatementSections:  problem.StatementSections,
 		Difficulty:         problem.Difficulty,
+		Rating:             problem.Rating,
+		Tags:               problem.Tags,
+		Visibility:         problem.Visibility,
+		Locale:             problem.Locale,
 		TimeLimitInSeconds: problem.TimeLimitInSeconds,
 		MemoryLimitInMB:    problem.MemoryLimitInMB,
 		CreatedBy:          problem.CreatedBy,
+		CoAuthors:          problem.CoAuthors,
 		CreatedAt:          problem.CreatedAt,
 		UpdatedAt:          problem.UpdatedAt,
 	}, nil
@@ -80,10 +131,23 @@ func (s *problemService) CreateProblemsInBulk(ctx context.Context, req *domain.B
 
 	response := &domain.BulkProblemCreationResponse{
 		Problems: []domain.ProblemCreationResponse{},
-		Errors:   []string{},
+		Errors:   []domain.BulkItemError{},
 	}
 
+	// Every problem is checked before any is stored
+	problems := make([]*domain.Problem, len(req.Problems))
 	for i, problemReq := range req.Problems {
+		err = validateStatementMath(problemReq.Statement, problemReq.StatementSections)
+		if err != nil {
+			response.Errors = append(response.Errors, domain.BulkItemError{Index: i, Error: err.Error()})
+			continue
+		}
+		err = s.checkCoAuthors(ctx, problemReq.CoAuthors)
+		if err != nil {
+			response.Errors = append(response.Errors, domain.BulkItemError{Index: i, Error: err.Error()})
+			continue
+		}
+
 		// Set defaults if not provided
 		if problemReq.TimeLimitInSeconds == 0 {
 			problemReq.TimeLimitInSeconds = 1
@@ -92,32 +156,87 @@ func (s *problemService) CreateProblemsInBulk(ctx context.Context, req *domain.B
 			problemReq.MemoryLimitInMB = 256
 		}
 
-		problem := &domain.Problem{
+		problems[i] = &domain.Problem{
 			UniqueID:           uuid.New().String(),
 			Title:              problemReq.Title,
 			Statement:          problemReq.Statement,
+			StatementSections:  problemReq.StatementSections,
 			Difficulty:         problemReq.Difficulty,
+			Rating:             problemReq.Rating,
+			Tags:               normalizeTags(problemReq.Tags),
+			Visibility:         problemVisibility(problemReq.Visibility),
+			Locale:             problemLocale(problemReq.Locale),
 			TimeLimitInSeconds: problemReq.TimeLimitInSeconds,
 			MemoryLimitInMB:    problemReq.MemoryLimitInMB,
 			CreatedBy:          createdBy,
+			CoAuthors:          problemReq.CoAuthors,
 		}
+	}
 
-		err = s.problemRepo.CreateProblem(ctx, problem)
+	// createProblem stores a problem with its initial revision
+	createProblem := func(tx domain.Transaction, problem *domain.Problem) error {
+		err := tx.Problems().CreateProblem(ctx, problem)
 		if err != nil {
-			response.FailedCount++
-			response.Errors = append(response.Errors, "problem '"+problemReq.Title+"' at index "+string(rune(i+'0'))+" failed: "+err.Error())
-			continue
+			return err
+		}
+		_, err = newProblemRevisions(tx.Revisions(), tx.TestCases()).record(ctx, problem, createdBy, "initial revision")
+		return err
+	}
+
+	var created []*domain.Problem
+	if req.Mode == domain.BulkModeBestEffort {
+		for i, problem := range problems {
+			if problem == nil {
+				continue
+			}
+			err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+				return createProblem(tx, problem)
+			})
+			if err != nil {
+				response.Errors = append(response.Errors, domain.BulkItemError{Index: i, Error: err.Error()})
+				continue
+			}
+			created = append(created, problem)
+		}
+	} else if len(response.Errors) == 0 {
+		err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+			for i, problem := range problems {
+				err := createProblem(tx, problem)
+				if err != nil {
+					response.Errors = append(response.Errors, domain.BulkItemError{Index: i, Error: err.Error()})
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil && len(response.Errors) == 0 {
+			return nil, err
 		}
+		if err == nil {
+			created = problems
+		}
+	}
 
-		response.SuccessCount++
+	slices.SortFunc(response.Errors, func(a, b domain.BulkItemError) int {
+		return a.Index - b.Index
+	})
+	response.FailedCount = len(response.Errors)
+	response.SuccessCount = len(created)
+	for _, problem := range created {
 		response.Problems = append(response.Problems, domain.ProblemCreationResponse{
 			UniqueID:           problem.UniqueID,
 			Title:              problem.Title,
 			Statement:          problem.Statement,
+			StatementSections:  problem.StatementSections,
 			Difficulty:         problem.Difficulty,
+			Rating:             problem.Rating,
+			Tags:               problem.Tags,
+			Visibility:         problem.Visibility,
+			Locale:             problem.Locale,
 			TimeLimitInSeconds: problem.TimeLimitInSeconds,
 			MemoryLimitInMB:    problem.MemoryLimitInMB,
 			CreatedBy:          problem.CreatedBy,
+			CoAuthors:          problem.CoAuthors,
 			CreatedAt:          problem.CreatedAt,
 			UpdatedAt:          problem.UpdatedAt,
 		})
@@ -126,22 +245,42 @@ func (s *problemService) CreateProblemsInBulk(ctx context.Context, req *domain.B
 	return response, nil
 }
 
-func (s *problemService) GetProblemByID(ctx context.Context, id string) (*domain.ProblemCreationResponse, error) {
+func (s *problemService) GetProblemByID(ctx context.Context, id string, userID string, role string, locales []string) (*domain.ProblemCreationResponse, error) {
 	problem, err := s.problemRepo.GetProblemByID(ctx, id)
 	if err != nil {
 		return nil, errors.New("problem not found")
 	}
 
+	canView, err := s.problems.canView(ctx, problem, userID, role, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("problem not found")
+	}
+
+	availableLocales, err := s.localizeProblem(ctx, problem, locales)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.ProblemCreationResponse{
 		UniqueID:           problem.UniqueID,
 		Title:              problem.Title,
 		Statement:          problem.Statement,
+		StatementSections:  problem.StatementSections,
 		Difficulty:         problem.Difficulty,
+		Rating:             problem.Rating,
+		Tags:               problem.Tags,
+		Visibility:         problem.Visibility,
+		Locale:             problem.Locale,
 		TimeLimitInSeconds: problem.TimeLimitInSeconds,
 		MemoryLimitInMB:    problem.MemoryLimitInMB,
 		CreatedBy:          problem.CreatedBy,
+		CoAuthors:          problem.CoAuthors,
 		CreatedAt:          problem.CreatedAt,
 		UpdatedAt:          problem.UpdatedAt,
+		AvailableLocales:   availableLocales,
 	}, nil
 }
 
@@ -162,9 +301,25 @@ func (s *problemService) UpdateProblem(ctx context.Context, req *domain.ProblemU
 		return nil, errors.New("problem not found")
 	}
 
-	// Check if user is the creator or admin
-	if user.Role != "admin" && existingProblem.CreatedBy != userID {
-		return nil, errors.New("user can only update their own problems")
+	err = s.problems.requireAuthor(existingProblem, userID, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Co-authors cannot hand the problem to others
+	if req.CoAuthors != nil {
+		if user.Role != "admin" && existingProblem.CreatedBy != userID {
+			return nil, errors.New("only the creator of a problem can change its co-authors")
+		}
+		err = s.checkCoAuthors(ctx, req.CoAuthors)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = validateStatementMath(req.Statement, req.StatementSections)
+	if err != nil {
+		return nil, err
 	}
 
 	// Set defaults if not provided
@@ -178,11 +333,31 @@ func (s *problemService) UpdateProblem(ctx context.Context, req *domain.ProblemU
 	// Update problem
 	existingProblem.Title = req.Title
 	existingProblem.Statement = req.Statement
+	existingProblem.StatementSections = req.StatementSections
 	existingProblem.Difficulty = req.Difficulty
+	existingProblem.Rating = req.Rating
+	existingProblem.Tags = normalizeTags(req.Tags)
+	if req.Visibility != "" {
+		existingProblem.Visibility = req.Visibility
+	}
+	if req.Locale != "" {
+		existingProblem.Locale = req.Locale
+	}
+	if req.CoAuthors != nil {
+		existingProblem.CoAuthors = req.CoAuthors
+	}
 	existingProblem.TimeLimitInSeconds = req.TimeLimitInSeconds
 	existingProblem.MemoryLimitInMB = req.MemoryLimitInMB
 
-	err = s.problemRepo.UpdateProblem(ctx, existingProblem)
+	// The edit and its revision are stored together
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		err := tx.Problems().UpdateProblem(ctx, existingProblem)
+		if err != nil {
+			return err
+		}
+		_, err = newProblemRevisions(tx.Revisions(), tx.TestCases()).record(ctx, existingProblem, userID, "")
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -191,10 +366,16 @@ func (s *problemService) UpdateProblem(ctx context.Context, req *domain.ProblemU
 		UniqueID:           existingProblem.UniqueID,
 		Title:              existingProblem.Title,
 		Statement:          existingProblem.Statement,
+		StatementSections:  existingProblem.StatementSections,
 		Difficulty:         existingProblem.Difficulty,
+		Rating:             existingProblem.Rating,
+		Tags:               existingProblem.Tags,
+		Visibility:         existingProblem.Visibility,
+		Locale:             existingProblem.Locale,
 		TimeLimitInSeconds: existingProblem.TimeLimitInSeconds,
 		MemoryLimitInMB:    existingProblem.MemoryLimitInMB,
 		CreatedBy:          existingProblem.CreatedBy,
+		CoAuthors:          existingProblem.CoAuthors,
 		CreatedAt:          existingProblem.CreatedAt,
 		UpdatedAt:          existingProblem.UpdatedAt,
 	}, nil
@@ -217,34 +398,118 @@ func (s *problemService) DeleteProblem(ctx context.Context, id string, userID st
 		return errors.New("problem not found")
 	}
 
-	// Check if user is the creator or admin
-	if user.Role != "admin" && existingProblem.CreatedBy != userID {
-		return errors.New("user can only delete their own problems")
+	err = s.problems.requireAuthor(existingProblem, userID, user.Role)
+	if err != nil {
+		return err
 	}
 
 	return s.problemRepo.DeleteProblem(ctx, id)
 }
 
-func (s *problemService) GetAllProblems(ctx context.Context) ([]domain.ProblemCreationResponse, error) {
-	problems, err := s.problemRepo.GetAllProblems(ctx)
+// GetAllProblems lists the problems matching the filter. Users who are not
+// admins only get the problems problemAccess.canView would show them, the rule is
+// applied in the query so pages and totals stay consistent.
+func (s *problemService) GetAllProblems(ctx context.Context, filter *domain.ProblemFilter, userID string, role string) (*domain.ProblemListResponse, error) {
+	if filter.MinRating > 0 && filter.MaxRating > 0 && filter.MinRating > filter.MaxRating {
+		return nil, errors.New("min_rating cannot be greater than max_rating")
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = domain.DefaultProblemPageSize
+	}
+	filter.Tags = normalizeTags(filter.Tags)
+
+	if role != "admin" {
+		filter.RestrictVisibility = true
+		filter.ViewerID = userID
+	}
+
+	problems, total, err := s.problemRepo.SearchProblems(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.localizeProblems(ctx, problems, filter.Locales)
 	if err != nil {
 		return nil, err
 	}
 
-	var problemResponses []domain.ProblemCreationResponse
-	for _, problem := range problems {
-		problemResponses = append(problemResponses, domain.ProblemCreationResponse{
+	response := &domain.ProblemListResponse{
+		Problems: []domain.ProblemCreationResponse{},
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}
+	for i := range problems {
+		problem := &problems[i]
+		response.Problems = append(response.Problems, domain.ProblemCreationResponse{
 			UniqueID:           problem.UniqueID,
 			Title:              problem.Title,
 			Statement:          problem.Statement,
+			StatementSections:  problem.StatementSections,
 			Difficulty:         problem.Difficulty,
+			Rating:             problem.Rating,
+			Tags:               problem.Tags,
+			Visibility:         problem.Visibility,
+			Locale:             problem.Locale,
 			TimeLimitInSeconds: problem.TimeLimitInSeconds,
 			MemoryLimitInMB:    problem.MemoryLimitInMB,
 			CreatedBy:          problem.CreatedBy,
+			CoAuthors:          problem.CoAuthors,
 			CreatedAt:          problem.CreatedAt,
 			UpdatedAt:          problem.UpdatedAt,
 		})
 	}
 
-	return problemResponses, nil
+	return response, nil
+}
+
+// GetAllTags counts the tags of the problems GetAllProblems would list to the user
+func (s *problemService) GetAllTags(ctx context.Context, userID string, role string) ([]domain.TagCount, error) {
+	if role == "admin" {
+		return s.problemRepo.GetAllTags(ctx, "")
+	}
+	return s.problemRepo.GetAllTags(ctx, userID)
+}
+
+// problemVisibility defaults the visibility of a new problem to draft
+func problemVisibility(visibility string) string {
+	if visibility == "" {
+		return domain.ProblemVisibilityDraft
+	}
+	return visibility
+}
+
+// normalizeTags lowercases and trims tags and drops empty and duplicate ones.
+// Comma separated tags are split, as filters may pass them in one parameter.
+// checkCoAuthors rejects co-authors who are not problem setters
+func (s *problemService) checkCoAuthors(ctx context.Context, coAuthors []string) error {
+	for _, coAuthorID := range coAuthors {
+		user, err := s.userRepo.GetByID(ctx, coAuthorID)
+		if err != nil {
+			return errors.New("co-author " + coAuthorID + " not found")
+		}
+		if user.Role != "problem_setter" && user.Role != "admin" {
+			return errors.New("co-author " + coAuthorID + " is not authorized as problem setter")
+		}
+	}
+	return nil
+}
+
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, value := range tags {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
 }
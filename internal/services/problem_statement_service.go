@@ -0,0 +1,246 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/storage"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// attachmentNamePattern is the file names attachments can be stored and referenced under
+var attachmentNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// attachmentReference matches attachment:<file name> in statement Markdown
+var attachmentReference = regexp.MustCompile(`attachment:([A-Za-z0-9][A-Za-z0-9._-]{0,127})`)
+
+func (s *problemService) GetProblemStatement(ctx context.Context, id string, userID string, role string, locales []string) (*domain.ProblemStatement, error) {
+	problem, err := s.problemRepo.GetProblemByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("problem not found")
+	}
+	canView, err := s.problems.canView(ctx, problem, userID, role, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("problem not found")
+	}
+
+	availableLocales, err := s.localizeProblem(ctx, problem, locales)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetAttachments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	urls := make(map[string]string, len(attachments))
+	for _, attachment := range attachments {
+		urls[attachment.FileName] = attachmentURL(id, attachment.FileName)
+	}
+
+	statement := &domain.ProblemStatement{
+		ProblemID:          problem.UniqueID,
+		Title:              problem.Title,
+		Locale:             problem.Locale,
+		AvailableLocales:   availableLocales,
+		TimeLimitInSeconds: problem.TimeLimitInSeconds,
+		MemoryLimitInMB:    problem.MemoryLimitInMB,
+		Sections:           []domain.StatementSection{},
+		Samples:            []domain.StatementSample{},
+		Attachments:        attachments,
+	}
+	for _, section := range statementSections(problem) {
+		if strings.TrimSpace(section.Markdown) == "" {
+			continue
+		}
+		section.Markdown = attachmentReference.ReplaceAllStringFunc(section.Markdown, func(reference string) string {
+			if url, ok := urls[strings.TrimPrefix(reference, "attachment:")]; ok {
+				return url
+			}
+			return reference
+		})
+		statement.Sections = append(statement.Sections, section)
+	}
+
+	// Samples are the visible test cases, in judging order
+	testCases, err := s.testCaseRepo.GetTestCasesByProblemID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, testCase := range testCases {
+		if !testCase.IsHidden {
+			statement.Samples = append(statement.Samples, domain.StatementSample{
+				Input:  testCase.Input,
+				Output: testCase.ExpectedOutput,
+			})
+		}
+	}
+
+	return statement, nil
+}
+
+func (s *problemService) UploadAttachment(ctx context.Context, problemID string, fileName string, contentType string, r io.Reader, userID string, role string) (*domain.ProblemAttachment, error) {
+	_, err := s.problems.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !attachmentNamePattern.MatchString(fileName) {
+		return nil, errors.New("invalid attachment file name, use letters, digits, dots, dashes and underscores")
+	}
+
+	if contentType == "" || contentType == "application/octet-stream" {
+		if byExtension := mime.TypeByExtension(path.Ext(fileName)); byExtension != "" {
+			contentType = byExtension
+		} else {
+			contentType = "application/octet-stream"
+		}
+	}
+
+	attachment := &domain.ProblemAttachment{
+		ID:          uuid.New().String(),
+		ProblemID:   problemID,
+		FileName:    fileName,
+		ContentType: contentType,
+		CreatedBy:   userID,
+	}
+	attachment.StorageKey = "problems/" + problemID + "/attachments/" + attachment.ID
+
+	// Read one byte past the limit to tell a file of exactly the limit from a larger one
+	attachment.Size, err = s.storage.Put(ctx, attachment.StorageKey, io.LimitReader(r, domain.MaxAttachmentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if attachment.Size > domain.MaxAttachmentSize {
+		_ = s.storage.Delete(ctx, attachment.StorageKey)
+		return nil, fmt.Errorf("attachment is larger than %d MB", domain.MaxAttachmentSize>>20)
+	}
+
+	// A file uploaded again under the same name replaces the previous one
+	previous, err := s.attachmentRepo.GetAttachment(ctx, problemID, fileName)
+	if err == nil {
+		err = s.attachmentRepo.DeleteAttachment(ctx, previous.ID)
+		if err != nil {
+			_ = s.storage.Delete(ctx, attachment.StorageKey)
+			return nil, err
+		}
+	}
+
+	err = s.attachmentRepo.CreateAttachment(ctx, attachment)
+	if err != nil {
+		_ = s.storage.Delete(ctx, attachment.StorageKey)
+		return nil, err
+	}
+	if previous != nil {
+		_ = s.storage.Delete(ctx, previous.StorageKey)
+	}
+	return attachment, nil
+}
+
+func (s *problemService) OpenAttachment(ctx context.Context, problemID string, fileName string, userID string, role string) (*domain.ProblemAttachment, io.ReadCloser, error) {
+	problem, err := s.problemRepo.GetProblemByID(ctx, problemID)
+	if err != nil {
+		return nil, nil, errors.New("problem not found")
+	}
+	canView, err := s.problems.canView(ctx, problem, userID, role, map[string]bool{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canView {
+		return nil, nil, errors.New("problem not found")
+	}
+
+	attachment, err := s.attachmentRepo.GetAttachment(ctx, problemID, fileName)
+	if err != nil {
+		return nil, nil, errors.New("attachment not found")
+	}
+	content, err := s.storage.Get(ctx, attachment.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, errors.New("attachment not found")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (s *problemService) DeleteAttachment(ctx context.Context, problemID string, fileName string, userID string, role string) error {
+	_, err := s.problems.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := s.attachmentRepo.GetAttachment(ctx, problemID, fileName)
+	if err != nil {
+		return errors.New("attachment not found")
+	}
+	err = s.attachmentRepo.DeleteAttachment(ctx, attachment.ID)
+	if err != nil {
+		return err
+	}
+	return s.storage.Delete(ctx, attachment.StorageKey)
+}
+
+func attachmentURL(problemID string, fileName string) string {
+	return "/api/problem/" + problemID + "/attachments/" + fileName
+}
+
+// statementSections lists the sections of a problem statement in display order
+func statementSections(problem *domain.Problem) []domain.StatementSection {
+	return []domain.StatementSection{
+		{Name: "legend", Title: "", Markdown: problem.Statement},
+		{Name: "input_format", Title: "Input", Markdown: problem.InputFormat},
+		{Name: "output_format", Title: "Output", Markdown: problem.OutputFormat},
+		{Name: "constraints", Title: "Constraints", Markdown: problem.Constraints},
+		{Name: "notes", Title: "Notes", Markdown: problem.Notes},
+	}
+}
+
+// validateStatementMath reports statement sections with an unbalanced $ math
+// delimiter, which would swallow the rest of the section when rendered.
+// Code spans and blocks are skipped, a literal dollar is written \$.
+func validateStatementMath(statement string, sections domain.StatementSections) error {
+	problem := &domain.Problem{Statement: statement, StatementSections: sections}
+	for _, section := range statementSections(problem) {
+		if countMathDelimiters(section.Markdown)%2 != 0 {
+			return errors.New("unbalanced $ math delimiter in the " + strings.ReplaceAll(section.Name, "_", " ") + ", write a literal dollar as \\$")
+		}
+	}
+	return nil
+}
+
+func countMathDelimiters(markdown string) int {
+	count := 0
+	inFence := false
+	for _, line := range strings.Split(markdown, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		inCode := false
+		for i := 0; i < len(line); i++ {
+			switch {
+			case line[i] == '\\':
+				i++ // escaped character
+			case line[i] == '`':
+				inCode = !inCode
+			case line[i] == '$' && !inCode:
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+func (s *problemService) SaveTranslation(ctx context.Context, problemID string, locale string, req *domain.ProblemTranslationRequest, userID string, role string) (*domain.ProblemTranslation, error) {
+	problem, err := s.problems.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(domain.SupportedLocales, locale) {
+		return nil, errors.New("unsupported locale " + locale)
+	}
+	if locale == problemLocale(problem.Locale) {
+		return nil, errors.New("the problem is written in " + locale + ", update the problem instead")
+	}
+
+	err = validateStatementMath(req.Statement, req.StatementSections)
+	if err != nil {
+		return nil, err
+	}
+
+	translation := &domain.ProblemTranslation{
+		ID:                uuid.New().String(),
+		ProblemID:         problemID,
+		Locale:            locale,
+		Title:             req.Title,
+		Statement:         req.Statement,
+		UpdatedBy:         userID,
+		StatementSections: req.StatementSections,
+	}
+	// Keep the ID of the translation being replaced
+	translations, err := s.translationRepo.GetTranslations(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range translations {
+		if existing.Locale == locale {
+			translation.ID = existing.ID
+			translation.CreatedAt = existing.CreatedAt
+		}
+	}
+
+	err = s.translationRepo.SaveTranslation(ctx, translation)
+	if err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (s *problemService) GetTranslations(ctx context.Context, problemID string, userID string, role string) ([]domain.ProblemTranslation, error) {
+	problem, err := s.problemRepo.GetProblemByID(ctx, problemID)
+	if err != nil {
+		return nil, errors.New("problem not found")
+	}
+	canView, err := s.problems.canView(ctx, problem, userID, role, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("problem not found")
+	}
+
+	return s.translationRepo.GetTranslations(ctx, problemID)
+}
+
+func (s *problemService) DeleteTranslation(ctx context.Context, problemID string, locale string, userID string, role string) error {
+	_, err := s.problems.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.translationRepo.DeleteTranslation(ctx, problemID, locale)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.New("translation not found")
+	}
+	return nil
+}
+
+// localizeProblem loads the translations of a problem and switches its title
+// and statement to the first preferred locale available. The problem is only
+// changed in memory. It returns the locales the problem can be read in.
+func (s *problemService) localizeProblem(ctx context.Context, problem *domain.Problem, preferred []string) ([]string, error) {
+	translations, err := s.translationRepo.GetTranslations(ctx, problem.UniqueID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Listed before the translation replaces the problem's locale
+	locales := availableLocales(problem, translations)
+	applyTranslation(problem, negotiateTranslation(problem, translations, preferred))
+	return locales, nil
+}
+
+// localizeProblems switches a page of problems to the preferred locales
+func (s *problemService) localizeProblems(ctx context.Context, problems []domain.Problem, preferred []string) error {
+	if len(preferred) == 0 || len(problems) == 0 {
+		return nil
+	}
+
+	problemIDs := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		problemIDs = append(problemIDs, problem.UniqueID)
+	}
+	translations, err := s.translationRepo.GetTranslationsForProblems(ctx, problemIDs, preferred)
+	if err != nil {
+		return err
+	}
+
+	byProblem := map[string][]domain.ProblemTranslation{}
+	for _, translation := range translations {
+		byProblem[translation.ProblemID] = append(byProblem[translation.ProblemID], translation)
+	}
+	for i := range problems {
+		applyTranslation(&problems[i], negotiateTranslation(&problems[i], byProblem[problems[i].UniqueID], preferred))
+	}
+	return nil
+}
+
+// negotiateTranslation returns the translation of the first preferred locale,
+// or nil when the problem's own language comes first or nothing matches
+func negotiateTranslation(problem *domain.Problem, translations []domain.ProblemTranslation, preferred []string) *domain.ProblemTranslation {
+	for _, locale := range preferred {
+		if locale == problemLocale(problem.Locale) {
+			return nil
+		}
+		for i := range translations {
+			if translations[i].Locale == locale {
+				return &translations[i]
+			}
+		}
+	}
+	return nil
+}
+
+func applyTranslation(problem *domain.Problem, translation *domain.ProblemTranslation) {
+	problem.Locale = problemLocale(problem.Locale)
+	if translation == nil {
+		return
+	}
+	problem.Title = translation.Title
+	problem.Statement = translation.Statement
+	problem.StatementSections = translation.StatementSections
+	problem.Locale = translation.Locale
+}
+
+// availableLocales lists the problem's own language and its translations, in
+// the order of domain.SupportedLocales
+func availableLocales(problem *domain.Problem, translations []domain.ProblemTranslation) []string {
+	available := map[string]bool{}
+	for _, translation := range translations {
+		available[translation.Locale] = true
+	}
+
+	var locales []string
+	for _, locale := range domain.SupportedLocales {
+		if available[locale] || locale == problemLocale(problem.Locale) {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+func problemLocale(locale string) string {
+	if locale == "" {
+		return domain.DefaultLocale
+	}
+	return locale
+}
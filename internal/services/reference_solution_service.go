@@ -0,0 +1,240 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/queue"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+type referenceSolutionService struct {
+	solutionRepo    domain.ReferenceSolutionRepository
+	problemRepo     domain.ProblemRepository
+	access          *problemAccess
+	revisionRepo    domain.ProblemRevisionRepository
+	testCaseRepo    domain.TestCaseRepository
+	queue           queue.ReferenceSolutionQueueInterface
+	timeLimitFactor float64
+}
+
+func NewReferenceSolutionService(solutionRepo domain.ReferenceSolutionRepository, problemRepo domain.ProblemRepository, userRepo domain.UserRepository, contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, revisionRepo domain.ProblemRevisionRepository, testCaseRepo domain.TestCaseRepository, queue queue.ReferenceSolutionQueueInterface, timeLimitFactor float64) domain.ReferenceSolutionUseCase {
+	return &referenceSolutionService{
+		solutionRepo:    solutionRepo,
+		problemRepo:     problemRepo,
+		access:          newProblemAccess(problemRepo, contestRepo, newContestAccess(userRepo, contestRegisterRepo)),
+		revisionRepo:    revisionRepo,
+		testCaseRepo:    testCaseRepo,
+		queue:           queue,
+		timeLimitFactor: timeLimitFactor,
+	}
+}
+
+func (s *referenceSolutionService) AddSolution(ctx context.Context, problemID string, req *domain.CreateReferenceSolutionRequest, userID string, role string) (*domain.ReferenceSolution, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	// The time limit is suggested from a single main solution
+	if req.Kind == domain.ReferenceSolutionMain {
+		solutions, err := s.solutionRepo.GetSolutions(ctx, problemID)
+		if err != nil {
+			return nil, err
+		}
+		for _, solution := range solutions {
+			if solution.Kind == domain.ReferenceSolutionMain {
+				return nil, errors.New("the problem already has a main solution")
+			}
+		}
+	}
+
+	solution := &domain.ReferenceSolution{
+		ID:        uuid.New().String(),
+		ProblemID: problemID,
+		Name:      req.Name,
+		Kind:      req.Kind,
+		Language:  req.Language,
+		Code:      req.Code,
+		CreatedBy: userID,
+		Status:    domain.ReferenceRunNotRun,
+	}
+	err = s.solutionRepo.CreateSolution(ctx, solution)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solutions added before the tests are run with RunSolutions
+	testCases, err := s.testCaseRepo.GetTestCasesByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	if len(testCases) == 0 {
+		return solution, nil
+	}
+
+	err = s.startRun(ctx, solution.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.solutionRepo.GetSolution(ctx, solution.ID)
+}
+
+func (s *referenceSolutionService) GetSolutions(ctx context.Context, problemID string, userID string, role string) ([]domain.ReferenceSolution, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.solutionRepo.GetSolutions(ctx, problemID)
+}
+
+func (s *referenceSolutionService) DeleteSolution(ctx context.Context, problemID string, solutionID string, userID string, role string) error {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	solution, err := s.solutionRepo.GetSolution(ctx, solutionID)
+	if err != nil || solution.ProblemID != problemID {
+		return errors.New("reference solution not found")
+	}
+
+	// A queued run finds the solution gone and is skipped
+	return s.solutionRepo.DeleteSolution(ctx, solutionID)
+}
+
+func (s *referenceSolutionService) RunSolutions(ctx context.Context, problemID string, userID string, role string) ([]domain.ReferenceSolution, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	testCases, err := s.testCaseRepo.GetTestCasesByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	if len(testCases) == 0 {
+		return nil, errors.New("the problem has no test cases")
+	}
+
+	solutions, err := s.solutionRepo.GetSolutions(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	if len(solutions) == 0 {
+		return nil, errors.New("the problem has no reference solutions")
+	}
+
+	for _, solution := range solutions {
+		err = s.startRun(ctx, solution.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.solutionRepo.GetSolutions(ctx, problemID)
+}
+
+func (s *referenceSolutionService) GetReport(ctx context.Context, problemID string, userID string, role string) (*domain.ReferenceSolutionReport, error) {
+	problem, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	solutions, err := s.solutionRepo.GetSolutions(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	var latestRevisionID string
+	revision, err := s.revisionRepo.GetLatestRevision(ctx, problemID)
+	if err == nil {
+		latestRevisionID = revision.ID
+	}
+
+	report := &domain.ReferenceSolutionReport{
+		ProblemID:          problemID,
+		Solutions:          solutions,
+		TimeLimitInSeconds: problem.TimeLimitInSeconds,
+		TimeLimitFactor:    s.timeLimitFactor,
+		Warnings:           []string{},
+	}
+
+	verified := true
+	var main *domain.ReferenceSolution
+	for i := range solutions {
+		solution := &solutions[i]
+		if solution.Kind == domain.ReferenceSolutionMain {
+			main = solution
+		}
+
+		switch solution.Status {
+		case domain.ReferenceRunNotRun:
+			verified = false
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s solution %q was never run", solution.Kind, solution.Name))
+			continue
+		case domain.ReferenceRunPending, domain.ReferenceRunRunning:
+			verified = false
+			report.Pending++
+			continue
+		}
+
+		if solution.ExpectationMet == nil || !*solution.ExpectationMet {
+			verified = false
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s solution %q got %s", solution.Kind, solution.Name, solution.Verdict))
+		}
+		judgedRevisionID := ""
+		if solution.ProblemRevisionID != nil {
+			judgedRevisionID = *solution.ProblemRevisionID
+		}
+		if judgedRevisionID != latestRevisionID {
+			verified = false
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s solution %q was judged against an older revision of the problem", solution.Kind, solution.Name))
+		}
+	}
+
+	if main == nil {
+		report.Warnings = append(report.Warnings, "the problem has no main solution")
+		return report, nil
+	}
+	report.Verified = verified
+
+	if main.Status != domain.ReferenceRunDone || main.Verdict != string(domain.VerdictAccepted) {
+		return report, nil
+	}
+
+	// Time limits are whole seconds
+	report.MainMaxTimeInMS = main.MaxTimeInMS
+	report.SuggestedTimeLimitInSeconds = int(math.Ceil(main.MaxTimeInMS * s.timeLimitFactor / 1000))
+	if report.SuggestedTimeLimitInSeconds < 1 {
+		report.SuggestedTimeLimitInSeconds = 1
+	}
+	suggestedInMS := float64(report.SuggestedTimeLimitInSeconds) * 1000
+
+	for _, solution := range solutions {
+		if solution.Status != domain.ReferenceRunDone {
+			continue
+		}
+		switch {
+		case solution.Kind == domain.ReferenceSolutionCorrect && solution.MaxTimeInMS > suggestedInMS:
+			report.Warnings = append(report.Warnings, fmt.Sprintf("correct solution %q runs in %.0f ms, over the suggested time limit", solution.Name, solution.MaxTimeInMS))
+		case solution.Kind == domain.ReferenceSolutionTimeLimit && report.SuggestedTimeLimitInSeconds > problem.TimeLimitInSeconds:
+			report.Warnings = append(report.Warnings, fmt.Sprintf("time_limit solution %q may pass with the suggested time limit, run the solutions again after changing it", solution.Name))
+		}
+	}
+
+	return report, nil
+}
+
+// startRun marks a solution pending under a new run and queues the run
+func (s *referenceSolutionService) startRun(ctx context.Context, solutionID string) error {
+	runID := uuid.New().String()
+	err := s.solutionRepo.StartRun(ctx, solutionID, runID)
+	if err != nil {
+		return err
+	}
+	return s.queue.EnqueueRun(ctx, solutionID, runID)
+}
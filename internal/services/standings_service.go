@@ -0,0 +1,535 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// penaltyPerRejectedAttempt is the ICPC penalty in minutes for every rejected
+// attempt made before the first accepted submission on a problem
+const penaltyPerRejectedAttempt = 20
+
+// exportBatchSize is the number of standings rows whose users are loaded at
+// once while exporting participants
+const exportBatchSize = 500
+
+type standingsService struct {
+	contestRepo         domain.ContestRepository
+	contestRegisterRepo domain.ContestRegisterRepository
+	problemRepo         domain.ProblemRepository
+	submissionRepo      domain.SubmissionRepository
+	userRepo            domain.UserRepository
+	virtualRepo         domain.VirtualParticipationRepository
+	teamRepo            domain.TeamRepository
+	access              *contestAccess
+}
+
+func NewStandingsService(contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, problemRepo domain.ProblemRepository, submissionRepo domain.SubmissionRepository, userRepo domain.UserRepository, virtualRepo domain.VirtualParticipationRepository, teamRepo domain.TeamRepository) domain.StandingsUseCase {
+	return &standingsService{
+		contestRepo:         contestRepo,
+		contestRegisterRepo: contestRegisterRepo,
+		problemRepo:         problemRepo,
+		submissionRepo:      submissionRepo,
+		userRepo:            userRepo,
+		virtualRepo:         virtualRepo,
+		teamRepo:            teamRepo,
+		access:              newContestAccess(userRepo, contestRegisterRepo),
+	}
+}
+
+func (s *standingsService) GetContestStandings(ctx context.Context, contestID string, userID string, role string) (*domain.StandingsResponse, error) {
+	contest, err := s.getViewableContest(ctx, contestID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	problems, err := getContestProblemResponses(ctx, s.contestRepo, s.problemRepo, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := s.submissionRepo.GetContestSubmissions(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	windows, err := s.getContestWindows(ctx, contest)
+	if err != nil {
+		return nil, err
+	}
+
+	disqualified, err := s.getDisqualifiedUsers(ctx, contest.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := newStandingsBuilder(problems)
+	for i := range submissions {
+		submission := &submissions[i]
+		elapsed, ok := officialElapsed(contest, windows, disqualified, submission)
+		if !ok {
+			continue
+		}
+		addOfficialSubmission(builder, contest, submission, elapsed)
+	}
+
+	elapsed := time.Since(contest.StartTime)
+	if time.Now().After(contest.EndTime) {
+		elapsed = contest.EndTime.Sub(contest.StartTime)
+	}
+
+	return s.toStandingsResponse(ctx, contestID, elapsed, problems, builder.build())
+}
+
+// GetVirtualStandings returns the ghost standings of a virtual participant: the
+// original participants as they stood at the same elapsed time, merged with
+// the virtual participant's own results.
+func (s *standingsService) GetVirtualStandings(ctx context.Context, contestID string, userID string, role string) (*domain.StandingsResponse, error) {
+	contest, err := s.getViewableContest(ctx, contestID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	participation, err := s.virtualRepo.GetVirtualParticipation(ctx, userID, contestID)
+	if err != nil {
+		return nil, errors.New("virtual participation not found")
+	}
+
+	problems, err := getContestProblemResponses(ctx, s.contestRepo, s.problemRepo, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := s.submissionRepo.GetContestSubmissions(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	elapsed := time.Since(participation.StartTime)
+	if time.Now().After(participation.EndTime) {
+		elapsed = participation.EndTime.Sub(participation.StartTime)
+	}
+
+	windows, err := s.getContestWindows(ctx, contest)
+	if err != nil {
+		return nil, err
+	}
+
+	disqualified, err := s.getDisqualifiedUsers(ctx, contest.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := newStandingsBuilder(problems)
+	for i := range submissions {
+		submission := &submissions[i]
+		if submission.IsVirtual {
+			if submission.UserId != userID || submission.SubmittedAt.Before(participation.StartTime) || submission.SubmittedAt.After(participation.EndTime) {
+				continue
+			}
+			participant := domain.StandingsRow{UserID: userID, IsVirtual: true}
+			builder.add("virtual:"+userID, participant, submission, submission.SubmittedAt.Sub(participation.StartTime))
+			continue
+		}
+		officialElapsedTime, ok := officialElapsed(contest, windows, disqualified, submission)
+		if !ok || officialElapsedTime > elapsed {
+			continue
+		}
+		addOfficialSubmission(builder, contest, submission, officialElapsedTime)
+	}
+
+	return s.toStandingsResponse(ctx, contestID, elapsed, problems, builder.build())
+}
+
+// ExportContestParticipants writes the official standings of a contest to the
+// writer, every active registration gets a row. Submissions are streamed from
+// the database and users are loaded per batch of rows, so only the aggregated
+// standings are kept in memory.
+func (s *standingsService) ExportContestParticipants(ctx context.Context, contestID string, writer domain.ParticipantExportWriter) error {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return errors.New("contest not found")
+	}
+
+	problems, err := getContestProblemResponses(ctx, s.contestRepo, s.problemRepo, contestID)
+	if err != nil {
+		return err
+	}
+
+	windows, err := s.getContestWindows(ctx, contest)
+	if err != nil {
+		return err
+	}
+
+	disqualified, err := s.getDisqualifiedUsers(ctx, contest.Id)
+	if err != nil {
+		return err
+	}
+
+	builder := newStandingsBuilder(problems)
+
+	// Participants who never submitted are exported too, with nothing solved
+	registrations, err := s.contestRegisterRepo.GetRegistrationsByContestID(ctx, contestID)
+	if err != nil {
+		return err
+	}
+	for _, registration := range registrations {
+		if contest.IsTeamContest {
+			if registration.TeamID != nil {
+				builder.row("team:"+*registration.TeamID, domain.StandingsRow{TeamID: *registration.TeamID})
+			}
+			continue
+		}
+		builder.row(registration.UserID, domain.StandingsRow{UserID: registration.UserID})
+	}
+
+	err = s.submissionRepo.StreamContestSubmissions(ctx, contestID, func(submission *domain.Submission) error {
+		elapsed, ok := officialElapsed(contest, windows, disqualified, submission)
+		if ok {
+			addOfficialSubmission(builder, contest, submission, elapsed)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	teamMembers, err := s.getTeamMembers(ctx, contest)
+	if err != nil {
+		return err
+	}
+
+	err = writer.WriteHeader(&domain.ContestExportHeader{
+		ContestID:   contest.Id,
+		ContestName: contest.Name,
+		Problems:    problems,
+	})
+	if err != nil {
+		return err
+	}
+
+	rows := builder.build()
+	for start := 0; start < len(rows); start += exportBatchSize {
+		end := min(start+exportBatchSize, len(rows))
+		err = s.writeExportRows(ctx, writer, rows[start:end], teamMembers)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeExportRows resolves the users and teams of a batch of standings rows
+// and writes them, one row per team member in team contests
+func (s *standingsService) writeExportRows(ctx context.Context, writer domain.ParticipantExportWriter, rows []domain.StandingsRow, teamMembers map[string][]string) error {
+	var userIDs, teamIDs []string
+	for _, row := range rows {
+		if row.TeamID != "" {
+			teamIDs = append(teamIDs, row.TeamID)
+			userIDs = append(userIDs, teamMembers[row.TeamID]...)
+		} else {
+			userIDs = append(userIDs, row.UserID)
+		}
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	teams, err := s.teamRepo.GetTeamsByIDs(ctx, teamIDs)
+	if err != nil {
+		return err
+	}
+
+	usersByID := make(map[string]domain.User, len(users))
+	for _, user := range users {
+		usersByID[user.Id] = user
+	}
+	teamNames := make(map[string]string, len(teams))
+	for _, team := range teams {
+		teamNames[team.ID] = team.Name
+	}
+
+	for _, row := range rows {
+		memberIDs := []string{row.UserID}
+		if row.TeamID != "" {
+			memberIDs = teamMembers[row.TeamID]
+		}
+		for _, memberID := range memberIDs {
+			user := usersByID[memberID]
+			err = writer.WriteRow(&domain.ParticipantExportRow{
+				Rank:     row.Rank,
+				UserID:   memberID,
+				Username: user.Username,
+				Email:    user.Email,
+				TeamID:   row.TeamID,
+				TeamName: teamNames[row.TeamID],
+				Solved:   row.Solved,
+				Penalty:  row.Penalty,
+				Problems: row.Problems,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getTeamMembers returns the registered members of every team in a team
+// contest keyed by team, nil for other contests
+func (s *standingsService) getTeamMembers(ctx context.Context, contest *domain.Contest) (map[string][]string, error) {
+	if !contest.IsTeamContest {
+		return nil, nil
+	}
+
+	registrations, err := s.contestRegisterRepo.GetRegistrationsByContestID(ctx, contest.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	members := map[string][]string{}
+	for _, registration := range registrations {
+		if registration.TeamID != nil {
+			members[*registration.TeamID] = append(members[*registration.TeamID], registration.UserID)
+		}
+	}
+	return members, nil
+}
+
+// getViewableContest returns the contest when the user is allowed to see it
+func (s *standingsService) getViewableContest(ctx context.Context, contestID string, userID string, role string) (*domain.Contest, error) {
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	canView, err := s.access.canView(ctx, contest, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("contest not found")
+	}
+	return contest, nil
+}
+
+// getContestWindows returns the personal windows of a windowed contest keyed
+// by user, nil for other contests
+func (s *standingsService) getContestWindows(ctx context.Context, contest *domain.Contest) (map[string]domain.ContestRegistration, error) {
+	if !contest.IsWindowed {
+		return nil, nil
+	}
+
+	registrations, err := s.contestRegisterRepo.GetRegistrationsByContestID(ctx, contest.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := make(map[string]domain.ContestRegistration, len(registrations))
+	for _, registration := range registrations {
+		if registration.StartedAt != nil && registration.EndsAt != nil {
+			windows[registration.UserID] = registration
+		}
+	}
+	return windows, nil
+}
+
+// getDisqualifiedUsers returns the users disqualified from a contest
+func (s *standingsService) getDisqualifiedUsers(ctx context.Context, contestID string) (map[string]bool, error) {
+	userIDs, err := s.contestRegisterRepo.GetDisqualifiedUserIDs(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	disqualified := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		disqualified[userID] = true
+	}
+	return disqualified, nil
+}
+
+// officialElapsed returns the time between the participant's start and an
+// official submission, and false when the submission was not made during the
+// participant's contest time or the participant was disqualified. In windowed
+// contests the participant starts with their personal window.
+func officialElapsed(contest *domain.Contest, windows map[string]domain.ContestRegistration, disqualified map[string]bool, submission *domain.Submission) (time.Duration, bool) {
+	if submission.IsVirtual || disqualified[submission.UserId] {
+		return 0, false
+	}
+
+	startTime, endTime := contest.StartTime, contest.EndTime
+	if contest.IsWindowed {
+		window, ok := windows[submission.UserId]
+		if !ok {
+			return 0, false
+		}
+		startTime, endTime = *window.StartedAt, *window.EndsAt
+	}
+
+	if submission.SubmittedAt.Before(startTime) || submission.SubmittedAt.After(endTime) {
+		return 0, false
+	}
+	return submission.SubmittedAt.Sub(startTime), true
+}
+
+// addOfficialSubmission adds a submission made during the contest, attributed
+// to the submitter's team in team contests
+func addOfficialSubmission(builder *standingsBuilder, contest *domain.Contest, submission *domain.Submission, elapsed time.Duration) {
+	if contest.IsTeamContest {
+		if submission.TeamID == nil {
+			return
+		}
+		builder.add("team:"+*submission.TeamID, domain.StandingsRow{TeamID: *submission.TeamID}, submission, elapsed)
+		return
+	}
+	builder.add(submission.UserId, domain.StandingsRow{UserID: submission.UserId}, submission, elapsed)
+}
+
+func (s *standingsService) toStandingsResponse(ctx context.Context, contestID string, elapsed time.Duration, problems []domain.ContestProblemResponse, rows []domain.StandingsRow) (*domain.StandingsResponse, error) {
+	var userIDs, teamIDs []string
+	for _, row := range rows {
+		if row.TeamID != "" {
+			teamIDs = append(teamIDs, row.TeamID)
+		} else {
+			userIDs = append(userIDs, row.UserID)
+		}
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.GetTeamsByIDs(ctx, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.Id] = user.Username
+	}
+	teamNames := make(map[string]string, len(teams))
+	for _, team := range teams {
+		teamNames[team.ID] = team.Name
+	}
+	for i := range rows {
+		if rows[i].TeamID != "" {
+			rows[i].TeamName = teamNames[rows[i].TeamID]
+		} else {
+			rows[i].Username = usernames[rows[i].UserID]
+		}
+	}
+
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	return &domain.StandingsResponse{
+		ContestID:      contestID,
+		ElapsedMinutes: int(elapsed.Minutes()),
+		Problems:       problems,
+		Rows:           rows,
+	}, nil
+}
+
+// standingsBuilder accumulates ICPC style results (solved count, then penalty)
+type standingsBuilder struct {
+	problems   []domain.ContestProblemResponse
+	problemPos map[string]int
+	rows       map[string]*domain.StandingsRow
+	keys       []string
+}
+
+func newStandingsBuilder(problems []domain.ContestProblemResponse) *standingsBuilder {
+	problemPos := make(map[string]int, len(problems))
+	for i, problem := range problems {
+		problemPos[problem.ProblemID] = i
+	}
+
+	return &standingsBuilder{
+		problems:   problems,
+		problemPos: problemPos,
+		rows:       map[string]*domain.StandingsRow{},
+	}
+}
+
+// add records a submission for the row identified by key, participant holds
+// the identity of the row (user or team). elapsed is the time between the
+// participant's start and the submission.
+func (b *standingsBuilder) add(key string, participant domain.StandingsRow, submission *domain.Submission, elapsed time.Duration) {
+	pos, ok := b.problemPos[submission.ProblemID]
+	if !ok {
+		return
+	}
+
+	row := b.row(key, participant)
+	result := &row.Problems[pos]
+	if result.Solved {
+		return
+	}
+
+	switch domain.VerdictStatus(submission.Verdict) {
+	case domain.VerdictAccepted:
+		result.Solved = true
+		result.SolvedAtMinute = int(elapsed.Minutes())
+		row.Solved++
+		row.Penalty += result.SolvedAtMinute + result.Attempts*penaltyPerRejectedAttempt
+	case domain.VerdictWrongAnswer,
+		domain.VerdictTimeLimitExceeded,
+		domain.VerdictMemoryLimitExceeded,
+		domain.VerdictRuntimeError:
+		result.Attempts++
+	}
+}
+
+// build returns the rows sorted and ranked, equal results share a rank
+// row returns the row identified by key, adding an empty one for participant
+// when it does not exist yet
+func (b *standingsBuilder) row(key string, participant domain.StandingsRow) *domain.StandingsRow {
+	row, ok := b.rows[key]
+	if ok {
+		return row
+	}
+
+	row = &participant
+	row.Problems = make([]domain.ProblemStanding, len(b.problems))
+	for i, problem := range b.problems {
+		row.Problems[i] = domain.ProblemStanding{
+			ProblemID: problem.ProblemID,
+			Index:     problem.Index,
+		}
+	}
+	b.rows[key] = row
+	b.keys = append(b.keys, key)
+	return row
+}
+
+func (b *standingsBuilder) build() []domain.StandingsRow {
+	rows := make([]domain.StandingsRow, 0, len(b.keys))
+	for _, key := range b.keys {
+		rows = append(rows, *b.rows[key])
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Solved != rows[j].Solved {
+			return rows[i].Solved > rows[j].Solved
+		}
+		return rows[i].Penalty < rows[j].Penalty
+	})
+
+	for i := range rows {
+		if i > 0 && rows[i].Solved == rows[i-1].Solved && rows[i].Penalty == rows[i-1].Penalty {
+			rows[i].Rank = rows[i-1].Rank
+		} else {
+			rows[i].Rank = i + 1
+		}
+	}
+
+	return rows
+}
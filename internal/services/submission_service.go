@@ -4,53 +4,90 @@ import (
 	"algoforces/internal/domain"
 	"algoforces/pkg/queue"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type SubmissionService struct {
-	submissionRepo domain.SubmissionRepository
-	queue          queue.SubmissionQueueInterface
+	submissionRepo      domain.SubmissionRepository
+	contestRepo         domain.ContestRepository
+	contestRegisterRepo domain.ContestRegisterRepository
+	virtualRepo         domain.VirtualParticipationRepository
+	problemRepo         domain.ProblemRepository
+	revisions           *problemRevisions
+	queue               queue.SubmissionQueueInterface
 }
 
-func NewSubmissionService(submissionRepo domain.SubmissionRepository, queue queue.SubmissionQueueInterface) domain.SubmissionUseCase {
+func NewSubmissionService(submissionRepo domain.SubmissionRepository, contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, virtualRepo domain.VirtualParticipationRepository, problemRepo domain.ProblemRepository, revisionRepo domain.ProblemRevisionRepository, testCaseRepo domain.TestCaseRepository, queue queue.SubmissionQueueInterface) domain.SubmissionUseCase {
 	return &SubmissionService{
-		submissionRepo: submissionRepo,
-		queue:          queue,
+		submissionRepo:      submissionRepo,
+		contestRepo:         contestRepo,
+		contestRegisterRepo: contestRegisterRepo,
+		virtualRepo:         virtualRepo,
+		problemRepo:         problemRepo,
+		revisions:           newProblemRevisions(revisionRepo, testCaseRepo),
+		queue:               queue,
 	}
 }
 
 func (s *SubmissionService) CreateNewSubmission(ctx context.Context, req *domain.CreateSubmissionRequest) (*domain.CreateSubmissionResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, req.ContestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	isVirtual, err := s.checkVirtualParticipation(ctx, contest, req)
+	if err != nil {
+		return nil, err
+	}
+
+	teamID, err := s.resolveTeam(ctx, contest, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkContestWindow(ctx, contest, req)
+	if err != nil {
+		return nil, err
+	}
+
 	//Get all TestCases for the problem
 	testCases, err := s.submissionRepo.GetAllTestCasesForProblem(ctx, req.ProblemID)
 	if err != nil {
 		return nil, err
 	}
 
-	var hiddenTestCases []domain.TestCase
-	var visibleTestCases []domain.TestCase
-	for _, testCase := range testCases {
-		if testCase.IsHidden {
-			hiddenTestCases = append(hiddenTestCases, testCase)
-		} else {
-			visibleTestCases = append(visibleTestCases, testCase)
-		}
+	problem, err := s.problemRepo.GetProblemByID(ctx, req.ProblemID)
+	if err != nil {
+		return nil, errors.New("problem not found")
+	}
+
+	// Pin the submission to the latest revision, a new one is only recorded
+	// when there is none yet or the problem changed since
+	revision, err := s.revisions.recordWithTests(ctx, problem, testCases, problem.CreatedBy, "")
+	if err != nil {
+		return nil, err
 	}
 
 	submissionID := uuid.New().String()
 	timNow := time.Now()
 	//Update the DB Status
 	submission := &domain.Submission{
-		UniqueID:    submissionID,
-		UserId:      req.UserID,
-		ContestID:   req.ContestID,
-		ProblemID:   req.ProblemID,
-		Code:        req.Code,
-		Language:    req.Language,
-		SubmittedAt: timNow,
-		Verdict:     string(domain.VerdictPending),
+		UniqueID:          submissionID,
+		UserId:            req.UserID,
+		ContestID:         req.ContestID,
+		ProblemID:         req.ProblemID,
+		Code:              req.Code,
+		Language:          req.Language,
+		SubmittedAt:       timNow,
+		Verdict:           string(domain.VerdictPending),
+		IsVirtual:         isVirtual,
+		TeamID:            teamID,
+		ProblemRevisionID: &revision.ID,
 	}
 	err = s.submissionRepo.CreateNewSubmission(ctx, submission)
 	if err != nil {
@@ -61,14 +98,7 @@ func (s *SubmissionService) CreateNewSubmission(ctx context.Context, req *domain
 	payload := queue.SubmissionPayload{
 		SubmissionID:      submissionID,
 		ProblemID:         req.ProblemID,
-		UserID:            req.UserID,
-		ContestID:         req.ContestID,
-		Code:              req.Code,
-		Language:          req.Language,
-		HiddenTestCases:   hiddenTestCases,
-		VisibleTestCases:  visibleTestCases,
-		TimeLimitInSecond: req.TimeLimitInSecond,
-		MemoryLimitInMB:   req.MemoryLimitInMB,
+		ProblemRevisionID: revision.ID,
 	}
 
 	//Push to Redis Queue
@@ -99,6 +129,87 @@ func (s *SubmissionService) CreateNewSubmission(ctx context.Context, req *domain
 	}, nil
 }
 
+// checkVirtualParticipation reports whether the submission is made during a
+// running virtual participation, which only accepts the contest's problems.
+// Submissions to an ended contest outside of one are plain practice submissions.
+func (s *SubmissionService) checkVirtualParticipation(ctx context.Context, contest *domain.Contest, req *domain.CreateSubmissionRequest) (bool, error) {
+	now := time.Now()
+	if now.Before(contest.EndTime) {
+		return false, nil
+	}
+
+	participation, err := s.virtualRepo.GetVirtualParticipation(ctx, req.UserID, req.ContestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if now.After(participation.EndTime) {
+		return false, nil
+	}
+
+	contestProblems, err := s.contestRepo.GetContestProblems(ctx, req.ContestID)
+	if err != nil {
+		return false, err
+	}
+	for _, cp := range contestProblems {
+		if cp.ProblemID == req.ProblemID {
+			return true, nil
+		}
+	}
+
+	return false, errors.New("problem is not part of this contest")
+}
+
+// resolveTeam returns the team a submission counts for. While a team contest
+// is running only users registered with a team can submit.
+func (s *SubmissionService) resolveTeam(ctx context.Context, contest *domain.Contest, req *domain.CreateSubmissionRequest) (*string, error) {
+	if !contest.IsTeamContest {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if now.Before(contest.StartTime) || now.After(contest.EndTime) {
+		return nil, nil
+	}
+
+	registration, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, req.UserID, req.ContestID)
+	if err != nil || !registration.IsActive() || registration.TeamID == nil {
+		return nil, errors.New("user is not registered with a team for this contest")
+	}
+
+	return registration.TeamID, nil
+}
+
+// checkContestWindow rejects submissions to a running windowed contest made
+// outside of the user's personal window
+func (s *SubmissionService) checkContestWindow(ctx context.Context, contest *domain.Contest, req *domain.CreateSubmissionRequest) error {
+	if !contest.IsWindowed {
+		return nil
+	}
+
+	now := time.Now()
+	if now.Before(contest.StartTime) || now.After(contest.EndTime) {
+		return nil
+	}
+
+	registration, err := s.contestRegisterRepo.GetRegistrationByUserAndContest(ctx, req.UserID, req.ContestID)
+	if err != nil || !registration.IsActive() {
+		return errors.New("user is not registered for this contest")
+	}
+
+	if registration.StartedAt == nil || registration.EndsAt == nil {
+		return errors.New("contest window has not been started")
+	}
+
+	if now.After(*registration.EndsAt) {
+		return errors.New("contest window has ended")
+	}
+
+	return nil
+}
+
 func (s *SubmissionService) UpdateSubmissionStatus(ctx context.Context, submissionID, status string) error {
 	return s.submissionRepo.UpdateSubmissionStatus(ctx, submissionID, status)
 }
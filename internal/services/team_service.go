@@ -0,0 +1,258 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/internal/utils"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const inviteCodeLength = 10
+
+type teamService struct {
+	teamRepo domain.TeamRepository
+	userRepo domain.UserRepository
+}
+
+func NewTeamService(teamRepo domain.TeamRepository, userRepo domain.UserRepository) domain.TeamUseCase {
+	return &teamService{
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *teamService) CreateTeam(ctx context.Context, userID string, req *domain.CreateTeamRequest) (*domain.TeamResponse, error) {
+	// Verify user exists
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	inviteCode, err := utils.GenerateInviteCode(inviteCodeLength)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &domain.Team{
+		ID:         uuid.New().String(),
+		Name:       req.Name,
+		CaptainID:  userID,
+		InviteCode: inviteCode,
+	}
+	captain := &domain.TeamMember{
+		ID:     uuid.New().String(),
+		TeamID: team.ID,
+		UserID: userID,
+	}
+
+	err = s.teamRepo.CreateTeam(ctx, team, captain)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toTeamResponse(ctx, team, userID)
+}
+
+func (s *teamService) JoinTeam(ctx context.Context, userID string, req *domain.JoinTeamRequest) (*domain.TeamResponse, error) {
+	team, err := s.teamRepo.GetTeamByInviteCode(ctx, req.InviteCode)
+	if err != nil {
+		return nil, errors.New("invalid invite code")
+	}
+
+	members, err := s.teamRepo.GetTeamMembers(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if member.UserID == userID {
+			return nil, errors.New("already a member of this team")
+		}
+	}
+
+	if len(members) >= domain.MaxTeamMembers {
+		return nil, errors.New("team is full")
+	}
+
+	err = s.teamRepo.AddTeamMember(ctx, &domain.TeamMember{
+		ID:     uuid.New().String(),
+		TeamID: team.ID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toTeamResponse(ctx, team, userID)
+}
+
+// LeaveTeam removes the user from the team. When the captain leaves, the
+// longest standing member becomes captain, and the last member leaving
+// deletes the team.
+func (s *teamService) LeaveTeam(ctx context.Context, userID string, teamID string) error {
+	team, err := s.teamRepo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return errors.New("team not found")
+	}
+
+	members, err := s.teamRepo.GetTeamMembers(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	if !isTeamMember(members, userID) {
+		return errors.New("not a member of this team")
+	}
+
+	if len(members) == 1 {
+		return s.teamRepo.DeleteTeam(ctx, teamID)
+	}
+
+	if team.CaptainID == userID {
+		for _, member := range members {
+			if member.UserID != userID {
+				team.CaptainID = member.UserID
+				break
+			}
+		}
+		err = s.teamRepo.UpdateTeam(ctx, team)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.teamRepo.RemoveTeamMember(ctx, teamID, userID)
+}
+
+func (s *teamService) RemoveTeamMember(ctx context.Context, userID string, teamID string, memberID string) error {
+	team, err := s.teamRepo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return errors.New("team not found")
+	}
+
+	if team.CaptainID != userID {
+		return errors.New("only the team captain can remove members")
+	}
+
+	if memberID == userID {
+		return errors.New("captain cannot remove themselves, leave the team instead")
+	}
+
+	members, err := s.teamRepo.GetTeamMembers(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	if !isTeamMember(members, memberID) {
+		return errors.New("user is not a member of this team")
+	}
+
+	return s.teamRepo.RemoveTeamMember(ctx, teamID, memberID)
+}
+
+func (s *teamService) RegenerateInviteCode(ctx context.Context, userID string, teamID string) (*domain.TeamResponse, error) {
+	team, err := s.teamRepo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return nil, errors.New("team not found")
+	}
+
+	if team.CaptainID != userID {
+		return nil, errors.New("only the team captain can regenerate the invite code")
+	}
+
+	inviteCode, err := utils.GenerateInviteCode(inviteCodeLength)
+	if err != nil {
+		return nil, err
+	}
+
+	team.InviteCode = inviteCode
+	err = s.teamRepo.UpdateTeam(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toTeamResponse(ctx, team, userID)
+}
+
+func (s *teamService) GetTeam(ctx context.Context, userID string, teamID string) (*domain.TeamResponse, error) {
+	team, err := s.teamRepo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return nil, errors.New("team not found")
+	}
+
+	return s.toTeamResponse(ctx, team, userID)
+}
+
+func (s *teamService) GetMyTeams(ctx context.Context, userID string) ([]domain.TeamResponse, error) {
+	teams, err := s.teamRepo.GetTeamsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []domain.TeamResponse{}
+	for i := range teams {
+		response, err := s.toTeamResponse(ctx, &teams[i], userID)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+
+	return responses, nil
+}
+
+// toTeamResponse builds the team response, the invite code is only included
+// when the requesting user is a member of the team
+func (s *teamService) toTeamResponse(ctx context.Context, team *domain.Team, userID string) (*domain.TeamResponse, error) {
+	members, err := s.teamRepo.GetTeamMembers(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.Id] = user.Username
+	}
+
+	response := &domain.TeamResponse{
+		ID:        team.ID,
+		Name:      team.Name,
+		CaptainID: team.CaptainID,
+		Members:   []domain.TeamMemberResponse{},
+		CreatedAt: team.CreatedAt,
+	}
+	for _, member := range members {
+		response.Members = append(response.Members, domain.TeamMemberResponse{
+			UserID:   member.UserID,
+			Username: usernames[member.UserID],
+			JoinedAt: member.JoinedAt,
+		})
+	}
+
+	if isTeamMember(members, userID) {
+		response.InviteCode = team.InviteCode
+	}
+
+	return response, nil
+}
+
+func isTeamMember(members []domain.TeamMember, userID string) bool {
+	for _, member := range members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/problempackage"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+func (s *TestCaseService) UploadTestArchive(ctx context.Context, problemID string, r io.ReaderAt, size int64, req *domain.TestArchiveUploadRequest, userID string, role string) (*domain.TestArchiveUploadResponse, error) {
+	problem, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	tests, err := problempackage.ReadTests(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test archive: %w", err)
+	}
+
+	// Every input is validated before any test case is stored
+	validationStatuses := make([]string, len(tests))
+	for i, test := range tests {
+		validationStatuses[i], err = s.validateInput(ctx, problem, test.Input)
+		if err != nil {
+			return nil, fmt.Errorf("test %s: %w", test.Name, err)
+		}
+	}
+
+	response := &domain.TestArchiveUploadResponse{
+		ProblemID: problemID,
+		Replaced:  req.Replace,
+		TestCases: []domain.TestArchiveEntry{},
+	}
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		testCaseRepo := tx.TestCases()
+
+		// Appended tests follow the existing ones
+		firstPosition := 1
+		if !req.Replace {
+			positions, err := testCaseRepo.GetTestCasePositions(ctx, problemID)
+			if err != nil {
+				return err
+			}
+			firstPosition = nextOrderPosition(positions)
+		}
+
+		testCases := make([]*domain.TestCase, 0, len(tests))
+		for i, test := range tests {
+			testCase := &domain.TestCase{
+				UniqueID:         uuid.New().String(),
+				ProblemID:        problemID,
+				Input:            test.Input,
+				ExpectedOutput:   test.Answer,
+				IsHidden:         !test.Sample,
+				OrderPosition:    firstPosition + i,
+				ValidationStatus: validationStatuses[i],
+			}
+			testCases = append(testCases, testCase)
+
+			if test.Sample {
+				response.SampleCount++
+			}
+			response.TestCases = append(response.TestCases, domain.TestArchiveEntry{
+				Name:             test.Name,
+				UniqueID:         testCase.UniqueID,
+				IsHidden:         testCase.IsHidden,
+				OrderPosition:    testCase.OrderPosition,
+				ValidationStatus: testCase.ValidationStatus,
+			})
+		}
+
+		var err error
+		if req.Replace {
+			err = testCaseRepo.ReplaceTestCases(ctx, problemID, testCases)
+		} else {
+			err = testCaseRepo.CreateTestCases(ctx, testCases)
+		}
+		if err != nil {
+			return err
+		}
+
+		revisions := newProblemRevisions(tx.Revisions(), testCaseRepo)
+		return recordTestSetRevision(ctx, tx.Problems(), revisions, problemID, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyTestSetChange(ctx, problemID)
+	return response, nil
+}
+
+func (s *TestCaseService) DownloadTestArchive(ctx context.Context, problemID string, w io.Writer, userID string, role string) error {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	testCases, err := s.testCaseRepo.GetTestCasesByProblemID(ctx, problemID)
+	if err != nil {
+		return err
+	}
+	if len(testCases) == 0 {
+		return errors.New("the problem has no test cases")
+	}
+
+	tests := make([]problempackage.Test, 0, len(testCases))
+	for _, testCase := range testCases {
+		tests = append(tests, problempackage.Test{
+			Input:  testCase.Input,
+			Answer: testCase.ExpectedOutput,
+			Sample: !testCase.IsHidden,
+		})
+	}
+	return problempackage.WriteTests(w, tests)
+}
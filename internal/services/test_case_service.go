@@ -4,50 +4,184 @@ import (
 	"algoforces/internal/domain"
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"slices"
 
 	"github.com/google/uuid"
 )
 
 type TestCaseService struct {
 	testCaseRepo domain.TestCaseRepository
+	problemRepo  domain.ProblemRepository
+	access       *problemAccess
+	revisions    *problemRevisions
+	unitOfWork   domain.UnitOfWork
+	validator    domain.InputValidator
+	notifier     domain.TestSetNotifier
 }
 
-func NewTestCaseService(testCaseRepo domain.TestCaseRepository) domain.TestCaseUseCase {
+func NewTestCaseService(testCaseRepo domain.TestCaseRepository, problemRepo domain.ProblemRepository, userRepo domain.UserRepository, contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, revisionRepo domain.ProblemRevisionRepository, unitOfWork domain.UnitOfWork, validator domain.InputValidator, notifier domain.TestSetNotifier) domain.TestCaseUseCase {
 	return &TestCaseService{
 		testCaseRepo: testCaseRepo,
+		problemRepo:  problemRepo,
+		access:       newProblemAccess(problemRepo, contestRepo, newContestAccess(userRepo, contestRegisterRepo)),
+		revisions:    newProblemRevisions(revisionRepo, testCaseRepo),
+		unitOfWork:   unitOfWork,
+		validator:    validator,
+		notifier:     notifier,
 	}
 }
 
-func (s *TestCaseService) CreateNewTestCase(ctx context.Context, req *domain.CreateTestCaseRequest) (*domain.CreateTestCaseResponse, error) {
+// recordTestSetChange records a revision of a problem whose test set changed
+// and tells the judge workers to drop their cached copy
+func (s *TestCaseService) recordTestSetChange(ctx context.Context, problemID string, userID string) error {
+	err := recordTestSetRevision(ctx, s.problemRepo, s.revisions, problemID, userID)
+	if err != nil {
+		return err
+	}
+	s.notifyTestSetChange(ctx, problemID)
+	return nil
+}
+
+// recordTestSetRevision records a revision of a problem whose test set changed
+func recordTestSetRevision(ctx context.Context, problemRepo domain.ProblemRepository, revisions *problemRevisions, problemID string, userID string) error {
+	problem, err := problemRepo.GetProblemByID(ctx, problemID)
+	if err != nil {
+		return errors.New("problem not found")
+	}
+	_, err = revisions.record(ctx, problem, userID, "test set changed")
+	return err
+}
+
+// notifyTestSetChange tells the judge workers to drop their cached copy of
+// the test set of a problem
+func (s *TestCaseService) notifyTestSetChange(ctx context.Context, problemID string) {
+	// Workers check cached test sets against the revision they judge, a lost
+	// notification only keeps a stale copy in memory a little longer
+	if err := s.notifier.NotifyTestSetChanged(ctx, problemID); err != nil {
+		log.Printf("Failed to notify the test set change of problem %s: %v", problemID, err)
+	}
+}
+
+// validateInput runs the validator of a problem on a test input. It returns
+// the validation status to store, or an error when the input is rejected.
+func (s *TestCaseService) validateInput(ctx context.Context, problem *domain.Problem, input string) (string, error) {
+	if problem.Validator == "" {
+		return domain.TestValidationUnchecked, nil
+	}
+
+	valid, message, err := s.validator.ValidateInput(ctx, problem.ValidatorLanguage, problem.Validator, input)
+	if err != nil {
+		return "", err
+	}
+	if !valid {
+		return "", errors.New("test input rejected by the validator: " + message)
+	}
+	return domain.TestValidationValid, nil
+}
+
+// checkOrderPosition returns an error when a test case other than testCaseID
+// has the order position, positions maps the test cases of a problem to theirs
+func checkOrderPosition(positions map[string]int, testCaseID string, position int) error {
+	for id, taken := range positions {
+		if taken == position && id != testCaseID {
+			return fmt.Errorf("order position %d is already used by another test case of the problem", position)
+		}
+	}
+	return nil
+}
+
+// nextOrderPosition returns the position after the last test case of a
+// problem, positions maps its test cases to theirs
+func nextOrderPosition(positions map[string]int) int {
+	next := 1
+	for _, position := range positions {
+		if position >= next {
+			next = position + 1
+		}
+	}
+	return next
+}
+
+func (s *TestCaseService) CreateNewTestCase(ctx context.Context, req *domain.CreateTestCaseRequest, userID string, role string) (*domain.CreateTestCaseResponse, error) {
+	problem, err := s.access.getOwnProblem(ctx, req.ProblemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	validationStatus, err := s.validateInput(ctx, problem, req.Input)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := s.testCaseRepo.GetTestCasePositions(ctx, req.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkOrderPosition(positions, "", req.OrderPosition)
+	if err != nil {
+		return nil, err
+	}
+
 	testCase := &domain.TestCase{
-		UniqueID:       uuid.New().String(),
-		ProblemID:      req.ProblemID,
-		Input:          req.Input,
-		ExpectedOutput: req.ExpectedOutput,
-		IsHidden:       req.IsHidden,
-		OrderPosition:  req.OrderPosition,
+		UniqueID:         uuid.New().String(),
+		ProblemID:        req.ProblemID,
+		Input:            req.Input,
+		ExpectedOutput:   req.ExpectedOutput,
+		IsHidden:         req.IsHidden,
+		OrderPosition:    req.OrderPosition,
+		ValidationStatus: validationStatus,
 	}
 
-	err := s.testCaseRepo.CreateTestCase(ctx, testCase)
+	err = s.testCaseRepo.CreateTestCase(ctx, testCase)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.recordTestSetChange(ctx, testCase.ProblemID, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	return &domain.CreateTestCaseResponse{
-		UniqueID:       testCase.UniqueID,
-		ProblemID:      testCase.ProblemID,
-		Input:          testCase.Input,
-		ExpectedOutput: testCase.ExpectedOutput,
-		IsHidden:       testCase.IsHidden,
-		OrderPosition:  testCase.OrderPosition,
+		UniqueID:         testCase.UniqueID,
+		ProblemID:        testCase.ProblemID,
+		Input:            testCase.Input,
+		ExpectedOutput:   testCase.ExpectedOutput,
+		IsHidden:         testCase.IsHidden,
+		OrderPosition:    testCase.OrderPosition,
+		ValidationStatus: testCase.ValidationStatus,
 	}, nil
 }
 
-func (s *TestCaseService) UpdateSingleTestCase(ctx context.Context, req *domain.UpdateTestCaseRequest) (*domain.UpdateTestCaseResponse, error) {
+func (s *TestCaseService) UpdateSingleTestCase(ctx context.Context, req *domain.UpdateTestCaseRequest, userID string, role string) (*domain.UpdateTestCaseResponse, error) {
 	testCase, err := s.testCaseRepo.GetTestCaseByUniqueID(ctx, req.UniqueID)
 
 	if err != nil {
-		return nil, errors.New("Error  in getting the test Case")
+		return nil, errors.New("test case not found")
+	}
+	problem, err := s.access.getOwnProblem(ctx, testCase.ProblemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inputs already accepted by the current validator are not run again
+	if req.Input != testCase.Input || testCase.ValidationStatus != domain.TestValidationValid {
+		testCase.ValidationStatus, err = s.validateInput(ctx, problem, req.Input)
+		if err != nil {
+			return nil, err
+		}
+		testCase.ValidationMessage = ""
+	}
+
+	if req.OrderPosition != testCase.OrderPosition {
+		positions, err := s.testCaseRepo.GetTestCasePositions(ctx, testCase.ProblemID)
+		if err != nil {
+			return nil, err
+		}
+		err = checkOrderPosition(positions, testCase.UniqueID, req.OrderPosition)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	testCase.Input = req.Input
@@ -60,70 +194,293 @@ func (s *TestCaseService) UpdateSingleTestCase(ctx context.Context, req *domain.
 		return nil, err
 	}
 
+	err = s.recordTestSetChange(ctx, testCase.ProblemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.UpdateTestCaseResponse{
-		UniqueID:       testCase.UniqueID,
-		ProblemID:      testCase.ProblemID,
-		Input:          testCase.Input,
-		ExpectedOutput: testCase.ExpectedOutput,
-		IsHidden:       testCase.IsHidden,
-		OrderPosition:  testCase.OrderPosition,
+		UniqueID:         testCase.UniqueID,
+		ProblemID:        testCase.ProblemID,
+		Input:            testCase.Input,
+		ExpectedOutput:   testCase.ExpectedOutput,
+		IsHidden:         testCase.IsHidden,
+		OrderPosition:    testCase.OrderPosition,
+		ValidationStatus: testCase.ValidationStatus,
 	}, nil
 }
 
-func (s *TestCaseService) DeleteSingleTestCase(ctx context.Context, uniqueId string) error {
-	err := s.testCaseRepo.DeleteTestCase(ctx, uniqueId)
+func (s *TestCaseService) DeleteSingleTestCase(ctx context.Context, uniqueId string, userID string, role string) error {
+	testCase, err := s.testCaseRepo.GetTestCaseByUniqueID(ctx, uniqueId)
+	if err != nil {
+		return errors.New("test case not found")
+	}
+	_, err = s.access.getOwnProblem(ctx, testCase.ProblemID, userID, role)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	err = s.testCaseRepo.DeleteTestCase(ctx, uniqueId)
+	if err != nil {
+		return err
+	}
+	return s.recordTestSetChange(ctx, testCase.ProblemID, userID)
 }
 
-func (s *TestCaseService) GetAllTestCasesForProblem(ctx context.Context, problemId string) ([]*domain.TestCase, error) {
+// GetAllTestCasesForProblem returns every test case of a problem to its
+// authors and only the samples to users who can see the problem
+func (s *TestCaseService) GetAllTestCasesForProblem(ctx context.Context, problemId string, userID string, role string) ([]*domain.TestCase, error) {
+	problem, err := s.problemRepo.GetProblemByID(ctx, problemId)
+	if err != nil {
+		return nil, errors.New("problem not found")
+	}
+	isAuthor, err := s.canSeeHiddenTests(ctx, problem, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
 	testCases, err := s.testCaseRepo.GetTestCasesByProblemID(ctx, problemId)
 	if err != nil {
 		return nil, err
 	}
-	return testCases, nil
+	if isAuthor {
+		return testCases, nil
+	}
+
+	samples := []*domain.TestCase{}
+	for _, testCase := range testCases {
+		if !testCase.IsHidden {
+			samples = append(samples, testCase)
+		}
+	}
+	return samples, nil
 }
 
-func (s *TestCaseService) GetTestCaseDetails(ctx context.Context, uniqueId string) (*domain.TestCase, error) {
+// GetTestCaseDetails returns a test case to the authors of its problem, and
+// a sample to users who can see the problem. Hidden tests are not found for
+// anyone else.
+func (s *TestCaseService) GetTestCaseDetails(ctx context.Context, uniqueId string, userID string, role string) (*domain.TestCase, error) {
 	testCase, err := s.testCaseRepo.GetTestCaseByUniqueID(ctx, uniqueId)
 	if err != nil {
+		return nil, errors.New("test case not found")
+	}
+	problem, err := s.problemRepo.GetProblemByID(ctx, testCase.ProblemID)
+	if err != nil {
+		return nil, errors.New("test case not found")
+	}
+	isAuthor, err := s.canSeeHiddenTests(ctx, problem, userID, role)
+	if err != nil {
+		if errors.Is(err, errProblemNotFound) {
+			return nil, errors.New("test case not found")
+		}
 		return nil, err
 	}
+	if testCase.IsHidden && !isAuthor {
+		return nil, errors.New("test case not found")
+	}
 	return testCase, nil
 }
 
-func (s *TestCaseService) UploadTestCasesInBulk(ctx context.Context, req *domain.BulkTestCaseUploadRequest) (*domain.BulkTestCaseUploadResponse, error) {
+// canSeeHiddenTests reports whether the user is an author of the problem. It
+// returns errProblemNotFound when the user cannot see the problem at all.
+func (s *TestCaseService) canSeeHiddenTests(ctx context.Context, problem *domain.Problem, userID string, role string) (bool, error) {
+	if s.access.isAuthor(problem, userID, role) {
+		return true, nil
+	}
+	canView, err := s.access.canView(ctx, problem, userID, role, map[string]bool{})
+	if err != nil {
+		return false, err
+	}
+	if !canView {
+		return false, errProblemNotFound
+	}
+	return false, nil
+}
+
+func (s *TestCaseService) UploadTestCasesInBulk(ctx context.Context, req *domain.BulkTestCaseUploadRequest, userID string, role string) (*domain.BulkTestCaseUploadResponse, error) {
 
 	response := &domain.BulkTestCaseUploadResponse{
 		CreatedTestCases: []domain.CreateTestCaseResponse{},
+		Errors:           []domain.BulkItemError{},
 	}
-	for _, testCase := range req.TestCases {
-		testcase := &domain.TestCase{
-			UniqueID:       uuid.New().String(),
-			ProblemID:      testCase.ProblemID,
-			Input:          testCase.Input,
-			ExpectedOutput: testCase.ExpectedOutput,
-			IsHidden:       testCase.IsHidden,
-			OrderPosition:  testCase.OrderPosition,
+
+	// Every input is validated before any test case is created
+	problems := map[string]*domain.Problem{}
+	positions := map[string]map[string]int{}
+	testCases := make([]*domain.TestCase, len(req.TestCases))
+	for i, testCase := range req.TestCases {
+		problem, ok := problems[testCase.ProblemID]
+		if !ok {
+			var err error
+			problem, err = s.access.getOwnProblem(ctx, testCase.ProblemID, userID, role)
+			if err != nil {
+				response.Errors = append(response.Errors, domain.BulkItemError{Index: i, Error: err.Error()})
+				continue
+			}
+			problems[testCase.ProblemID] = problem
+			positions[testCase.ProblemID], err = s.testCaseRepo.GetTestCasePositions(ctx, testCase.ProblemID)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		err := s.testCaseRepo.CreateTestCase(ctx, testcase)
+		status, err := s.validateInput(ctx, problem, testCase.Input)
+		if err != nil {
+			response.Errors = append(response.Errors, domain.BulkItemError{Index: i, Error: err.Error()})
+			continue
+		}
+		// Positions must also differ between the test cases of the request
+		err = checkOrderPosition(positions[testCase.ProblemID], "", testCase.OrderPosition)
+		if err != nil {
+			response.Errors = append(response.Errors, domain.BulkItemError{Index: i, Error: err.Error()})
+			continue
+		}
+		uniqueID := uuid.New().String()
+		positions[testCase.ProblemID][uniqueID] = testCase.OrderPosition
+
+		testCases[i] = &domain.TestCase{
+			UniqueID:         uniqueID,
+			ProblemID:        testCase.ProblemID,
+			Input:            testCase.Input,
+			ExpectedOutput:   testCase.ExpectedOutput,
+			IsHidden:         testCase.IsHidden,
+			OrderPosition:    testCase.OrderPosition,
+			ValidationStatus: status,
+		}
+	}
+
+	var created []*domain.TestCase
+	var changedProblems []string
+	if req.Mode == domain.BulkModeBestEffort {
+		for i, testCase := range testCases {
+			if testCase == nil {
+				continue
+			}
+			err := s.testCaseRepo.CreateTestCase(ctx, testCase)
+			if err != nil {
+				response.Errors = append(response.Errors, domain.BulkItemError{Index: i, Error: err.Error()})
+				continue
+			}
+			created = append(created, testCase)
+			if !slices.Contains(changedProblems, testCase.ProblemID) {
+				changedProblems = append(changedProblems, testCase.ProblemID)
+			}
+		}
+
+		// One revision per problem for the whole upload
+		for _, problemID := range changedProblems {
+			err := s.recordTestSetChange(ctx, problemID, userID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else if len(response.Errors) == 0 {
+		for _, testCase := range testCases {
+			if !slices.Contains(changedProblems, testCase.ProblemID) {
+				changedProblems = append(changedProblems, testCase.ProblemID)
+			}
+		}
+
+		// The test cases and the revisions of their problems are stored together
+		err := s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+			err := tx.TestCases().CreateTestCases(ctx, testCases)
+			if err != nil {
+				return err
+			}
+			revisions := newProblemRevisions(tx.Revisions(), tx.TestCases())
+			for _, problemID := range changedProblems {
+				err = recordTestSetRevision(ctx, tx.Problems(), revisions, problemID, userID)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 		if err != nil {
 			return nil, err
 		}
+		created = testCases
+		for _, problemID := range changedProblems {
+			s.notifyTestSetChange(ctx, problemID)
+		}
+	}
 
+	slices.SortFunc(response.Errors, func(a, b domain.BulkItemError) int {
+		return a.Index - b.Index
+	})
+	response.FailedCount = len(response.Errors)
+	response.SuccessCount = len(created)
+	for _, testcase := range created {
 		response.CreatedTestCases = append(response.CreatedTestCases, domain.CreateTestCaseResponse{
-			UniqueID:       testcase.UniqueID,
-			ProblemID:      testcase.ProblemID,
-			Input:          testcase.Input,
-			ExpectedOutput: testcase.ExpectedOutput,
-			IsHidden:       testcase.IsHidden,
-			OrderPosition:  testcase.OrderPosition,
+			UniqueID:         testcase.UniqueID,
+			ProblemID:        testcase.ProblemID,
+			Input:            testcase.Input,
+			ExpectedOutput:   testcase.ExpectedOutput,
+			IsHidden:         testcase.IsHidden,
+			OrderPosition:    testcase.OrderPosition,
+			ValidationStatus: testcase.ValidationStatus,
 		})
 	}
 
 	return response, nil
 
 }
+
+func (s *TestCaseService) StoreGeneratedTests(ctx context.Context, problemID string, tests []domain.GeneratedTest, replace bool, userID string) error {
+	problem, err := s.problemRepo.GetProblemByID(ctx, problemID)
+	if err != nil {
+		return errors.New("problem not found")
+	}
+
+	validationStatuses := make([]string, len(tests))
+	for i, test := range tests {
+		validationStatuses[i], err = s.validateInput(ctx, problem, test.Input)
+		if err != nil {
+			return fmt.Errorf("%s: %w", test.Invocation, err)
+		}
+	}
+
+	// Earlier generated tests are only deleted together with the new tests being stored
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		testCaseRepo := tx.TestCases()
+		if replace {
+			err := testCaseRepo.DeleteGeneratedTestCases(ctx, problemID)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Generated tests follow the existing ones
+		positions, err := testCaseRepo.GetTestCasePositions(ctx, problemID)
+		if err != nil {
+			return err
+		}
+		nextPosition := nextOrderPosition(positions)
+
+		testCases := make([]*domain.TestCase, 0, len(tests))
+		for i, test := range tests {
+			testCases = append(testCases, &domain.TestCase{
+				UniqueID:         uuid.New().String(),
+				ProblemID:        problemID,
+				Input:            test.Input,
+				ExpectedOutput:   test.ExpectedOutput,
+				IsHidden:         true,
+				OrderPosition:    nextPosition + i,
+				ValidationStatus: validationStatuses[i],
+				Generator:        test.Invocation,
+			})
+		}
+		err = testCaseRepo.CreateTestCases(ctx, testCases)
+		if err != nil {
+			return err
+		}
+
+		revisions := newProblemRevisions(tx.Revisions(), testCaseRepo)
+		return recordTestSetRevision(ctx, tx.Problems(), revisions, problemID, userID)
+	})
+	if err != nil {
+		return err
+	}
+	s.notifyTestSetChange(ctx, problemID)
+	return nil
+}
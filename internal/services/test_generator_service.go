@@ -0,0 +1,152 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/queue"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type testGeneratorService struct {
+	generatorRepo domain.TestGeneratorRepository
+	problemRepo   domain.ProblemRepository
+	access        *problemAccess
+	solutionRepo  domain.ReferenceSolutionRepository
+	queue         queue.TestGenerationQueueInterface
+}
+
+func NewTestGeneratorService(generatorRepo domain.TestGeneratorRepository, problemRepo domain.ProblemRepository, userRepo domain.UserRepository, contestRepo domain.ContestRepository, contestRegisterRepo domain.ContestRegisterRepository, solutionRepo domain.ReferenceSolutionRepository, queue queue.TestGenerationQueueInterface) domain.TestGeneratorUseCase {
+	return &testGeneratorService{
+		generatorRepo: generatorRepo,
+		problemRepo:   problemRepo,
+		access:        newProblemAccess(problemRepo, contestRepo, newContestAccess(userRepo, contestRegisterRepo)),
+		solutionRepo:  solutionRepo,
+		queue:         queue,
+	}
+}
+
+func (s *testGeneratorService) SaveGenerator(ctx context.Context, problemID string, req *domain.SaveGeneratorRequest, userID string, role string) (*domain.ProblemGenerator, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	// Scripts split invocations on spaces and skip lines starting with #
+	if strings.ContainsAny(req.Name, " #") {
+		return nil, errors.New("generator names cannot contain spaces or #")
+	}
+
+	generator := &domain.ProblemGenerator{
+		ID:        uuid.New().String(),
+		ProblemID: problemID,
+		Name:      req.Name,
+		Language:  req.Language,
+		Source:    req.Source,
+		CreatedBy: userID,
+	}
+	err = s.generatorRepo.SaveGenerator(ctx, generator)
+	if err != nil {
+		return nil, err
+	}
+	return generator, nil
+}
+
+func (s *testGeneratorService) GetGenerators(ctx context.Context, problemID string, userID string, role string) ([]domain.ProblemGenerator, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.generatorRepo.GetGenerators(ctx, problemID)
+}
+
+func (s *testGeneratorService) DeleteGenerator(ctx context.Context, problemID string, name string, userID string, role string) error {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.generatorRepo.DeleteGenerator(ctx, problemID, name)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.New("generator not found")
+	}
+	return nil
+}
+
+func (s *testGeneratorService) GenerateTests(ctx context.Context, problemID string, req *domain.GenerateTestsRequest, userID string, role string) (*domain.TestGeneration, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked here so mistakes are reported before the script is queued
+	invocations, err := domain.ParseGeneratorScript(req.Script)
+	if err != nil {
+		return nil, err
+	}
+	generators, err := s.generatorRepo.GetGenerators(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, generator := range generators {
+		names[generator.Name] = true
+	}
+	for _, invocation := range invocations {
+		if !names[invocation.Generator] {
+			return nil, errors.New("unknown generator " + invocation.Generator)
+		}
+	}
+
+	// The main reference solution produces the expected outputs
+	solutions, err := s.solutionRepo.GetSolutions(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	hasMain := false
+	for _, solution := range solutions {
+		if solution.Kind == domain.ReferenceSolutionMain {
+			hasMain = true
+		}
+	}
+	if !hasMain {
+		return nil, errors.New("the problem has no main solution")
+	}
+
+	generation := &domain.TestGeneration{
+		ID:        uuid.New().String(),
+		ProblemID: problemID,
+		Script:    req.Script,
+		Replace:   req.Replace,
+		Status:    domain.TestGenerationPending,
+		CreatedBy: userID,
+	}
+	err = s.generatorRepo.CreateGeneration(ctx, generation)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.queue.EnqueueGeneration(ctx, generation.ID)
+	if err != nil {
+		return nil, err
+	}
+	return generation, nil
+}
+
+func (s *testGeneratorService) GetGeneration(ctx context.Context, problemID string, generationID string, userID string, role string) (*domain.TestGeneration, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	generation, err := s.generatorRepo.GetGeneration(ctx, generationID)
+	if err != nil || generation.ProblemID != problemID {
+		return nil, errors.New("test generation not found")
+	}
+	return generation, nil
+}
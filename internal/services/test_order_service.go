@@ -0,0 +1,71 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+)
+
+func (s *TestCaseService) ReorderTestCases(ctx context.Context, problemID string, req *domain.ReorderTestCasesRequest, userID string, role string) (*domain.TestCaseOrder, error) {
+	_, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	// The order is checked against the test cases under the problem lock that
+	// revisions are recorded under, a concurrent change cannot slip in between
+	err = s.unitOfWork.Do(ctx, func(tx domain.Transaction) error {
+		_, err := tx.Problems().LockProblem(ctx, problemID)
+		if err != nil {
+			return errors.New("problem not found")
+		}
+
+		positions, err := tx.TestCases().GetTestCasePositions(ctx, problemID)
+		if err != nil {
+			return err
+		}
+		if len(positions) == 0 {
+			return errors.New("the problem has no test cases")
+		}
+
+		// A partial order would leave the other test cases' positions ambiguous
+		listed := make(map[string]bool, len(req.TestCaseIDs))
+		for _, testCaseID := range req.TestCaseIDs {
+			if _, ok := positions[testCaseID]; !ok || listed[testCaseID] {
+				return errors.New("the order must list every test case of the problem exactly once")
+			}
+			listed[testCaseID] = true
+		}
+		if len(listed) != len(positions) {
+			return errors.New("the order must list every test case of the problem exactly once")
+		}
+
+		err = tx.TestCases().ReorderTestCases(ctx, problemID, req.TestCaseIDs)
+		if err != nil {
+			return err
+		}
+		revisions := newProblemRevisions(tx.Revisions(), tx.TestCases())
+		return recordTestSetRevision(ctx, tx.Problems(), revisions, problemID, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyTestSetChange(ctx, problemID)
+
+	testCases, err := s.testCaseRepo.GetTestCasesByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	order := &domain.TestCaseOrder{
+		ProblemID: problemID,
+		TestCases: make([]domain.TestCaseOrderEntry, 0, len(testCases)),
+	}
+	for _, testCase := range testCases {
+		order.TestCases = append(order.TestCases, domain.TestCaseOrderEntry{
+			UniqueID:      testCase.UniqueID,
+			OrderPosition: testCase.OrderPosition,
+			IsHidden:      testCase.IsHidden,
+		})
+	}
+	return order, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+)
+
+func (s *TestCaseService) SetValidator(ctx context.Context, problemID string, req *domain.SetValidatorRequest, userID string, role string) (*domain.ProblemValidator, error) {
+	problem, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	problem.Validator = req.Source
+	problem.ValidatorLanguage = req.Language
+	err = s.problemRepo.UpdateProblem(ctx, problem)
+	if err != nil {
+		return nil, err
+	}
+
+	// Statuses given by the previous validator no longer hold
+	err = s.testCaseRepo.ResetValidationStatus(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ProblemValidator{
+		ProblemID: problemID,
+		Language:  problem.ValidatorLanguage,
+		Source:    problem.Validator,
+	}, nil
+}
+
+func (s *TestCaseService) GetValidator(ctx context.Context, problemID string, userID string, role string) (*domain.ProblemValidator, error) {
+	problem, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if problem.Validator == "" {
+		return nil, errors.New("the problem has no validator")
+	}
+
+	return &domain.ProblemValidator{
+		ProblemID: problemID,
+		Language:  problem.ValidatorLanguage,
+		Source:    problem.Validator,
+	}, nil
+}
+
+func (s *TestCaseService) DeleteValidator(ctx context.Context, problemID string, userID string, role string) error {
+	problem, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return err
+	}
+	if problem.Validator == "" {
+		return errors.New("the problem has no validator")
+	}
+
+	problem.Validator = ""
+	problem.ValidatorLanguage = ""
+	err = s.problemRepo.UpdateProblem(ctx, problem)
+	if err != nil {
+		return err
+	}
+	return s.testCaseRepo.ResetValidationStatus(ctx, problemID)
+}
+
+func (s *TestCaseService) ValidateTestCases(ctx context.Context, problemID string, userID string, role string) (*domain.TestValidationReport, error) {
+	problem, err := s.access.getOwnProblem(ctx, problemID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if problem.Validator == "" {
+		return nil, errors.New("the problem has no validator")
+	}
+
+	testCases, err := s.testCaseRepo.GetTestCasesByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.TestValidationReport{
+		ProblemID: problemID,
+		Results:   []domain.TestValidationResult{},
+	}
+	for _, testCase := range testCases {
+		valid, message, err := s.validator.ValidateInput(ctx, problem.ValidatorLanguage, problem.Validator, testCase.Input)
+		if err != nil {
+			return nil, err
+		}
+
+		status := domain.TestValidationValid
+		if valid {
+			report.Valid++
+		} else {
+			status = domain.TestValidationInvalid
+			report.Invalid++
+		}
+		err = s.testCaseRepo.UpdateValidationStatus(ctx, testCase.UniqueID, status, message)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Results = append(report.Results, domain.TestValidationResult{
+			TestCaseID:    testCase.UniqueID,
+			OrderPosition: testCase.OrderPosition,
+			Status:        status,
+			Message:       message,
+		})
+	}
+	return report, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type virtualParticipationService struct {
+	virtualRepo    domain.VirtualParticipationRepository
+	contestRepo    domain.ContestRepository
+	submissionRepo domain.SubmissionRepository
+	access         *contestAccess
+}
+
+func NewVirtualParticipationService(virtualRepo domain.VirtualParticipationRepository, contestRepo domain.ContestRepository, submissionRepo domain.SubmissionRepository, userRepo domain.UserRepository, contestRegisterRepo domain.ContestRegisterRepository) domain.VirtualParticipationUseCase {
+	return &virtualParticipationService{
+		virtualRepo:    virtualRepo,
+		contestRepo:    contestRepo,
+		submissionRepo: submissionRepo,
+		access:         newContestAccess(userRepo, contestRegisterRepo),
+	}
+}
+
+func (s *virtualParticipationService) StartVirtualParticipation(ctx context.Context, userID string, role string, req *domain.StartVirtualParticipationRequest) (*domain.VirtualParticipationResponse, error) {
+	contest, err := s.contestRepo.GetByID(ctx, req.ContestID)
+	if err != nil {
+		return nil, errors.New("contest not found")
+	}
+
+	// Private contests can only be replayed by the users who could see them
+	canView, err := s.access.canView(ctx, contest, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("contest not found")
+	}
+
+	// Only finished contests can be replayed
+	if time.Now().Before(contest.EndTime) {
+		return nil, errors.New("virtual participation is only available after the contest ends")
+	}
+
+	existing, err := s.virtualRepo.GetVirtualParticipation(ctx, userID, req.ContestID)
+	if err == nil && existing != nil {
+		return nil, errors.New("virtual participation already exists for this contest")
+	}
+
+	// Users who took part in the contest already know the problems
+	count, err := s.submissionRepo.CountOfficialSubmissions(ctx, userID, req.ContestID)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.New("user already participated in this contest")
+	}
+
+	startTime := time.Now()
+	participation := &domain.VirtualParticipation{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		ContestID: req.ContestID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(time.Duration(contest.Duration) * time.Minute),
+	}
+
+	err = s.virtualRepo.CreateVirtualParticipation(ctx, participation)
+	if err != nil {
+		return nil, err
+	}
+
+	return toVirtualParticipationResponse(participation), nil
+}
+
+func (s *virtualParticipationService) GetVirtualParticipation(ctx context.Context, userID string, contestID string) (*domain.VirtualParticipationResponse, error) {
+	participation, err := s.virtualRepo.GetVirtualParticipation(ctx, userID, contestID)
+	if err != nil {
+		return nil, errors.New("virtual participation not found")
+	}
+
+	return toVirtualParticipationResponse(participation), nil
+}
+
+func toVirtualParticipationResponse(participation *domain.VirtualParticipation) *domain.VirtualParticipationResponse {
+	now := time.Now()
+	elapsed := now.Sub(participation.StartTime)
+	if now.After(participation.EndTime) {
+		elapsed = participation.EndTime.Sub(participation.StartTime)
+	}
+
+	return &domain.VirtualParticipationResponse{
+		ID:             participation.ID,
+		UserID:         participation.UserID,
+		ContestID:      participation.ContestID,
+		StartTime:      participation.StartTime,
+		EndTime:        participation.EndTime,
+		ElapsedMinutes: int(elapsed.Minutes()),
+		IsRunning:      now.Before(participation.EndTime),
+	}
+}
@@ -24,6 +24,7 @@ type SubmissionRequest struct {
 	SourceCode                           string  `json:"source_code"`
 	LanguageID                           int     `json:"language_id"`
 	Stdin                                string  `json:"stdin,omitempty"`
+	CommandLineArguments                 string  `json:"command_line_arguments,omitempty"`
 	ExpectedOutput                       string  `json:"expected_output,omitempty"`
 	CPUTimeLimit                         float64 `json:"cpu_time_limit,omitempty"`  // seconds
 	CPUExtraTime                         float64 `json:"cpu_extra_time,omitempty"`  // seconds
@@ -209,3 +210,12 @@ func (c *Judge0Client) WaitForCompletion(token string, maxWaitTime time.Duration
 		time.Sleep(pollInterval)
 	}
 }
+
+// Run submits a program and waits for its result
+func (c *Judge0Client) Run(req *SubmissionRequest, maxWaitTime time.Duration) (*SubmissionStatus, error) {
+	submission, err := c.CreateSubmission(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitForCompletion(submission.Token, maxWaitTime)
+}
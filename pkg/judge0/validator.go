@@ -0,0 +1,74 @@
+package judge0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Limits of a validator run on one test input
+const (
+	validatorTimeLimitInSeconds = 5
+	validatorMemoryLimitInKB    = 256 * 1024
+)
+
+// InputValidator runs validator programs on test inputs. A validator reads
+// the input on stdin and exits with 0 when it is valid, otherwise it exits
+// with another code and prints the reason to stderr (like testlib validators).
+type InputValidator struct {
+	client *Judge0Client
+}
+
+func NewInputValidator(judge0URL string) *InputValidator {
+	return &InputValidator{
+		client: NewClient(judge0URL),
+	}
+}
+
+// ValidateInput runs the validator on an input and returns whether it is
+// valid with the validator's message. An error means the validator itself
+// could not be run: it does not compile, crashed Judge0 or was too slow.
+func (v *InputValidator) ValidateInput(ctx context.Context, language string, source string, input string) (bool, string, error) {
+	languageID, err := GetLanguageID(language)
+	if err != nil {
+		return false, "", fmt.Errorf("validator: %w", err)
+	}
+
+	status, err := v.client.Run(&SubmissionRequest{
+		SourceCode:   source,
+		LanguageID:   languageID,
+		Stdin:        input,
+		CPUTimeLimit: validatorTimeLimitInSeconds,
+		MemoryLimit:  validatorMemoryLimitInKB,
+	}, (validatorTimeLimitInSeconds+30)*time.Second)
+	if err != nil {
+		return false, "", err
+	}
+
+	switch status.Status.ID {
+	case StatusAccepted:
+		return true, "", nil
+	case StatusCompilationError:
+		message := "validator does not compile"
+		if status.CompileOutput != nil && *status.CompileOutput != "" {
+			message += ": " + strings.TrimSpace(*status.CompileOutput)
+		}
+		return false, "", errors.New(message)
+	case StatusTimeLimitExceeded:
+		return false, "", errors.New("validator exceeded the time limit")
+	case StatusRuntimeErrorNZEC:
+		// The validator rejected the input
+		message := ""
+		if status.Stderr != nil {
+			message = strings.TrimSpace(*status.Stderr)
+		}
+		if message == "" {
+			message = "the validator exited with a non-zero code"
+		}
+		return false, message, nil
+	default:
+		return false, "", fmt.Errorf("validator failed: %s", status.Status.Description)
+	}
+}
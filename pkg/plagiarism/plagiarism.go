@@ -0,0 +1,207 @@
+// Package plagiarism compares source code with winnowing, the document
+// fingerprinting used by MOSS. Code is first reduced to a token stream where
+// identifiers, literals, comments and whitespace are normalized, so renaming
+// variables or reformatting does not hide a copy.
+package plagiarism
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// KGramSize is the number of consecutive tokens hashed together
+	KGramSize = 5
+	// WindowSize is the number of consecutive k-gram hashes a fingerprint is picked from
+	WindowSize = 4
+)
+
+// Normalized tokens replacing identifiers and literals
+const (
+	identifierToken = "ID"
+	numberToken     = "NUM"
+	stringToken     = "STR"
+)
+
+var keywords = map[string]map[string]bool{
+	"python": wordSet("and as assert async await break class continue def del elif else except False finally for from global if import in is lambda None nonlocal not or pass raise return True try while with yield"),
+	"cpp":    wordSet("auto bool break case catch char class const continue default delete do double else enum false float for goto if inline int long namespace new nullptr operator private protected public return short signed sizeof static struct switch template this throw true try typedef typename union unsigned using virtual void while"),
+	"java":   wordSet("abstract boolean break byte case catch char class continue default do double else enum extends final finally float for if implements import instanceof int interface long new null package private protected public return short static super switch this throw throws true false try void while"),
+}
+
+func wordSet(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// Fingerprints is the set of selected k-gram hashes of a source file
+type Fingerprints map[uint64]struct{}
+
+// Fingerprint returns the winnowed fingerprints of source code written in
+// language (python, cpp or java)
+func Fingerprint(code string, language string) Fingerprints {
+	tokens := Tokenize(code, language)
+	fingerprints := Fingerprints{}
+	if len(tokens) == 0 {
+		return fingerprints
+	}
+
+	// Short programs are fingerprinted as a whole
+	if len(tokens) < KGramSize {
+		fingerprints[hashTokens(tokens)] = struct{}{}
+		return fingerprints
+	}
+
+	hashes := make([]uint64, 0, len(tokens)-KGramSize+1)
+	for i := 0; i+KGramSize <= len(tokens); i++ {
+		hashes = append(hashes, hashTokens(tokens[i:i+KGramSize]))
+	}
+
+	if len(hashes) <= WindowSize {
+		fingerprints[minHash(hashes)] = struct{}{}
+		return fingerprints
+	}
+
+	// Keep the smallest hash of every window, the same hash picked by
+	// overlapping windows is stored once
+	for i := 0; i+WindowSize <= len(hashes); i++ {
+		fingerprints[minHash(hashes[i:i+WindowSize])] = struct{}{}
+	}
+	return fingerprints
+}
+
+// Similarity returns the share of the fingerprints of the smaller program
+// also found in the other one, between 0 and 1. Dividing by the smaller set
+// keeps a copy padded with extra code from lowering the score.
+func Similarity(a, b Fingerprints) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	shared := 0
+	for hash := range a {
+		if _, ok := b[hash]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a))
+}
+
+// Tokenize splits source code into normalized tokens. Comments and
+// whitespace are dropped, identifiers that are not keywords of the language
+// become ID, and number and string literals become NUM and STR.
+func Tokenize(code string, language string) []string {
+	languageKeywords := keywords[language]
+	src := []rune(code)
+	var tokens []string
+
+	for i := 0; i < len(src); {
+		r := src[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case isLineComment(src, i, language):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+
+		case language != "python" && r == '/' && i+1 < len(src) && src[i+1] == '*':
+			i += 2
+			for i+1 < len(src) && (src[i] != '*' || src[i+1] != '/') {
+				i++
+			}
+			i += 2
+
+		case r == '"' || r == '\'':
+			i = skipString(src, i, language)
+			tokens = append(tokens, stringToken)
+
+		case unicode.IsDigit(r):
+			for i < len(src) && (unicode.IsDigit(src[i]) || unicode.IsLetter(src[i]) || src[i] == '.' || src[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, numberToken)
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(src[i]) || unicode.IsDigit(src[i]) || src[i] == '_') {
+				i++
+			}
+			word := string(src[start:i])
+			if languageKeywords[word] {
+				tokens = append(tokens, word)
+			} else {
+				tokens = append(tokens, identifierToken)
+			}
+
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+	return tokens
+}
+
+// isLineComment reports whether a comment running to the end of the line
+// starts at i. Preprocessor lines are skipped in C++ as includes and macros
+// say nothing about the solution.
+func isLineComment(src []rune, i int, language string) bool {
+	switch language {
+	case "python":
+		return src[i] == '#'
+	case "cpp":
+		if src[i] == '#' {
+			return true
+		}
+	}
+	return src[i] == '/' && i+1 < len(src) && src[i+1] == '/'
+}
+
+// skipString returns the position right after the string literal starting
+// at i, handling escapes and python triple quotes
+func skipString(src []rune, i int, language string) int {
+	quote := src[i]
+	if language == "python" && i+2 < len(src) && src[i+1] == quote && src[i+2] == quote {
+		i += 3
+		for i+2 < len(src) && (src[i] != quote || src[i+1] != quote || src[i+2] != quote) {
+			i++
+		}
+		return min(i+3, len(src))
+	}
+
+	i++
+	for i < len(src) && src[i] != quote && src[i] != '\n' {
+		if src[i] == '\\' {
+			i++
+		}
+		i++
+	}
+	return min(i+1, len(src))
+}
+
+func hashTokens(tokens []string) uint64 {
+	h := fnv.New64a()
+	for _, token := range tokens {
+		h.Write([]byte(token))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+func minHash(hashes []uint64) uint64 {
+	smallest := hashes[0]
+	for _, hash := range hashes[1:] {
+		if hash < smallest {
+			smallest = hash
+		}
+	}
+	return smallest
+}
@@ -0,0 +1,155 @@
+package problempackage
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// kattisConfig is the part of problem.yaml the judge uses
+type kattisConfig struct {
+	Name   string `yaml:"name"`
+	Limits struct {
+		TimeLimit float64 `yaml:"time_limit,omitempty"` // seconds
+		Memory    int     `yaml:"memory,omitempty"`     // MB
+	} `yaml:"limits"`
+	Validation string `yaml:"validation,omitempty"` // "default" or "custom"
+}
+
+// Statement files in order of preference, older packages use problem_statement/
+var kattisStatements = []string{
+	"statement/problem.en.md",
+	"statement/problem.md",
+	"problem_statement/problem.en.md",
+	"problem_statement/problem.md",
+	"statement/problem.en.tex",
+	"statement/problem.tex",
+	"problem_statement/problem.en.tex",
+	"problem_statement/problem.tex",
+}
+
+func readKattis(files packageFiles) (*Package, error) {
+	var config kattisConfig
+	err := yaml.Unmarshal([]byte(files["problem.yaml"]), &config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid problem.yaml: %w", err)
+	}
+
+	pkg := &Package{
+		Title:              config.Name,
+		TimeLimitInSeconds: int(math.Ceil(config.Limits.TimeLimit)),
+		MemoryLimitInMB:    config.Limits.Memory,
+	}
+
+	for _, name := range kattisStatements {
+		if statement, ok := files[name]; ok {
+			pkg.Statement = statement
+			break
+		}
+	}
+
+	for _, group := range []string{"data/sample", "data/secret"} {
+		for _, inputName := range files.sortedNames(group, ".in") {
+			answerName := strings.TrimSuffix(inputName, ".in") + ".ans"
+			answer, ok := files[answerName]
+			if !ok {
+				return nil, errors.New("missing answer file " + answerName)
+			}
+			pkg.Tests = append(pkg.Tests, Test{
+				Name:   strings.TrimPrefix(strings.TrimSuffix(inputName, ".in"), group+"/"),
+				Input:  files[inputName],
+				Answer: answer,
+				Sample: group == "data/sample",
+			})
+		}
+	}
+
+	pkg.Checker = kattisProgram(files, "output_validators")
+	if pkg.Checker == nil {
+		pkg.Checker = kattisProgram(files, "output_validator")
+	}
+	pkg.Validator = kattisProgram(files, "input_validators")
+	if pkg.Validator == nil {
+		pkg.Validator = kattisProgram(files, "input_validator")
+	}
+	return pkg, nil
+}
+
+// kattisProgram returns the first source file under dir, packages keep each
+// program in its own sub directory
+func kattisProgram(files packageFiles, dir string) *Program {
+	names := files.sortedNames(dir, "")
+	for _, name := range names {
+		language := programLanguage(name)
+		if language == "cpp" || language == "python" || language == "java" {
+			return &Program{FileName: path.Base(name), Language: language, Source: files[name]}
+		}
+	}
+	return nil
+}
+
+func writeKattis(zw *zip.Writer, pkg *Package) error {
+	var config kattisConfig
+	config.Name = pkg.Title
+	config.Limits.TimeLimit = float64(pkg.TimeLimitInSeconds)
+	config.Limits.Memory = pkg.MemoryLimitInMB
+	if pkg.Checker != nil {
+		config.Validation = "custom"
+	}
+	encoded, err := yaml.Marshal(&config)
+	if err != nil {
+		return err
+	}
+
+	err = writeFile(zw, "problem.yaml", string(encoded))
+	if err != nil {
+		return err
+	}
+	err = writeFile(zw, "problem_statement/problem.en.md", pkg.FullStatement())
+	if err != nil {
+		return err
+	}
+
+	for i, test := range pkg.Tests {
+		group := "data/secret"
+		if test.Sample {
+			group = "data/sample"
+		}
+		name := fmt.Sprintf("%s/%03d", group, i+1)
+		err = writeFile(zw, name+".in", test.Input)
+		if err != nil {
+			return err
+		}
+		err = writeFile(zw, name+".ans", test.Answer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pkg.Checker != nil {
+		err = writeFile(zw, "output_validators/checker/"+programFileName(pkg.Checker, "checker"), pkg.Checker.Source)
+		if err != nil {
+			return err
+		}
+	}
+	if pkg.Validator != nil {
+		err = writeFile(zw, "input_validators/validator/"+programFileName(pkg.Validator, "validator"), pkg.Validator.Source)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// programFileName keeps the original file name of a program when known
+func programFileName(program *Program, fallback string) string {
+	if program.FileName != "" {
+		return program.FileName
+	}
+	return fallback + "." + programExtension(program.Language)
+}
@@ -0,0 +1,251 @@
+// Package problempackage reads and writes zipped problem packages in the
+// Kattis (ICPC problem package) and Polygon formats.
+package problempackage
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Supported package formats
+const (
+	FormatKattis  = "kattis"
+	FormatPolygon = "polygon"
+)
+
+// MaxUncompressedSize bounds the total size of the files of a package, so a
+// small zip cannot expand into gigabytes
+const MaxUncompressedSize = 512 << 20
+
+// Package is a problem independent of the package format
+type Package struct {
+	Format             string // format the package was read from
+	Title              string
+	Statement          string // legend, or the whole statement when the package has no sections
+	InputFormat        string
+	OutputFormat       string
+	Constraints        string
+	Notes              string
+	TimeLimitInSeconds int
+	MemoryLimitInMB    int
+	Tests              []Test   // samples first, in judging order
+	Checker            *Program // nil compares the output with the answer
+	Validator          *Program // nil when the package has no input validator
+}
+
+type Test struct {
+	Name   string
+	Input  string
+	Answer string
+	Sample bool
+}
+
+// Program is the source of a checker or validator
+type Program struct {
+	FileName string
+	Language string // cpp, python, java or the file extension when unknown
+	Source   string
+}
+
+// Read parses a zipped package, format is detected from its files when empty
+func Read(r io.ReaderAt, size int64, format string) (*Package, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+	files, err := readFiles(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "" {
+		format = detectFormat(files)
+	}
+
+	var pkg *Package
+	switch format {
+	case FormatKattis:
+		pkg, err = readKattis(files)
+	case FormatPolygon:
+		pkg, err = readPolygon(files)
+	default:
+		return nil, errors.New("unknown package format, expected problem.yaml (kattis) or problem.xml (polygon)")
+	}
+	if err != nil {
+		return nil, err
+	}
+	pkg.Format = format
+
+	if pkg.Title == "" {
+		return nil, errors.New("package has no problem name")
+	}
+	if pkg.Statement == "" {
+		return nil, errors.New("package has no statement")
+	}
+	if len(pkg.Tests) == 0 {
+		return nil, errors.New("package has no tests")
+	}
+	return pkg, nil
+}
+
+// Write zips a package in the given format
+func Write(w io.Writer, pkg *Package, format string) error {
+	zw := zip.NewWriter(w)
+
+	var err error
+	switch format {
+	case FormatKattis:
+		err = writeKattis(zw, pkg)
+	case FormatPolygon:
+		err = writePolygon(zw, pkg)
+	default:
+		err = errors.New("unsupported package format " + format)
+	}
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// FullStatement joins the statement sections into a single Markdown document
+func (pkg *Package) FullStatement() string {
+	parts := []string{strings.TrimSpace(pkg.Statement)}
+	for _, section := range []struct{ title, text string }{
+		{"Input", pkg.InputFormat},
+		{"Output", pkg.OutputFormat},
+		{"Constraints", pkg.Constraints},
+		{"Notes", pkg.Notes},
+	} {
+		if text := strings.TrimSpace(section.text); text != "" {
+			parts = append(parts, "## "+section.title+"\n\n"+text)
+		}
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// packageFiles maps the slash separated paths of a package to their content
+type packageFiles map[string]string
+
+// readFiles reads every file of the archive. A single top level directory,
+// as zipping a folder produces, is stripped from the paths.
+func readFiles(zr *zip.Reader) (packageFiles, error) {
+	files, err := readArchive(zr)
+	if err != nil {
+		return nil, err
+	}
+	return stripRootDir(files), nil
+}
+
+// readArchive reads every file of the archive, rejecting paths outside of it
+// and archives expanding past MaxUncompressedSize
+func readArchive(zr *zip.Reader) (packageFiles, error) {
+	var total uint64
+	files := packageFiles{}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		name := path.Clean(strings.ReplaceAll(f.Name, "\\", "/"))
+		if strings.HasPrefix(name, "../") || strings.HasPrefix(name, "/") {
+			return nil, errors.New("invalid path in package: " + f.Name)
+		}
+
+		total += f.UncompressedSize64
+		if total > MaxUncompressedSize {
+			return nil, fmt.Errorf("package is larger than %d MB uncompressed", MaxUncompressedSize>>20)
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		// The header size can lie, never read past the limit
+		content, err := io.ReadAll(io.LimitReader(rc, MaxUncompressedSize+1))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		if len(content) > MaxUncompressedSize {
+			return nil, fmt.Errorf("package is larger than %d MB uncompressed", MaxUncompressedSize>>20)
+		}
+		files[name] = string(content)
+	}
+	return files, nil
+}
+
+func stripRootDir(files packageFiles) packageFiles {
+	root := ""
+	for name := range files {
+		dir, _, found := strings.Cut(name, "/")
+		if !found || (root != "" && dir != root) {
+			return files
+		}
+		root = dir
+	}
+
+	stripped := packageFiles{}
+	for name, content := range files {
+		stripped[strings.TrimPrefix(name, root+"/")] = content
+	}
+	return stripped
+}
+
+func detectFormat(files packageFiles) string {
+	if _, ok := files["problem.xml"]; ok {
+		return FormatPolygon
+	}
+	if _, ok := files["problem.yaml"]; ok {
+		return FormatKattis
+	}
+	return ""
+}
+
+// sortedNames returns the files in dir with the extension, sorted
+func (files packageFiles) sortedNames(dir string, extension string) []string {
+	var names []string
+	for name := range files {
+		if strings.HasPrefix(name, dir+"/") && strings.HasSuffix(name, extension) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// programLanguage guesses the language of a source file from its extension
+func programLanguage(fileName string) string {
+	switch ext := strings.TrimPrefix(path.Ext(fileName), "."); ext {
+	case "cpp", "cc", "cxx", "c++", "h", "hpp":
+		return "cpp"
+	case "py":
+		return "python"
+	default:
+		return ext
+	}
+}
+
+// programExtension is the file extension a program is exported with
+func programExtension(language string) string {
+	switch language {
+	case "python":
+		return "py"
+	case "":
+		return "txt"
+	default:
+		return language
+	}
+}
+
+func writeFile(zw *zip.Writer, name string, content string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
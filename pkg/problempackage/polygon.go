@@ -0,0 +1,266 @@
+package problempackage
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// polygonProblem is the part of problem.xml the judge uses
+type polygonProblem struct {
+	XMLName    xml.Name           `xml:"problem"`
+	ShortName  string             `xml:"short-name,attr,omitempty"`
+	Names      []polygonName      `xml:"names>name"`
+	Statements []polygonStatement `xml:"statements>statement"`
+	Testsets   []polygonTestset   `xml:"judging>testset"`
+	Checker    *polygonAsset      `xml:"assets>checker"`
+	Validators []polygonAsset     `xml:"assets>validators>validator"`
+}
+
+type polygonName struct {
+	Language string `xml:"language,attr"`
+	Value    string `xml:"value,attr"`
+}
+
+type polygonStatement struct {
+	Path     string `xml:"path,attr"`
+	Language string `xml:"language,attr"`
+	Type     string `xml:"type,attr"`
+}
+
+type polygonTestset struct {
+	Name              string        `xml:"name,attr"`
+	TimeLimit         int           `xml:"time-limit"`   // milliseconds
+	MemoryLimit       int64         `xml:"memory-limit"` // bytes
+	TestCount         int           `xml:"test-count"`
+	InputPathPattern  string        `xml:"input-path-pattern"`
+	AnswerPathPattern string        `xml:"answer-path-pattern"`
+	Tests             []polygonTest `xml:"tests>test"`
+}
+
+type polygonTest struct {
+	Method string `xml:"method,attr,omitempty"`
+	Sample bool   `xml:"sample,attr,omitempty"`
+}
+
+type polygonAsset struct {
+	Name   string `xml:"name,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Source struct {
+		Path string `xml:"path,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"source"`
+}
+
+const polygonSectionsDir = "statement-sections/english/"
+
+func readPolygon(files packageFiles) (*Package, error) {
+	var problem polygonProblem
+	err := xml.Unmarshal([]byte(files["problem.xml"]), &problem)
+	if err != nil {
+		return nil, fmt.Errorf("invalid problem.xml: %w", err)
+	}
+
+	pkg := &Package{}
+	for _, name := range problem.Names {
+		if pkg.Title == "" || name.Language == "english" {
+			pkg.Title = name.Value
+		}
+	}
+	readPolygonStatement(files, problem.Statements, pkg)
+
+	var testset *polygonTestset
+	for i := range problem.Testsets {
+		if testset == nil || problem.Testsets[i].Name == "tests" {
+			testset = &problem.Testsets[i]
+		}
+	}
+	if testset == nil {
+		return nil, errors.New("problem.xml has no testset")
+	}
+	pkg.TimeLimitInSeconds = (testset.TimeLimit + 999) / 1000
+	pkg.MemoryLimitInMB = int(testset.MemoryLimit >> 20)
+
+	count := max(testset.TestCount, len(testset.Tests))
+	for i := 1; i <= count; i++ {
+		inputName := polygonTestPath(testset.InputPathPattern, "tests/%02d", i)
+		answerName := polygonTestPath(testset.AnswerPathPattern, "tests/%02d.a", i)
+		input, ok := files[inputName]
+		if !ok {
+			return nil, errors.New("missing test input " + inputName + ", generated tests must be included in the package")
+		}
+		answer, ok := files[answerName]
+		if !ok {
+			return nil, errors.New("missing test answer " + answerName)
+		}
+		pkg.Tests = append(pkg.Tests, Test{
+			Name:   strconv.Itoa(i),
+			Input:  input,
+			Answer: answer,
+			Sample: i <= len(testset.Tests) && testset.Tests[i-1].Sample,
+		})
+	}
+	pkg.Tests = samplesFirst(pkg.Tests)
+
+	if problem.Checker != nil {
+		pkg.Checker = polygonProgram(files, problem.Checker)
+	}
+	if len(problem.Validators) > 0 {
+		pkg.Validator = polygonProgram(files, &problem.Validators[0])
+	}
+	return pkg, nil
+}
+
+// readPolygonStatement reads the english statement sections when the
+// package has them, otherwise the whole statement file becomes the legend
+func readPolygonStatement(files packageFiles, statements []polygonStatement, pkg *Package) {
+	pkg.Statement = strings.TrimSpace(files[polygonSectionsDir+"legend.tex"])
+	if pkg.Statement != "" {
+		pkg.InputFormat = strings.TrimSpace(files[polygonSectionsDir+"input.tex"])
+		pkg.OutputFormat = strings.TrimSpace(files[polygonSectionsDir+"output.tex"])
+		pkg.Notes = strings.TrimSpace(files[polygonSectionsDir+"notes.tex"])
+		return
+	}
+
+	for _, s := range statements {
+		if s.Type == "application/pdf" {
+			continue
+		}
+		if text, ok := files[s.Path]; ok && (pkg.Statement == "" || s.Language == "english") {
+			pkg.Statement = text
+		}
+	}
+}
+
+func polygonTestPath(pattern string, fallback string, index int) string {
+	if pattern == "" {
+		pattern = fallback
+	}
+	return fmt.Sprintf(pattern, index)
+}
+
+func polygonProgram(files packageFiles, asset *polygonAsset) *Program {
+	source, ok := files[asset.Source.Path]
+	if !ok {
+		return nil
+	}
+	return &Program{
+		FileName: path.Base(asset.Source.Path),
+		Language: programLanguage(asset.Source.Path),
+		Source:   source,
+	}
+}
+
+// samplesFirst moves the samples ahead of the other tests, keeping the order
+// within each group
+func samplesFirst(tests []Test) []Test {
+	sorted := make([]Test, 0, len(tests))
+	for _, test := range tests {
+		if test.Sample {
+			sorted = append(sorted, test)
+		}
+	}
+	for _, test := range tests {
+		if !test.Sample {
+			sorted = append(sorted, test)
+		}
+	}
+	return sorted
+}
+
+// polygonSourceTypes are the Polygon compiler names programs are exported with
+var polygonSourceTypes = map[string]string{
+	"cpp":    "cpp.g++17",
+	"python": "python.3",
+	"java":   "java11",
+}
+
+func writePolygon(zw *zip.Writer, pkg *Package) error {
+	problem := polygonProblem{
+		Names:      []polygonName{{Language: "english", Value: pkg.Title}},
+		Statements: []polygonStatement{{Path: "statements/english/problem.tex", Language: "english", Type: "application/x-tex"}},
+	}
+
+	testset := polygonTestset{
+		Name:              "tests",
+		TimeLimit:         pkg.TimeLimitInSeconds * 1000,
+		MemoryLimit:       int64(pkg.MemoryLimitInMB) << 20,
+		TestCount:         len(pkg.Tests),
+		InputPathPattern:  "tests/%02d",
+		AnswerPathPattern: "tests/%02d.a",
+	}
+	for i, test := range pkg.Tests {
+		testset.Tests = append(testset.Tests, polygonTest{Method: "manual", Sample: test.Sample})
+
+		err := writeFile(zw, fmt.Sprintf(testset.InputPathPattern, i+1), test.Input)
+		if err != nil {
+			return err
+		}
+		err = writeFile(zw, fmt.Sprintf(testset.AnswerPathPattern, i+1), test.Answer)
+		if err != nil {
+			return err
+		}
+	}
+	problem.Testsets = []polygonTestset{testset}
+
+	if pkg.Checker != nil {
+		problem.Checker = &polygonAsset{Name: "checker", Type: "testlib"}
+		err := writePolygonProgram(zw, problem.Checker, pkg.Checker, "check")
+		if err != nil {
+			return err
+		}
+	}
+	if pkg.Validator != nil {
+		validator := polygonAsset{}
+		err := writePolygonProgram(zw, &validator, pkg.Validator, "validator")
+		if err != nil {
+			return err
+		}
+		problem.Validators = []polygonAsset{validator}
+	}
+
+	encoded, err := xml.MarshalIndent(&problem, "", "    ")
+	if err != nil {
+		return err
+	}
+	err = writeFile(zw, "problem.xml", xml.Header+string(encoded)+"\n")
+	if err != nil {
+		return err
+	}
+
+	err = writeFile(zw, "statements/english/problem.tex", pkg.FullStatement())
+	if err != nil {
+		return err
+	}
+
+	// Polygon has no constraints section, they usually close the input section
+	input := pkg.InputFormat
+	if pkg.Constraints != "" {
+		input = strings.TrimSpace(input + "\n\n" + pkg.Constraints)
+	}
+	for file, text := range map[string]string{
+		"legend.tex": pkg.Statement,
+		"input.tex":  input,
+		"output.tex": pkg.OutputFormat,
+		"notes.tex":  pkg.Notes,
+	} {
+		if text == "" {
+			continue
+		}
+		err = writeFile(zw, polygonSectionsDir+file, text)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writePolygonProgram(zw *zip.Writer, asset *polygonAsset, program *Program, fallback string) error {
+	asset.Source.Path = "files/" + programFileName(program, fallback)
+	asset.Source.Type = polygonSourceTypes[program.Language]
+	return writeFile(zw, asset.Source.Path, program.Source)
+}
@@ -0,0 +1,132 @@
+package problempackage
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ReadTests parses a zipped test archive of NN.in files with their answers
+// in NN.out or NN.ans. Tests inside a sample or samples directory, or whose
+// name starts with "sample", are samples. Samples come first, each group is
+// in natural order of the names, so 2 comes before 10.
+func ReadTests(r io.ReaderAt, size int64) ([]Test, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+	// Directories are kept, a sample directory marks its tests
+	files, err := readArchive(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	var tests []Test
+	for name, content := range files {
+		extension := path.Ext(name)
+		base := strings.TrimSuffix(name, extension)
+		switch extension {
+		case ".in":
+			output, hasOutput := files[base+".out"]
+			answer, hasAnswer := files[base+".ans"]
+			if hasOutput && hasAnswer {
+				return nil, fmt.Errorf("test %s has both %s.out and %s.ans", base, base, base)
+			}
+			if !hasOutput && !hasAnswer {
+				return nil, fmt.Errorf("test %s has no answer, expected %s.out or %s.ans", base, base, base)
+			}
+			if hasAnswer {
+				output = answer
+			}
+			tests = append(tests, Test{
+				Name:   base,
+				Input:  content,
+				Answer: output,
+				Sample: isSampleTest(base),
+			})
+		case ".out", ".ans":
+			if _, ok := files[base+".in"]; !ok {
+				return nil, fmt.Errorf("answer %s has no input %s.in", name, base)
+			}
+		}
+	}
+	if len(tests) == 0 {
+		return nil, errors.New("archive has no tests, expected NN.in files with NN.out or NN.ans answers")
+	}
+
+	sort.Slice(tests, func(i, j int) bool {
+		if tests[i].Sample != tests[j].Sample {
+			return tests[i].Sample
+		}
+		return naturalLess(tests[i].Name, tests[j].Name)
+	})
+	return tests, nil
+}
+
+// WriteTests zips tests in the layout ReadTests reads, samples in sample/ and
+// the other tests at the root, numbered in order
+func WriteTests(w io.Writer, tests []Test) error {
+	zw := zip.NewWriter(w)
+
+	width := max(2, len(fmt.Sprint(len(tests))))
+	for i, test := range tests {
+		name := fmt.Sprintf("%0*d", width, i+1)
+		if test.Sample {
+			name = "sample/" + name
+		}
+		err := writeFile(zw, name+".in", test.Input)
+		if err != nil {
+			return err
+		}
+		err = writeFile(zw, name+".out", test.Answer)
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func isSampleTest(name string) bool {
+	dir, base := path.Split(strings.ToLower(name))
+	for _, part := range strings.Split(strings.Trim(dir, "/"), "/") {
+		if part == "sample" || part == "samples" {
+			return true
+		}
+	}
+	return strings.HasPrefix(base, "sample")
+}
+
+// naturalLess compares names with runs of digits compared by their value
+func naturalLess(a string, b string) bool {
+	for a != "" && b != "" {
+		aDigits, bDigits := leadingDigits(a), leadingDigits(b)
+		if aDigits != "" && bDigits != "" {
+			aValue, bValue := strings.TrimLeft(aDigits, "0"), strings.TrimLeft(bDigits, "0")
+			if len(aValue) != len(bValue) {
+				return len(aValue) < len(bValue)
+			}
+			if aValue != bValue {
+				return aValue < bValue
+			}
+			a, b = a[len(aDigits):], b[len(bDigits):]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
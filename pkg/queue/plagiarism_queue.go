@@ -0,0 +1,79 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// Plagiarism task types
+const (
+	TypePlagiarismCheck = "plagiarism:check" // checks one contest
+	TypePlagiarismSweep = "plagiarism:sweep" // checks every ended contest not checked yet
+)
+
+// PlagiarismQueueName is the asynq queue plagiarism tasks run on
+const PlagiarismQueueName = "plagiarism"
+
+type PlagiarismPayload struct {
+	ContestID string `json:"contest_id"`
+}
+
+// PlagiarismQueue enqueues plagiarism checks
+type PlagiarismQueue struct {
+	client *asynq.Client
+}
+
+// NewPlagiarismQueue creates a new plagiarism queue client
+func NewPlagiarismQueue(redisURL string) (*PlagiarismQueue, error) {
+	client := asynq.NewClient(asynq.RedisClientOpt{
+		Addr: redisURL,
+	})
+
+	return &PlagiarismQueue{
+		client: client,
+	}, nil
+}
+
+// EnqueueCheck queues a plagiarism check of a contest, a check already queued
+// for the same contest is not duplicated
+func (pq *PlagiarismQueue) EnqueueCheck(ctx context.Context, contestID string) error {
+	payloadBytes, err := json.Marshal(PlagiarismPayload{ContestID: contestID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypePlagiarismCheck, payloadBytes,
+		asynq.MaxRetry(3),
+		asynq.Timeout(30*time.Minute),
+		asynq.Queue(PlagiarismQueueName),
+		asynq.Unique(time.Hour),
+	)
+
+	info, err := pq.client.EnqueueContext(ctx, task)
+	if err != nil {
+		if errors.Is(err, asynq.ErrDuplicateTask) {
+			return errors.New("a plagiarism check is already queued for this contest")
+		}
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Printf("Enqueued plagiarism check of contest %s to queue: %s", contestID, info.Queue)
+	return nil
+}
+
+// Close closes the queue client
+func (pq *PlagiarismQueue) Close() error {
+	return pq.client.Close()
+}
+
+// PlagiarismQueueInterface defines the interface for plagiarism queue operations
+type PlagiarismQueueInterface interface {
+	EnqueueCheck(ctx context.Context, contestID string) error
+	Close() error
+}
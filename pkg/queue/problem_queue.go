@@ -0,0 +1,9 @@
+package queue
+
+// Problem task types
+const (
+	TypeProblemPublish = "problem:publish" // publishes the problems of ended contests
+)
+
+// ProblemQueueName is the asynq queue problem maintenance tasks run on
+const ProblemQueueName = "problem"
@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// Reference solution task types
+const (
+	TypeReferenceSolutionJudge = "reference:judge"
+)
+
+// ReferenceQueueName is the asynq queue reference solutions are judged on
+const ReferenceQueueName = "reference"
+
+type ReferenceSolutionPayload struct {
+	SolutionID string `json:"solution_id"`
+	RunID      string `json:"run_id"`
+}
+
+// ReferenceSolutionQueue enqueues runs of reference solutions
+type ReferenceSolutionQueue struct {
+	client *asynq.Client
+}
+
+// NewReferenceSolutionQueue creates a new reference solution queue client
+func NewReferenceSolutionQueue(redisURL string) (*ReferenceSolutionQueue, error) {
+	client := asynq.NewClient(asynq.RedisClientOpt{
+		Addr: redisURL,
+	})
+
+	return &ReferenceSolutionQueue{
+		client: client,
+	}, nil
+}
+
+// EnqueueRun queues a run of a reference solution against every test case
+func (rq *ReferenceSolutionQueue) EnqueueRun(ctx context.Context, solutionID string, runID string) error {
+	payloadBytes, err := json.Marshal(ReferenceSolutionPayload{SolutionID: solutionID, RunID: runID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypeReferenceSolutionJudge, payloadBytes,
+		asynq.MaxRetry(3),
+		asynq.Timeout(30*time.Minute), // every test case, possibly up to the time limit
+		asynq.Queue(ReferenceQueueName),
+		asynq.Retention(24*time.Hour),
+		asynq.TaskID(runID),
+	)
+
+	info, err := rq.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Printf("Enqueued reference solution %s to queue: %s", solutionID, info.Queue)
+	return nil
+}
+
+// Close closes the queue client
+func (rq *ReferenceSolutionQueue) Close() error {
+	return rq.client.Close()
+}
+
+// ReferenceSolutionQueueInterface defines the interface for reference solution queue operations
+type ReferenceSolutionQueueInterface interface {
+	EnqueueRun(ctx context.Context, solutionID string, runID string) error
+	Close() error
+}
@@ -1,7 +1,6 @@
 package queue
 
 import (
-	"algoforces/internal/domain"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -16,17 +15,12 @@ const (
 	TypeSubmissionJudge = "submission:judge"
 )
 
+// SubmissionPayload only references the submission, the worker loads its
+// code and the test set of its problem revision
 type SubmissionPayload struct {
-	SubmissionID      string            `json:"submission_id"`
-	ProblemID         string            `json:"problem_id"`
-	UserID            string            `json:"user_id"`
-	ContestID         string            `json:"contest_id"`
-	Code              string            `json:"code"`
-	Language          string            `json:"language"`
-	VisibleTestCases  []domain.TestCase `json:"visible_test_cases"`
-	HiddenTestCases   []domain.TestCase `json:"hidden_test_cases"`
-	TimeLimitInSecond int               `json:"time_limit"`
-	MemoryLimitInMB   int               `json:"memory_limit"`
+	SubmissionID      string `json:"submission_id"`
+	ProblemID         string `json:"problem_id"`
+	ProblemRevisionID string `json:"problem_revision_id"`
 }
 
 // SubmissionQueue manages the Redis queue for submissions
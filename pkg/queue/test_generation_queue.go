@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// Test generation task types
+const (
+	TypeTestGeneration = "tests:generate"
+)
+
+// GenerationQueueName is the asynq queue generator scripts run on
+const GenerationQueueName = "generation"
+
+type TestGenerationPayload struct {
+	GenerationID string `json:"generation_id"`
+}
+
+// TestGenerationQueue enqueues runs of generator scripts
+type TestGenerationQueue struct {
+	client *asynq.Client
+}
+
+// NewTestGenerationQueue creates a new test generation queue client
+func NewTestGenerationQueue(redisURL string) (*TestGenerationQueue, error) {
+	client := asynq.NewClient(asynq.RedisClientOpt{
+		Addr: redisURL,
+	})
+
+	return &TestGenerationQueue{
+		client: client,
+	}, nil
+}
+
+// EnqueueGeneration queues the run of a generator script
+func (gq *TestGenerationQueue) EnqueueGeneration(ctx context.Context, generationID string) error {
+	payloadBytes, err := json.Marshal(TestGenerationPayload{GenerationID: generationID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	// Failures are stored on the generation, a failed script is not retried
+	task := asynq.NewTask(TypeTestGeneration, payloadBytes,
+		asynq.MaxRetry(0),
+		asynq.Timeout(time.Hour),
+		asynq.Queue(GenerationQueueName),
+		asynq.Retention(24*time.Hour),
+		asynq.TaskID(generationID),
+	)
+
+	info, err := gq.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Printf("Enqueued test generation %s to queue: %s", generationID, info.Queue)
+	return nil
+}
+
+// Close closes the queue client
+func (gq *TestGenerationQueue) Close() error {
+	return gq.client.Close()
+}
+
+// TestGenerationQueueInterface defines the interface for test generation queue operations
+type TestGenerationQueueInterface interface {
+	EnqueueGeneration(ctx context.Context, generationID string) error
+	Close() error
+}
@@ -0,0 +1,59 @@
+package queue
+
+import (
+	"context"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestSetChannel is the Redis channel changes of test sets are published on,
+// messages are problem IDs
+const TestSetChannel = "tests:changed"
+
+// TestSetNotifier tells workers the test set of a problem changed, so they
+// drop their cached copy
+type TestSetNotifier struct {
+	client *redis.Client
+}
+
+// NewTestSetNotifier creates a new test set notifier client
+func NewTestSetNotifier(redisURL string) (*TestSetNotifier, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr: redisURL,
+	})
+
+	return &TestSetNotifier{
+		client: client,
+	}, nil
+}
+
+// NotifyTestSetChanged publishes that the test set of a problem changed
+func (tn *TestSetNotifier) NotifyTestSetChanged(ctx context.Context, problemID string) error {
+	return tn.client.Publish(ctx, TestSetChannel, problemID).Err()
+}
+
+// Subscribe calls fn with the problem ID of every published change until ctx
+// is done
+func (tn *TestSetNotifier) Subscribe(ctx context.Context, fn func(problemID string)) {
+	pubsub := tn.client.Subscribe(ctx, TestSetChannel)
+	defer pubsub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-pubsub.Channel():
+			if !ok {
+				log.Printf("Test set change subscription closed")
+				return
+			}
+			fn(message.Payload)
+		}
+	}
+}
+
+// Close closes the notifier client
+func (tn *TestSetNotifier) Close() error {
+	return tn.client.Close()
+}
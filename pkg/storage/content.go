@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ContentStore stores blobs under the sha256 of their content, so identical
+// content is stored once and a stored blob never changes. Blobs are not
+// deleted with the rows referencing them: other rows, problem revisions and
+// queued submissions may still need them.
+type ContentStore struct {
+	blobs  BlobStorage
+	prefix string
+}
+
+// NewContentStore returns a content store keeping its blobs under prefix
+func NewContentStore(blobs BlobStorage, prefix string) *ContentStore {
+	return &ContentStore{blobs: blobs, prefix: prefix}
+}
+
+// HashContent returns the hash content is stored under
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Put stores content unless it is already stored and returns its hash
+func (s *ContentStore) Put(ctx context.Context, content []byte) (string, error) {
+	hash := HashContent(content)
+	exists, err := s.blobs.Exists(ctx, s.key(hash))
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return hash, nil
+	}
+
+	_, err = s.blobs.Put(ctx, s.key(hash), bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// Get returns the content stored under a hash, checking it was not corrupted
+func (s *ContentStore) Get(ctx context.Context, hash string) ([]byte, error) {
+	if len(hash) != sha256.Size*2 {
+		return nil, errors.New("invalid content hash " + hash)
+	}
+
+	r, err := s.blobs.Get(ctx, s.key(hash))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if HashContent(content) != hash {
+		return nil, fmt.Errorf("content of blob %s does not match its hash", hash)
+	}
+	return content, nil
+}
+
+// Exists reports whether content is stored under a hash
+func (s *ContentStore) Exists(ctx context.Context, hash string) (bool, error) {
+	if len(hash) != sha256.Size*2 {
+		return false, errors.New("invalid content hash " + hash)
+	}
+	return s.blobs.Exists(ctx, s.key(hash))
+}
+
+// Delete removes the content stored under a hash. Only caches delete
+// content, other stores cannot know whether it is still referenced.
+func (s *ContentStore) Delete(ctx context.Context, hash string) error {
+	if len(hash) != sha256.Size*2 {
+		return errors.New("invalid content hash " + hash)
+	}
+	return s.blobs.Delete(ctx, s.key(hash))
+}
+
+// key spreads blobs over directories named after the first two hex digits
+func (s *ContentStore) key(hash string) string {
+	return s.prefix + "/" + hash[:2] + "/" + hash
+}
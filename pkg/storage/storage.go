@@ -0,0 +1,41 @@
+// Package storage stores binary blobs such as problem attachments and test
+// data behind the BlobStorage interface. Its operations map to the object
+// operations of S3-compatible stores, so the local filesystem can be swapped
+// for an object store.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrNotFound is returned when no blob is stored under a key
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStorage stores blobs under slash separated keys such as
+// "problems/<id>/attachments/<id>"
+type BlobStorage interface {
+	// Put stores the content of r under key, replacing any previous blob, and returns its size
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Get opens the blob stored under key, the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key, a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+	// Exists reports whether a blob is stored under key
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// validKey rejects keys that could escape the storage root
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return errors.New("invalid blob key " + key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return errors.New("invalid blob key " + key)
+		}
+	}
+	return nil
+}
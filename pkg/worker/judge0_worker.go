@@ -4,6 +4,7 @@ import (
 	"algoforces/internal/domain"
 	"algoforces/pkg/judge0"
 	"algoforces/pkg/queue"
+	"algoforces/pkg/storage"
 	"context"
 	"encoding/json"
 	"errors"
@@ -17,16 +18,28 @@ import (
 
 type JudgeWorker struct {
 	submissionRepo domain.SubmissionRepository
+	revisionRepo   domain.ProblemRevisionRepository
+	testSets       *testSetCache
 	Judge0Client   *judge0.Judge0Client
 }
 
-func NewJudgeWorker(submissionRepo domain.SubmissionRepository, judge0URL string) *JudgeWorker {
+// NewJudgeWorker returns a worker fetching test data from testData and
+// caching it in testCache on disk. Up to testSetCacheBytes of test sets are
+// kept in memory.
+func NewJudgeWorker(submissionRepo domain.SubmissionRepository, revisionRepo domain.ProblemRevisionRepository, testData *storage.ContentStore, testCache *storage.ContentStore, testSetCacheBytes int64, judge0URL string) *JudgeWorker {
 	return &JudgeWorker{
 		submissionRepo: submissionRepo,
+		revisionRepo:   revisionRepo,
+		testSets:       newTestSetCache(submissionRepo, revisionRepo, newTestDataCache(testCache, testData), testSetCacheBytes),
 		Judge0Client:   judge0.NewClient(judge0URL),
 	}
 }
 
+// InvalidateTestSet drops the cached test set of a problem whose test cases changed
+func (jw *JudgeWorker) InvalidateTestSet(problemID string) {
+	jw.testSets.invalidate(problemID)
+}
+
 func (jw *JudgeWorker) JudgeSubmission(ctx context.Context, task *asynq.Task) error {
 	//Parse task payload
 	var payload queue.SubmissionPayload
@@ -41,50 +54,39 @@ func (jw *JudgeWorker) JudgeSubmission(ctx context.Context, task *asynq.Task) er
 		return err
 	}
 
+	submission, err := jw.submissionRepo.GetSubmissionDetails(ctx, payload.SubmissionID)
+	if err != nil {
+		return err
+	}
+	revision, testCases, err := jw.loadTestSet(ctx, submission)
+	if errors.Is(err, errTestSetChanged) {
+		// Retrying cannot bring the tests of the revision back
+		log.Printf("Tests of the revision of submission %s are gone: %v", payload.SubmissionID, err)
+		return jw.updateSubmissionError(ctx, payload.SubmissionID, domain.VerdictSystemError, 0, 0, 0, 0, []string{"The tests of the problem revision this submission was made against are no longer available"})
+	}
+	if err != nil {
+		return err
+	}
+
 	// Get language ID
-	languageID, err := judge0.GetLanguageID(payload.Language)
+	languageID, err := judge0.GetLanguageID(submission.Language)
 	if err != nil {
 		return errors.New("failed to get language ID")
 	}
 	log.Printf("Language ID: %d", languageID)
-	log.Printf("Visible Test Cases: %d", len(payload.VisibleTestCases))
-	log.Printf("Hidden Test Cases: %d", len(payload.HiddenTestCases))
 
-	TotalTestCases := len(payload.VisibleTestCases) + len(payload.HiddenTestCases)
+	// Tests run in judging order and are numbered by it, judging stops at
+	// the first failed test
+	TotalTestCases := len(testCases)
 	passedTests := 0
 	var testResults []string
 	var maxTime float64
 	var maxMemory int
 
-	isVisibleTestCaseFailed := false
-	for i, testCase := range payload.VisibleTestCases {
+	for i, testCase := range testCases {
 		log.Printf("Running test case %d/%d for submission %s", i+1, TotalTestCases, payload.SubmissionID)
 
-		// Create submission request
-		submissionReqData := &judge0.SubmissionRequest{
-			SourceCode:     payload.Code,
-			LanguageID:     languageID,
-			Stdin:          testCase.Input,
-			CPUTimeLimit:   float64(payload.TimeLimitInSecond),
-			MemoryLimit:    payload.MemoryLimitInMB * 1024, // Convert MB to KB
-			ExpectedOutput: testCase.ExpectedOutput,
-		}
-
-		// SUBMIT TO JUDGE0
-		submissionResponse, err := jw.Judge0Client.CreateSubmission(submissionReqData)
-		if err != nil {
-			return err
-		}
-
-		// Wait for result - add buffer time for Judge0 queue latency (30 seconds)
-		maxWaitTime := time.Duration(payload.TimeLimitInSecond+30) * time.Second
-		_, err = jw.Judge0Client.WaitForCompletion(submissionResponse.Token, maxWaitTime)
-		if err != nil {
-			return err
-		}
-
-		// Get the final submission status with details
-		finalResponse, err := jw.Judge0Client.GetSubmissionStatus(submissionResponse.Token)
+		finalResponse, err := runTestCase(jw.Judge0Client, submission.Code, languageID, testCase, revision.TimeLimitInSeconds, revision.MemoryLimitInMB)
 		if err != nil {
 			return err
 		}
@@ -104,89 +106,86 @@ func (jw *JudgeWorker) JudgeSubmission(ctx context.Context, task *asynq.Task) er
 		}
 
 		// Map Judge0 status to our verdict
-		verdict := jw.mapJudge0Status(finalResponse.Status.ID)
+		verdict := mapJudge0Status(finalResponse.Status.ID)
 
 		// Create comprehensive test result using shared function
-		testResult := jw.formatTestResult(testCase, finalResponse, i+1, false)
+		testResult := jw.formatTestResult(testCase, finalResponse, i+1, testCase.IsHidden)
 		testResults = append(testResults, testResult)
 
 		if verdict == domain.VerdictAccepted {
 			passedTests++
 		} else {
-			isVisibleTestCaseFailed = true
+			return jw.updateSubmissionError(ctx, payload.SubmissionID, verdict, passedTests, TotalTestCases, maxTime, maxMemory, testResults)
 		}
 	}
-	if isVisibleTestCaseFailed {
-		return jw.updateSubmissionError(ctx, payload.SubmissionID, domain.VerdictWrongAnswer, passedTests, TotalTestCases, maxTime, maxMemory, testResults)
-	}
-	for i, testCase := range payload.HiddenTestCases {
-		log.Printf("Running test case %d/%d for submission %s", i+1, TotalTestCases, payload.SubmissionID)
 
-		// Create submission request
-		submissionReqData := &judge0.SubmissionRequest{
-			SourceCode:     payload.Code,
-			LanguageID:     languageID,
-			Stdin:          testCase.Input,
-			CPUTimeLimit:   float64(payload.TimeLimitInSecond),
-			MemoryLimit:    payload.MemoryLimitInMB * 1024, // Convert MB to KB
-			ExpectedOutput: testCase.ExpectedOutput,
-		}
-
-		// SUBMIT TO JUDGE0
-		submissionResponse, err := jw.Judge0Client.CreateSubmission(submissionReqData)
-		if err != nil {
-			return err
-		}
+	// All tests passed - update submission with success
+	finalVerdict := domain.VerdictAccepted
+	return jw.updateSubmissionSuccess(ctx, payload.SubmissionID, finalVerdict, passedTests, TotalTestCases, testResults, maxTime, maxMemory)
+}
 
-		// Wait for result - add buffer time for Judge0 queue latency (30 seconds)
-		maxWaitTime := time.Duration(payload.TimeLimitInSecond+30) * time.Second
-		_, err = jw.Judge0Client.WaitForCompletion(submissionResponse.Token, maxWaitTime)
+// loadTestSet returns the revision a submission is judged against with its
+// test cases in judging order. Submissions made before revisions existed are
+// pinned to the latest revision.
+func (jw *JudgeWorker) loadTestSet(ctx context.Context, submission *domain.Submission) (*domain.ProblemRevision, []domain.TestCase, error) {
+	if submission.ProblemRevisionID != nil {
+		revision, err := jw.revisionRepo.GetRevisionByID(ctx, *submission.ProblemRevisionID)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-
-		// Get the final submission status with details
-		finalResponse, err := jw.Judge0Client.GetSubmissionStatus(submissionResponse.Token)
+		testCases, err := jw.testSets.get(ctx, revision)
 		if err != nil {
-			return err
-		}
-
-		// Update max time and memory
-		if finalResponse.Time != nil {
-			if timeVal, err := strconv.ParseFloat(*finalResponse.Time, 64); err == nil {
-				timeInMS := timeVal * 1000.0 // Convert seconds to milliseconds
-				if timeInMS > maxTime {
-					maxTime = timeInMS
-				}
-			}
+			return nil, nil, err
 		}
+		return revision, testCases, nil
+	}
 
-		if finalResponse.Memory != nil && *finalResponse.Memory > maxMemory {
-			maxMemory = *finalResponse.Memory
-		}
+	revision, err := jw.revisionRepo.GetLatestRevision(ctx, submission.ProblemID)
+	if err != nil {
+		return nil, nil, err
+	}
+	testCases, err := jw.testSets.get(ctx, revision)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = jw.submissionRepo.UpdateSubmissionResult(ctx, submission.UniqueID, &domain.Submission{ProblemRevisionID: &revision.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return revision, testCases, nil
+}
 
-		// Map Judge0 status to our verdict
-		verdict := jw.mapJudge0Status(finalResponse.Status.ID)
+// runTestCase runs code on one test case through Judge0 and waits for its result
+func runTestCase(client *judge0.Judge0Client, code string, languageID int, testCase domain.TestCase, timeLimitInSeconds int, memoryLimitInMB int) (*judge0.SubmissionStatus, error) {
+	// Create submission request
+	submissionReqData := &judge0.SubmissionRequest{
+		SourceCode:     code,
+		LanguageID:     languageID,
+		Stdin:          testCase.Input,
+		CPUTimeLimit:   float64(timeLimitInSeconds),
+		MemoryLimit:    memoryLimitInMB * 1024, // Convert MB to KB
+		ExpectedOutput: testCase.ExpectedOutput,
+	}
 
-		// Create comprehensive test result using shared function
-		testNum := len(payload.VisibleTestCases) + i + 1
-		testResult := jw.formatTestResult(testCase, finalResponse, testNum, true)
-		testResults = append(testResults, testResult)
+	// SUBMIT TO JUDGE0
+	submissionResponse, err := client.CreateSubmission(submissionReqData)
+	if err != nil {
+		return nil, err
+	}
 
-		if verdict == domain.VerdictAccepted {
-			passedTests++
-		} else {
-			return jw.updateSubmissionError(ctx, payload.SubmissionID, verdict, passedTests, TotalTestCases, maxTime, maxMemory, testResults)
-		}
+	// Wait for result - add buffer time for Judge0 queue latency (30 seconds)
+	maxWaitTime := time.Duration(timeLimitInSeconds+30) * time.Second
+	_, err = client.WaitForCompletion(submissionResponse.Token, maxWaitTime)
+	if err != nil {
+		return nil, err
 	}
 
-	// All tests passed - update submission with success
-	finalVerdict := domain.VerdictAccepted
-	return jw.updateSubmissionSuccess(ctx, payload.SubmissionID, finalVerdict, passedTests, TotalTestCases, testResults, maxTime, maxMemory)
+	// Get the final submission status with details
+	return client.GetSubmissionStatus(submissionResponse.Token)
 }
 
 // mapJudge0Status maps Judge0 status to our verdict
-func (w *JudgeWorker) mapJudge0Status(statusID int) domain.VerdictStatus {
+func mapJudge0Status(statusID int) domain.VerdictStatus {
 	switch statusID {
 	case judge0.StatusAccepted:
 		return domain.VerdictAccepted
@@ -211,7 +210,7 @@ func (w *JudgeWorker) mapJudge0Status(statusID int) domain.VerdictStatus {
 // formatTestResult creates a comprehensive single-line test result
 func (w *JudgeWorker) formatTestResult(testCase domain.TestCase, status *judge0.SubmissionStatus, testNum int, isHidden bool) string {
 	// Get the verdict for this test
-	verdict := w.mapJudge0Status(status.Status.ID)
+	verdict := mapJudge0Status(status.Status.ID)
 
 	// Create comprehensive test result
 	testResult := fmt.Sprintf("Test %d (%s): %s", testNum, testCase.UniqueID, verdict)
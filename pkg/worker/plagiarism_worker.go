@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/plagiarism"
+	"algoforces/pkg/queue"
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+// suspiciousSimilarity is the similarity from which a pair of submissions is
+// stored as a plagiarism case
+const suspiciousSimilarity = 0.8
+
+type PlagiarismWorker struct {
+	contestRepo    domain.ContestRepository
+	submissionRepo domain.SubmissionRepository
+	plagiarismRepo domain.PlagiarismRepository
+}
+
+func NewPlagiarismWorker(contestRepo domain.ContestRepository, submissionRepo domain.SubmissionRepository, plagiarismRepo domain.PlagiarismRepository) *PlagiarismWorker {
+	return &PlagiarismWorker{
+		contestRepo:    contestRepo,
+		submissionRepo: submissionRepo,
+		plagiarismRepo: plagiarismRepo,
+	}
+}
+
+// CheckContest runs the plagiarism check of the contest in the task payload
+func (pw *PlagiarismWorker) CheckContest(ctx context.Context, task *asynq.Task) error {
+	var payload queue.PlagiarismPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return err
+	}
+
+	contest, err := pw.contestRepo.GetByID(ctx, payload.ContestID)
+	if err != nil {
+		return err
+	}
+
+	return pw.checkContest(ctx, contest)
+}
+
+// SweepEndedContests checks every ended contest that was never checked
+func (pw *PlagiarismWorker) SweepEndedContests(ctx context.Context, task *asynq.Task) error {
+	contests, err := pw.contestRepo.GetContestsPendingPlagiarismCheck(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range contests {
+		err = pw.checkContest(ctx, &contests[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkContest compares the first accepted submissions of every participant
+// on each problem of the contest. In team contests submissions of the same
+// team are not compared.
+func (pw *PlagiarismWorker) checkContest(ctx context.Context, contest *domain.Contest) error {
+	log.Printf("Running plagiarism check for contest %s", contest.Id)
+
+	contestProblems, err := pw.contestRepo.GetContestProblems(ctx, contest.Id)
+	if err != nil {
+		return err
+	}
+
+	found := 0
+	for _, contestProblem := range contestProblems {
+		submissions, err := pw.submissionRepo.GetAcceptedProblemSubmissions(ctx, contest.Id, contestProblem.ProblemID)
+		if err != nil {
+			return err
+		}
+
+		// Submissions are oldest first, keep the one that counted for each participant
+		var candidates []domain.Submission
+		seen := map[string]bool{}
+		for _, submission := range submissions {
+			participant := submission.UserId
+			if submission.TeamID != nil {
+				participant = "team:" + *submission.TeamID
+			}
+			if seen[participant] {
+				continue
+			}
+			seen[participant] = true
+			candidates = append(candidates, submission)
+		}
+
+		fingerprints := make([]plagiarism.Fingerprints, len(candidates))
+		for i, submission := range candidates {
+			fingerprints[i] = plagiarism.Fingerprint(submission.Code, submission.Language)
+		}
+
+		for i := range candidates {
+			for j := i + 1; j < len(candidates); j++ {
+				if candidates[i].Language != candidates[j].Language {
+					continue
+				}
+				similarity := plagiarism.Similarity(fingerprints[i], fingerprints[j])
+				if similarity < suspiciousSimilarity {
+					continue
+				}
+
+				err = pw.plagiarismRepo.CreateCase(ctx, &domain.PlagiarismCase{
+					ID:            uuid.New().String(),
+					ContestID:     contest.Id,
+					ProblemID:     contestProblem.ProblemID,
+					SubmissionAID: candidates[i].UniqueID,
+					SubmissionBID: candidates[j].UniqueID,
+					UserAID:       candidates[i].UserId,
+					UserBID:       candidates[j].UserId,
+					Similarity:    similarity,
+					Status:        domain.PlagiarismStatusSuspected,
+				})
+				if err != nil {
+					return err
+				}
+				found++
+			}
+		}
+	}
+
+	log.Printf("Plagiarism check for contest %s found %d suspicious pairs", contest.Id, found)
+	return pw.contestRepo.SetPlagiarismCheckedAt(ctx, contest.Id, time.Now())
+}
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"algoforces/internal/domain"
+	"context"
+	"log"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+type ProblemPublishWorker struct {
+	problemRepo domain.ProblemRepository
+}
+
+func NewProblemPublishWorker(problemRepo domain.ProblemRepository) *ProblemPublishWorker {
+	return &ProblemPublishWorker{
+		problemRepo: problemRepo,
+	}
+}
+
+// PublishEndedContestProblems moves the contest-only problems whose contests
+// all ended to the practice archive
+func (pw *ProblemPublishWorker) PublishEndedContestProblems(ctx context.Context, task *asynq.Task) error {
+	published, err := pw.problemRepo.PublishEndedContestProblems(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if published > 0 {
+		log.Printf("Published %d problems of ended contests", published)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package worker
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/judge0"
+	"algoforces/pkg/queue"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+type ReferenceSolutionWorker struct {
+	solutionRepo domain.ReferenceSolutionRepository
+	problemRepo  domain.ProblemRepository
+	revisionRepo domain.ProblemRevisionRepository
+	testCaseRepo domain.TestCaseRepository
+	Judge0Client *judge0.Judge0Client
+}
+
+func NewReferenceSolutionWorker(solutionRepo domain.ReferenceSolutionRepository, problemRepo domain.ProblemRepository, revisionRepo domain.ProblemRevisionRepository, testCaseRepo domain.TestCaseRepository, judge0URL string) *ReferenceSolutionWorker {
+	return &ReferenceSolutionWorker{
+		solutionRepo: solutionRepo,
+		problemRepo:  problemRepo,
+		revisionRepo: revisionRepo,
+		testCaseRepo: testCaseRepo,
+		Judge0Client: judge0.NewClient(judge0URL),
+	}
+}
+
+// JudgeSolution runs a reference solution against every test case of its
+// problem, in order, until one fails. Runs superseded by a newer run of the
+// same solution are skipped.
+func (rw *ReferenceSolutionWorker) JudgeSolution(ctx context.Context, task *asynq.Task) error {
+	var payload queue.ReferenceSolutionPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return err
+	}
+
+	solution, err := rw.solutionRepo.GetSolution(ctx, payload.SolutionID)
+	if err != nil {
+		// Deleted since it was queued
+		log.Printf("Skipping reference solution %s: %v", payload.SolutionID, err)
+		return nil
+	}
+	current, err := rw.solutionRepo.UpdateRunStatus(ctx, solution.ID, payload.RunID, domain.ReferenceRunRunning)
+	if err != nil {
+		return err
+	}
+	if !current {
+		log.Printf("Skipping outdated run %s of reference solution %s", payload.RunID, solution.ID)
+		return nil
+	}
+
+	problem, err := rw.problemRepo.GetProblemByID(ctx, solution.ProblemID)
+	if err != nil {
+		return err
+	}
+	timeLimit, memoryLimit := problem.TimeLimitInSeconds, problem.MemoryLimitInMB
+
+	// Judged like submissions, against the latest revision of the problem
+	var revisionID *string
+	revision, err := rw.revisionRepo.GetLatestRevision(ctx, problem.UniqueID)
+	if err == nil {
+		revisionID = &revision.ID
+		timeLimit, memoryLimit = revision.TimeLimitInSeconds, revision.MemoryLimitInMB
+	}
+
+	testCases, err := rw.testCaseRepo.GetTestCasesByProblemID(ctx, problem.UniqueID)
+	if err != nil {
+		return err
+	}
+
+	languageID, err := judge0.GetLanguageID(solution.Language)
+	if err != nil {
+		return err
+	}
+
+	verdict := domain.VerdictAccepted
+	passedTests := 0
+	var testResults []string
+	var maxTime float64
+	var maxMemory int
+	for i, testCase := range testCases {
+		log.Printf("Running test case %d/%d for reference solution %s", i+1, len(testCases), solution.ID)
+
+		status, err := runTestCase(rw.Judge0Client, solution.Code, languageID, *testCase, timeLimit, memoryLimit)
+		if err != nil {
+			return err
+		}
+
+		testResult := fmt.Sprintf("Test %d (%s): %s", i+1, testCase.UniqueID, mapJudge0Status(status.Status.ID))
+		if status.Time != nil {
+			if timeVal, err := strconv.ParseFloat(*status.Time, 64); err == nil {
+				timeInMS := timeVal * 1000.0
+				if timeInMS > maxTime {
+					maxTime = timeInMS
+				}
+				testResult += fmt.Sprintf(" | Time: %.2fms", timeInMS)
+			}
+		}
+		if status.Memory != nil {
+			if *status.Memory > maxMemory {
+				maxMemory = *status.Memory
+			}
+			testResult += fmt.Sprintf(" | Memory: %dKB", *status.Memory)
+		}
+		if status.CompileOutput != nil && *status.CompileOutput != "" {
+			testResult += fmt.Sprintf(" | Compile: %s", *status.CompileOutput)
+		}
+		testResults = append(testResults, testResult)
+
+		if testVerdict := mapJudge0Status(status.Status.ID); testVerdict != domain.VerdictAccepted {
+			verdict = testVerdict
+			break
+		}
+		passedTests++
+	}
+
+	now := time.Now()
+	expectationMet := solution.ExpectsVerdict(verdict)
+	solution.RunID = payload.RunID
+	solution.ProblemRevisionID = revisionID
+	solution.Verdict = string(verdict)
+	solution.TestCasesPassed = passedTests
+	solution.TotalTestCases = len(testCases)
+	solution.MaxTimeInMS = maxTime
+	solution.MaxMemoryInKB = maxMemory
+	solution.TestCaseResults = testResults
+	solution.ExpectationMet = &expectationMet
+	solution.JudgedAt = &now
+
+	saved, err := rw.solutionRepo.SaveRunResult(ctx, solution)
+	if err != nil {
+		return fmt.Errorf("failed to save reference solution result: %w", err)
+	}
+	if !saved {
+		log.Printf("Dropping result of outdated run %s of reference solution %s", payload.RunID, solution.ID)
+		return nil
+	}
+
+	log.Printf("Reference solution %s (%s) completed with verdict: %s (%d/%d tests passed)",
+		solution.ID, solution.Kind, verdict, passedTests, len(testCases))
+	return nil
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// testDataCache fetches test data from the shared blob storage and keeps a
+// copy on the local disk of the worker. Content never changes under a hash,
+// so cached copies are never stale.
+type testDataCache struct {
+	local  *storage.ContentStore
+	shared *storage.ContentStore
+}
+
+func newTestDataCache(local *storage.ContentStore, shared *storage.ContentStore) *testDataCache {
+	return &testDataCache{
+		local:  local,
+		shared: shared,
+	}
+}
+
+// get returns the content stored under a hash
+func (tc *testDataCache) get(ctx context.Context, hash string) (string, error) {
+	content, err := tc.local.Get(ctx, hash)
+	if err == nil {
+		return string(content), nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		// A corrupted copy is dropped and fetched again
+		log.Printf("Dropping cached test data %s: %v", hash, err)
+		if err := tc.local.Delete(ctx, hash); err != nil {
+			return "", err
+		}
+	}
+
+	content, err = tc.shared.Get(ctx, hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch test data %s: %w", hash, err)
+	}
+	if _, err := tc.local.Put(ctx, content); err != nil {
+		log.Printf("Failed to cache test data %s: %v", hash, err)
+	}
+	return string(content), nil
+}
+
+// hydrate loads the input and expected output of a test case
+func (tc *testDataCache) hydrate(ctx context.Context, testCase *domain.TestCase) error {
+	input, err := tc.get(ctx, testCase.InputHash)
+	if err != nil {
+		return err
+	}
+	output, err := tc.get(ctx, testCase.OutputHash)
+	if err != nil {
+		return err
+	}
+
+	testCase.Input = input
+	testCase.ExpectedOutput = output
+	return nil
+}
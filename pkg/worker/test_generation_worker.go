@@ -0,0 +1,189 @@
+package worker
+
+import (
+	"algoforces/internal/domain"
+	"algoforces/pkg/judge0"
+	"algoforces/pkg/queue"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// Limits of a generator run
+const (
+	generatorTimeLimitInSeconds = 10
+	generatorMemoryLimitInMB    = 512
+)
+
+type TestGenerationWorker struct {
+	generatorRepo   domain.TestGeneratorRepository
+	problemRepo     domain.ProblemRepository
+	solutionRepo    domain.ReferenceSolutionRepository
+	testCaseUseCase domain.TestCaseUseCase
+	Judge0Client    *judge0.Judge0Client
+}
+
+func NewTestGenerationWorker(generatorRepo domain.TestGeneratorRepository, problemRepo domain.ProblemRepository, solutionRepo domain.ReferenceSolutionRepository, testCaseUseCase domain.TestCaseUseCase, judge0URL string) *TestGenerationWorker {
+	return &TestGenerationWorker{
+		generatorRepo:   generatorRepo,
+		problemRepo:     problemRepo,
+		solutionRepo:    solutionRepo,
+		testCaseUseCase: testCaseUseCase,
+		Judge0Client:    judge0.NewClient(judge0URL),
+	}
+}
+
+// GenerateTests runs the generator script of the generation in the task
+// payload and stores the tests it produces. Nothing is stored unless every
+// invocation succeeds.
+func (gw *TestGenerationWorker) GenerateTests(ctx context.Context, task *asynq.Task) error {
+	var payload queue.TestGenerationPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return err
+	}
+
+	generation, err := gw.generatorRepo.GetGeneration(ctx, payload.GenerationID)
+	if err != nil {
+		return err
+	}
+	if generation.Status != domain.TestGenerationPending {
+		log.Printf("Skipping test generation %s: %s", generation.ID, generation.Status)
+		return nil
+	}
+
+	generation.Status = domain.TestGenerationRunning
+	err = gw.generatorRepo.UpdateGeneration(ctx, generation)
+	if err != nil {
+		return err
+	}
+
+	tests, err := gw.generate(ctx, generation)
+	if err == nil {
+		err = gw.testCaseUseCase.StoreGeneratedTests(ctx, generation.ProblemID, tests, generation.Replace, generation.CreatedBy)
+	}
+	if err != nil {
+		log.Printf("Test generation %s failed: %v", generation.ID, err)
+		generation.Status = domain.TestGenerationFailed
+		generation.Error = err.Error()
+	} else {
+		log.Printf("Test generation %s produced %d tests", generation.ID, len(tests))
+		generation.Status = domain.TestGenerationDone
+		generation.TestCount = len(tests)
+	}
+	return gw.generatorRepo.UpdateGeneration(ctx, generation)
+}
+
+// generate runs every invocation of the script, then the main solution on
+// the generated input
+func (gw *TestGenerationWorker) generate(ctx context.Context, generation *domain.TestGeneration) ([]domain.GeneratedTest, error) {
+	invocations, err := domain.ParseGeneratorScript(generation.Script)
+	if err != nil {
+		return nil, err
+	}
+
+	problem, err := gw.problemRepo.GetProblemByID(ctx, generation.ProblemID)
+	if err != nil {
+		return nil, errors.New("problem not found")
+	}
+
+	generators, err := gw.generatorRepo.GetGenerators(ctx, problem.UniqueID)
+	if err != nil {
+		return nil, err
+	}
+	generatorsByName := map[string]domain.ProblemGenerator{}
+	for _, generator := range generators {
+		generatorsByName[generator.Name] = generator
+	}
+
+	solutions, err := gw.solutionRepo.GetSolutions(ctx, problem.UniqueID)
+	if err != nil {
+		return nil, err
+	}
+	var main *domain.ReferenceSolution
+	for i := range solutions {
+		if solutions[i].Kind == domain.ReferenceSolutionMain {
+			main = &solutions[i]
+		}
+	}
+	if main == nil {
+		return nil, errors.New("the problem has no main solution")
+	}
+	mainLanguageID, err := judge0.GetLanguageID(main.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	tests := make([]domain.GeneratedTest, 0, len(invocations))
+	for i, invocation := range invocations {
+		log.Printf("Running generator invocation %d/%d of test generation %s", i+1, len(invocations), generation.ID)
+
+		generator, ok := generatorsByName[invocation.Generator]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown generator %s", invocation, invocation.Generator)
+		}
+		languageID, err := judge0.GetLanguageID(generator.Language)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", invocation, err)
+		}
+
+		status, err := gw.Judge0Client.Run(&judge0.SubmissionRequest{
+			SourceCode:           generator.Source,
+			LanguageID:           languageID,
+			CommandLineArguments: invocation.Arguments,
+			CPUTimeLimit:         generatorTimeLimitInSeconds,
+			MemoryLimit:          generatorMemoryLimitInMB * 1024,
+		}, (generatorTimeLimitInSeconds+30)*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		if status.Status.ID != judge0.StatusAccepted {
+			return nil, fmt.Errorf("%s: generator failed: %s", invocation, describeFailure(status))
+		}
+		input := ""
+		if status.Stdout != nil {
+			input = *status.Stdout
+		}
+
+		status, err = gw.Judge0Client.Run(&judge0.SubmissionRequest{
+			SourceCode:   main.Code,
+			LanguageID:   mainLanguageID,
+			Stdin:        input,
+			CPUTimeLimit: float64(problem.TimeLimitInSeconds),
+			MemoryLimit:  problem.MemoryLimitInMB * 1024,
+		}, time.Duration(problem.TimeLimitInSeconds+30)*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		if status.Status.ID != judge0.StatusAccepted {
+			return nil, fmt.Errorf("%s: main solution failed: %s", invocation, describeFailure(status))
+		}
+		output := ""
+		if status.Stdout != nil {
+			output = *status.Stdout
+		}
+
+		tests = append(tests, domain.GeneratedTest{
+			Invocation:     invocation.String(),
+			Input:          input,
+			ExpectedOutput: output,
+		})
+	}
+	return tests, nil
+}
+
+// describeFailure returns the Judge0 status of a failed run with its error output
+func describeFailure(status *judge0.SubmissionStatus) string {
+	description := status.Status.Description
+	for _, output := range []*string{status.CompileOutput, status.Stderr, status.Message} {
+		if output != nil && strings.TrimSpace(*output) != "" {
+			return description + ": " + strings.TrimSpace(*output)
+		}
+	}
+	return description
+}
@@ -0,0 +1,147 @@
+package worker
+
+import (
+	"algoforces/internal/domain"
+	"container/list"
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+// errTestSetChanged is returned for a revision recorded without its tests
+// when the test cases of its problem no longer match it
+var errTestSetChanged = errors.New("the test set changed since the revision")
+
+// cachedTestSet is the test cases of a problem at one revision of its test
+// set, in judging order with their data loaded
+type cachedTestSet struct {
+	problemID string
+	hash      string // TestSetHash of the revisions the tests belong to
+	testCases []domain.TestCase
+	size      int64
+}
+
+// testSetCache keeps the test sets of recently judged problems in memory,
+// least recently used first out once their data exceeds maxBytes. Only the
+// last test set loaded for a problem is kept.
+type testSetCache struct {
+	submissionRepo domain.SubmissionRepository
+	revisionRepo   domain.ProblemRevisionRepository
+	testData       *testDataCache
+	maxBytes       int64
+
+	mu      sync.Mutex
+	size    int64
+	order   *list.List               // of *cachedTestSet, most recently used first
+	entries map[string]*list.Element // by problem ID
+}
+
+func newTestSetCache(submissionRepo domain.SubmissionRepository, revisionRepo domain.ProblemRevisionRepository, testData *testDataCache, maxBytes int64) *testSetCache {
+	return &testSetCache{
+		submissionRepo: submissionRepo,
+		revisionRepo:   revisionRepo,
+		testData:       testData,
+		maxBytes:       maxBytes,
+		order:          list.New(),
+		entries:        map[string]*list.Element{},
+	}
+}
+
+// get returns the test cases of a revision in judging order. The tests
+// stored with the revision are loaded with their data from the blob storage
+// when they are not cached. Revisions recorded without their tests fall back
+// to the current test cases, errTestSetChanged is returned when those changed
+// since the revision.
+func (c *testSetCache) get(ctx context.Context, revision *domain.ProblemRevision) ([]domain.TestCase, error) {
+	if testCases, ok := c.lookup(revision.ProblemID, revision.TestSetHash); ok {
+		return testCases, nil
+	}
+
+	revisionTests, err := c.revisionRepo.GetRevisionTests(ctx, revision.ID)
+	if err != nil {
+		return nil, err
+	}
+	var testCases []domain.TestCase
+	if len(revisionTests) > 0 || revision.TestCount == 0 {
+		for _, test := range revisionTests {
+			testCases = append(testCases, test.TestCase(revision.ProblemID))
+		}
+	} else {
+		testCases, err = c.submissionRepo.GetAllTestCasesForProblem(ctx, revision.ProblemID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if domain.HashTestSet(testCases) != revision.TestSetHash {
+		return nil, errTestSetChanged
+	}
+
+	testCases = domain.OrderTestCases(testCases)
+	size := int64(0)
+	for i := range testCases {
+		err = c.testData.hydrate(ctx, &testCases[i])
+		if err != nil {
+			return nil, err
+		}
+		size += testCases[i].InputSize + testCases[i].OutputSize
+	}
+
+	c.store(&cachedTestSet{
+		problemID: revision.ProblemID,
+		hash:      revision.TestSetHash,
+		testCases: testCases,
+		size:      size,
+	})
+	return testCases, nil
+}
+
+// invalidate drops the cached test set of a problem
+func (c *testSetCache) invalidate(problemID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[problemID]; ok {
+		c.remove(element)
+		log.Printf("Dropped the cached test set of problem %s", problemID)
+	}
+}
+
+func (c *testSetCache) lookup(problemID string, hash string) ([]domain.TestCase, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[problemID]
+	if !ok || element.Value.(*cachedTestSet).hash != hash {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cachedTestSet).testCases, true
+}
+
+func (c *testSetCache) store(testSet *cachedTestSet) {
+	// Test sets larger than the whole cache are not kept
+	if testSet.size > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[testSet.problemID]; ok {
+		c.remove(element)
+	}
+	c.entries[testSet.problemID] = c.order.PushFront(testSet)
+	c.size += testSet.size
+
+	for c.size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove drops a cache entry, c.mu must be held
+func (c *testSetCache) remove(element *list.Element) {
+	testSet := c.order.Remove(element).(*cachedTestSet)
+	delete(c.entries, testSet.problemID)
+	c.size -= testSet.size
+}